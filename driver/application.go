@@ -16,14 +16,16 @@
 
 package driver
 
+import "context"
+
 //go:generate mockgen -source application.go -destination application_mock.go -package driver
 
 // Application is an abstraction of an application running on a Norma net.
 type Application interface {
 	// Start begins producing load on the network as configured for this app.
-	Start() error
+	Start(ctx context.Context) error
 	// Stop terminates the load production.
-	Stop() error
+	Stop(ctx context.Context) error
 
 	// Config returns current application configuration.
 	Config() *ApplicationConfig
@@ -37,5 +39,10 @@ type Application interface {
 
 	// GetReceivedTransactions returns the number fo transactions received by the appliation
 	// on the network.
-	GetReceivedTransactions() (uint64, error)
+	GetReceivedTransactions(ctx context.Context) (uint64, error)
+
+	// GetReceivedTransactionsByComponent returns the number of transactions received by
+	// each application type of an application mixing several types, or nil if the
+	// application is of a single type.
+	GetReceivedTransactionsByComponent(ctx context.Context) (map[string]uint64, error)
 }
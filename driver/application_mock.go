@@ -26,6 +26,7 @@
 package driver
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -83,18 +84,33 @@ func (mr *MockApplicationMockRecorder) GetNumberOfUsers() *gomock.Call {
 }
 
 // GetReceivedTransactions mocks base method.
-func (m *MockApplication) GetReceivedTransactions() (uint64, error) {
+func (m *MockApplication) GetReceivedTransactions(ctx context.Context) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReceivedTransactions")
+	ret := m.ctrl.Call(m, "GetReceivedTransactions", ctx)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetReceivedTransactions indicates an expected call of GetReceivedTransactions.
-func (mr *MockApplicationMockRecorder) GetReceivedTransactions() *gomock.Call {
+func (mr *MockApplicationMockRecorder) GetReceivedTransactions(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactions))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactions), ctx)
+}
+
+// GetReceivedTransactionsByComponent mocks base method.
+func (m *MockApplication) GetReceivedTransactionsByComponent(ctx context.Context) (map[string]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedTransactionsByComponent", ctx)
+	ret0, _ := ret[0].(map[string]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedTransactionsByComponent indicates an expected call of GetReceivedTransactionsByComponent.
+func (mr *MockApplicationMockRecorder) GetReceivedTransactionsByComponent(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactionsByComponent", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactionsByComponent), ctx)
 }
 
 // GetSentTransactions mocks base method.
@@ -113,29 +129,29 @@ func (mr *MockApplicationMockRecorder) GetSentTransactions(user any) *gomock.Cal
 }
 
 // Start mocks base method.
-func (m *MockApplication) Start() error {
+func (m *MockApplication) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Start")
+	ret := m.ctrl.Call(m, "Start", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Start indicates an expected call of Start.
-func (mr *MockApplicationMockRecorder) Start() *gomock.Call {
+func (mr *MockApplicationMockRecorder) Start(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockApplication)(nil).Start))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockApplication)(nil).Start), ctx)
 }
 
 // Stop mocks base method.
-func (m *MockApplication) Stop() error {
+func (m *MockApplication) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Stop")
+	ret := m.ctrl.Call(m, "Stop", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Stop indicates an expected call of Stop.
-func (mr *MockApplicationMockRecorder) Stop() *gomock.Call {
+func (mr *MockApplicationMockRecorder) Stop(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockApplication)(nil).Stop))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockApplication)(nil).Stop), ctx)
 }
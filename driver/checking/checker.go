@@ -17,9 +17,12 @@
 package checking
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/parser"
 )
 
 // Checker do the network consistency check at the end of the scenario.
@@ -27,14 +30,43 @@ type Checker interface {
 	Check(net driver.Network) error
 }
 
-func CheckNetworkConsistency(net driver.Network) error {
+// CheckNetworkConsistency runs all checkers, including the given scenario
+// specific ones, on the nodes of the network covered by the given selection.
+func CheckNetworkConsistency(ctx context.Context, net driver.Network, selection parser.NodeSelection, extra ...Checker) error {
+	selected, err := driver.SelectNodes(ctx, net, net.GetActiveNodes(), selection)
+	if err != nil {
+		return fmt.Errorf("failed to select nodes to check; %v", err)
+	}
+	// Observers do not offer the APIs required for checking their state.
+	nodes := make([]driver.Node, 0, len(selected))
+	for _, node := range selected {
+		if node.GetType() != driver.ObserverNode {
+			nodes = append(nodes, node)
+		}
+	}
+	if skipped := len(selected) - len(nodes); skipped > 0 {
+		fmt.Printf("skipping checks for %d observer node(s)\n", skipped)
+	}
+	net = &selectedNetwork{Network: net, nodes: nodes}
+
 	checkers := []Checker{
 		new(BlockHeightChecker),
 		new(BlocksHashesChecker),
 	}
+	checkers = append(checkers, extra...)
 	errs := make([]error, len(checkers))
 	for i, checker := range checkers {
 		errs[i] = checker.Check(net)
 	}
 	return errors.Join(errs...)
 }
+
+// selectedNetwork restricts the active nodes of a network to a selection.
+type selectedNetwork struct {
+	driver.Network
+	nodes []driver.Node
+}
+
+func (n *selectedNetwork) GetActiveNodes() []driver.Node {
+	return n.nodes
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RulesUpdate records an update of the network rules submitted during a run.
+type RulesUpdate struct {
+	Name  string
+	Rules driver.NetworkRules
+	Epoch uint64 // the epoch current at the time the update was submitted
+}
+
+// NetworkRulesChecker is a Checker checking that all nodes apply each of the
+// submitted rule updates starting with the same epoch.
+type NetworkRulesChecker struct {
+	Updates []RulesUpdate
+}
+
+func (c *NetworkRulesChecker) Check(net driver.Network) (err error) {
+	if len(c.Updates) == 0 {
+		return nil
+	}
+	nodes := net.GetActiveNodes()
+	fmt.Printf("checking %d network rule update(s) for %d nodes\n", len(c.Updates), len(nodes))
+	rpcClients := make([]rpc.RpcClient, len(nodes))
+	for i, n := range nodes {
+		rpcClients[i], err = n.DialRpc()
+		if err != nil {
+			return fmt.Errorf("failed to dial RPC for node %s; %v", n.GetLabel(), err)
+		}
+	}
+	defer func() {
+		for _, rpcClient := range rpcClients {
+			rpcClient.Close()
+		}
+	}()
+
+	for _, update := range c.Updates {
+		var reference *uint64
+		for i, n := range nodes {
+			epoch, err := getFirstEpochWithRules(rpcClients[i], update)
+			if err != nil {
+				return fmt.Errorf("failed to check rules update %s at node %s; %v", update.Name, n.GetLabel(), err)
+			}
+			if reference == nil {
+				reference = &epoch
+			} else if *reference != epoch {
+				return fmt.Errorf("nodes apply rules update %s from different epochs, %d and %d", update.Name, *reference, epoch)
+			}
+		}
+	}
+	return nil
+}
+
+// getFirstEpochWithRules obtains the first epoch since the submission of the
+// given update in which the node applies the updated rules.
+func getFirstEpochWithRules(rpcClient rpc.RpcClient, update RulesUpdate) (uint64, error) {
+	expected, err := normalize(update.Rules)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode rules; %v", err)
+	}
+	current, err := GetCurrentEpoch(rpcClient)
+	if err != nil {
+		return 0, err
+	}
+	for epoch := update.Epoch; epoch <= current; epoch++ {
+		var rules any
+		if err := rpcClient.Call(&rules, "eth_getRules", hexutil.EncodeUint64(epoch)); err != nil {
+			return 0, fmt.Errorf("failed to get rules of epoch %d; %v", epoch, err)
+		}
+		if containsRules(rules, expected) {
+			return epoch, nil
+		}
+	}
+	return 0, fmt.Errorf("updated rules not applied in epochs %d to %d", update.Epoch, current)
+}
+
+// GetCurrentEpoch obtains the current epoch of the node behind the given client.
+func GetCurrentEpoch(rpcClient rpc.RpcClient) (uint64, error) {
+	var epoch hexutil.Uint64
+	if err := rpcClient.Call(&epoch, "eth_currentEpoch"); err != nil {
+		return 0, fmt.Errorf("failed to get current epoch; %v", err)
+	}
+	return uint64(epoch), nil
+}
+
+// normalize converts the given rules into the representation obtained when
+// decoding rules from JSON, making them comparable to rules fetched via RPC.
+func normalize(rules driver.NetworkRules) (any, error) {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	var res any
+	return res, json.Unmarshal(data, &res)
+}
+
+// containsRules checks whether all fields of the expected (partial) rules
+// are present in the given rules with the same values.
+func containsRules(rules, expected any) bool {
+	expectedFields, ok := expected.(map[string]any)
+	if !ok {
+		return reflect.DeepEqual(rules, expected)
+	}
+	fields, ok := rules.(map[string]any)
+	if !ok {
+		return false
+	}
+	for key, value := range expectedFields {
+		if !containsRules(fields[key], value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/mock/gomock"
+)
+
+// mockRulesRpc sets up the given client to report the given current epoch
+// and the updated rules from the given epoch on.
+func mockRulesRpc(client *rpc.MockRpcClient, current, updatedFrom uint64) {
+	client.EXPECT().Call(gomock.Any(), "eth_currentEpoch").DoAndReturn(func(result any, method string, args ...any) error {
+		*result.(*hexutil.Uint64) = hexutil.Uint64(current)
+		return nil
+	}).AnyTimes()
+	client.EXPECT().Call(gomock.Any(), "eth_getRules", gomock.Any()).DoAndReturn(func(result any, method string, args ...any) error {
+		epoch, err := hexutil.DecodeUint64(args[0].(string))
+		if err != nil {
+			return err
+		}
+		gas := 1000.0
+		if epoch >= updatedFrom {
+			gas = 2000.0
+		}
+		*result.(*any) = map[string]any{
+			"Blocks":  map[string]any{"MaxBlockGas": gas, "MaxEmptyBlockSkipPeriod": 1.0},
+			"Economy": map[string]any{"MinGasPrice": 1.0},
+		}
+		return nil
+	}).AnyTimes()
+	client.EXPECT().Close()
+}
+
+func TestNetworkRulesChecker_UpdatesAppliedFromSameEpochAreAccepted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node1 := driver.NewMockNode(ctrl)
+	node2 := driver.NewMockNode(ctrl)
+	rpc1 := rpc.NewMockRpcClient(ctrl)
+	rpc2 := rpc.NewMockRpcClient(ctrl)
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node1, node2})
+	node1.EXPECT().DialRpc().Return(rpc1, nil)
+	node2.EXPECT().DialRpc().Return(rpc2, nil)
+	mockRulesRpc(rpc1, 8, 5)
+	mockRulesRpc(rpc2, 9, 5)
+
+	checker := NetworkRulesChecker{Updates: []RulesUpdate{{
+		Name:  "gas",
+		Rules: driver.NetworkRules{"Blocks": map[string]any{"MaxBlockGas": 2000}},
+		Epoch: 4,
+	}}}
+	if err := checker.Check(net); err != nil {
+		t.Errorf("unexpected error from NetworkRulesChecker: %v", err)
+	}
+}
+
+func TestNetworkRulesChecker_UpdatesAppliedFromDifferentEpochsAreDetected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node1 := driver.NewMockNode(ctrl)
+	node2 := driver.NewMockNode(ctrl)
+	rpc1 := rpc.NewMockRpcClient(ctrl)
+	rpc2 := rpc.NewMockRpcClient(ctrl)
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node1, node2})
+	node1.EXPECT().DialRpc().Return(rpc1, nil)
+	node2.EXPECT().DialRpc().Return(rpc2, nil)
+	mockRulesRpc(rpc1, 8, 5)
+	mockRulesRpc(rpc2, 8, 6)
+
+	checker := NetworkRulesChecker{Updates: []RulesUpdate{{
+		Name:  "gas",
+		Rules: driver.NetworkRules{"Blocks": map[string]any{"MaxBlockGas": 2000}},
+		Epoch: 4,
+	}}}
+	err := checker.Check(net)
+	if err == nil || !strings.Contains(err.Error(), "from different epochs, 5 and 6") {
+		t.Errorf("diverging rules were not detected, got %v", err)
+	}
+}
+
+func TestNetworkRulesChecker_MissingUpdateIsDetected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node := driver.NewMockNode(ctrl)
+	client := rpc.NewMockRpcClient(ctrl)
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	node.EXPECT().DialRpc().Return(client, nil)
+	node.EXPECT().GetLabel().Return("A").AnyTimes()
+	mockRulesRpc(client, 8, 10)
+
+	checker := NetworkRulesChecker{Updates: []RulesUpdate{{
+		Name:  "gas",
+		Rules: driver.NetworkRules{"Blocks": map[string]any{"MaxBlockGas": 2000}},
+		Epoch: 4,
+	}}}
+	err := checker.Check(net)
+	if err == nil || !strings.Contains(err.Error(), "not applied in epochs 4 to 8") {
+		t.Errorf("missing update was not detected, got %v", err)
+	}
+}
+
+func TestContainsRules_ComparesNestedFields(t *testing.T) {
+	rules := map[string]any{"Blocks": map[string]any{"MaxBlockGas": 1.0, "Other": 2.0}}
+	tests := []struct {
+		expected any
+		want     bool
+	}{
+		{map[string]any{}, true},
+		{map[string]any{"Blocks": map[string]any{"MaxBlockGas": 1.0}}, true},
+		{map[string]any{"Blocks": map[string]any{"MaxBlockGas": 2.0}}, false},
+		{map[string]any{"Blocks": map[string]any{"Missing": 2.0}}, false},
+		{map[string]any{"Economy": map[string]any{"MinGasPrice": 1.0}}, false},
+	}
+	for _, test := range tests {
+		if got := containsRules(rules, test.expected); got != test.want {
+			t.Errorf("containsRules(%v) = %t, wanted %t", test.expected, got, test.want)
+		}
+	}
+}
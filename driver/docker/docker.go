@@ -17,18 +17,29 @@
 package docker
 
 import (
+	"archive/tar"
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math/rand"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 )
@@ -48,7 +59,9 @@ var Sigterm Signal = "SIGTERM"
 // Client provides means to spawn Docker containers capable of hosting
 // services like the go-opera client.
 type Client struct {
-	cli *client.Client
+	cli         *client.Client
+	address     string // the address under which ports forwarded by the Docker host are reachable
+	bindAddress string // the host interface forwarded ports are bound to
 }
 
 // Network represents a Docker network. It is used to connect Containers
@@ -67,7 +80,8 @@ type Container struct {
 	id      string
 	client  *Client
 	config  *ContainerConfig
-	stopped bool
+	ports   map[network.Port]network.Port // Container Port => Host Port, assigned by Docker
+	stopped atomic.Bool                   // set before the container is stopped, read concurrently by IsRunning
 	cleaned bool
 }
 
@@ -75,56 +89,138 @@ type Container struct {
 type ContainerConfig struct {
 	ImageName       string
 	ShutdownTimeout *time.Duration
-	PortForwarding  map[network.Port]network.Port // Container Port => Host Port
+	ExportedPorts   []network.Port // container ports to be forwarded to host ports assigned by Docker
 	Environment     map[string]string
-	Entrypoint      []string // Entrypoint to run when starting the container. Optional.
-	Network         *Network // Docker network to join, nil to join bridge network
-	MountDatadir    *string  // mount client datadir to this path on host
-	MountGenesis    *string  // mount client genesis to this path on host
+	Entrypoint      []string               // Entrypoint to run when starting the container. Optional.
+	Network         *Network               // Docker network to join, nil to join bridge network
+	MountDatadir    *string                // mount client datadir to this path on host
+	MountGenesis    *string                // mount client genesis to this path on host
+	Resources       network.ResourceLimits // limits of host resources, zero for none
+	Files           map[string][]byte      // absolute path in container => content, copied before start
+	Mounts          []Mount                // host paths to be made available in the container
+}
+
+// Mount makes a file or directory of the host available within a container.
+type Mount struct {
+	Source   string // absolute path on the host
+	Target   string // absolute path in the container
+	ReadOnly bool
 }
 
 // NewClient creates a new client facilitating the creation of Docker
-// Containers capable of hosting services. Clients successfully created
-// through this function should be Closed() eventually.
+// Containers capable of hosting services. The client connects to the
+// Docker host configured by the environment, e.g. through DOCKER_HOST.
+// Clients successfully created through this function should be Closed()
+// eventually.
 func NewClient() (*Client, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	return NewClientForHost("", "", "")
+}
+
+// NewClientForHost creates a new client connected to the Docker host at the
+// given URL, e.g. tcp://10.0.0.2:2375. If the URL is empty, the host is
+// configured by the environment. TLS settings are always taken from the
+// environment, as done by the docker CLI. Ports forwarded by the host are
+// reached through the given address, which is derived from the URL if
+// empty. They are bound to the given bind address on the host, see
+// getBindAddress for the default. Clients successfully created through this
+// function should be Closed() eventually.
+func NewClientForHost(host, address, bindAddress string) (*Client, error) {
+	opts := []client.Opt{client.FromEnv}
+	if host != "" {
+		opts = append(opts, client.WithHost(host))
+	}
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{cli}, nil
+	if address == "" {
+		address = getForwardingAddress(cli.DaemonHost())
+	}
+	bindAddress, err = getBindAddress(address, bindAddress)
+	if err != nil {
+		return nil, errors.Join(err, cli.Close())
+	}
+	return &Client{cli: cli, address: address, bindAddress: bindAddress}, nil
+}
+
+// localhost is the address of ports forwarded by a Docker host running on
+// the same machine as this process.
+const localhost = "localhost"
+
+// getForwardingAddress derives the address under which ports forwarded by
+// the Docker host at the given URL are reachable. Hosts connected through
+// a socket run on the local machine.
+func getForwardingAddress(host string) string {
+	parsed, err := url.Parse(host)
+	if err != nil || parsed.Hostname() == "" {
+		return localhost
+	}
+	switch parsed.Scheme {
+	case "tcp", "http", "https":
+		return parsed.Hostname()
+	}
+	return localhost
+}
+
+// getBindAddress determines the interface of a Docker host that forwarded
+// ports are bound to. Ports of local hosts are only forwarded on the loopback
+// interface. Ports of remote hosts are forwarded on the interface of the
+// address they are reached through, unless a bind address is configured,
+// which is required if this address is not an IP address, e.g. a host name.
+func getBindAddress(address, bindAddress string) (string, error) {
+	if bindAddress != "" {
+		if net.ParseIP(bindAddress) == nil {
+			return "", fmt.Errorf("invalid bind address %q, expected an IP address", bindAddress)
+		}
+		return bindAddress, nil
+	}
+	if address == localhost {
+		return "127.0.0.1", nil
+	}
+	if net.ParseIP(address) == nil {
+		return "", fmt.Errorf("no bind address configured for ports reached through %s", address)
+	}
+	return address, nil
 }
 
-// Purge removes all Docker objects created by norma.
-func Purge() error {
+// Purge removes all Docker objects created by norma on the Docker host
+// configured by the environment.
+func Purge(ctx context.Context) error {
 	cli, err := NewClient()
 	if err != nil {
 		return err
 	}
+	defer cli.Close()
+	return cli.Purge(ctx)
+}
 
+// Purge removes all Docker objects created by norma on the Docker host of
+// this client.
+func (c *Client) Purge(ctx context.Context) error {
 	// get all containers created by norma
-	containers, err := cli.listContainers()
+	containers, err := c.listContainers(ctx)
 	if err != nil {
 		return err
 	}
 
 	// remove all containers
-	for _, c := range containers {
+	for _, ct := range containers {
 		// remove the container
-		err = cli.cli.ContainerRemove(context.Background(), c.ID, container.RemoveOptions{Force: true})
+		err = c.cli.ContainerRemove(ctx, ct.ID, container.RemoveOptions{Force: true})
 		if err != nil {
 			return err
 		}
 	}
 
 	// get all networks created by norma
-	networks, err := cli.listNetworks()
+	networks, err := c.listNetworks(ctx)
 	if err != nil {
 		return err
 	}
 
 	// remove all networks
 	for _, n := range networks {
-		err = cli.cli.NetworkRemove(context.Background(), n.ID)
+		err = c.cli.NetworkRemove(ctx, n.ID)
 		if err != nil {
 			return err
 		}
@@ -141,24 +237,29 @@ func (c *Client) Close() error {
 // to configure the Docker image to run inside the container -- and thus the
 // services to be offered -- and port-forwarding specifications to make those
 // services reachable from outside the Docker container (e.g. by the
-// application running this code).
-func (c *Client) Start(config *ContainerConfig) (*Container, error) {
+// application running this code). If the context is cancelled before the
+// Container is running, the start is aborted and the Container removed.
+// Remote Docker hosts can not access paths of this machine, so read-only
+// mounts are copied into the Container instead and writable ones rejected.
+func (c *Client) Start(ctx context.Context, config *ContainerConfig) (*Container, error) {
+	mounts, copies := getMounts(config), []Mount{}
+	if c.isRemote() {
+		for _, m := range mounts {
+			if !m.ReadOnly {
+				return nil, fmt.Errorf("writable mount of %s is not supported by remote Docker host %s", m.Source, c.cli.DaemonHost())
+			}
+		}
+		mounts, copies = copies, mounts
+	}
+
 	envVars := []string{}
 	for key, value := range config.Environment {
 		envVars = append(envVars, fmt.Sprintf("%s=%s", key, value))
 	}
 
-	portMapping := nat.PortMap{}
-	for inner, outer := range config.PortForwarding {
-		portMapping[nat.Port(fmt.Sprintf("%d/tcp", inner))] = []nat.PortBinding{{
-			HostIP:   "localhost",
-			HostPort: fmt.Sprintf("%d/tcp", outer),
-		}}
-	}
-
 	init := true
 	stopTimeout := int(config.ShutdownTimeout.Seconds())
-	resp, err := c.cli.ContainerCreate(context.Background(), &container.Config{
+	resp, err := c.cli.ContainerCreate(ctx, &container.Config{
 		Image:      config.ImageName,
 		Tty:        false,
 		Env:        envVars,
@@ -168,9 +269,11 @@ func (c *Client) Start(config *ContainerConfig) (*Container, error) {
 		},
 		StopTimeout: &stopTimeout,
 	}, &container.HostConfig{
-		PortBindings: portMapping,
+		PortBindings: toPortBindings(config.ExportedPorts, c.bindAddress),
 		Init:         &init,
 		CapAdd:       []string{"NET_ADMIN"},
+		Resources:    toDockerResources(config.Resources),
+		Mounts:       toDockerMounts(mounts),
 	}, nil, nil, "")
 	if err != nil {
 		return nil, err
@@ -181,28 +284,243 @@ func (c *Client) Start(config *ContainerConfig) (*Container, error) {
 	// custom network at the same time (otherwise on network cleanup the
 	// forwarded ports would be lost)
 	if config.Network != nil {
-		err = c.cli.NetworkConnect(context.Background(), config.Network.id, resp.ID, nil)
+		err = c.cli.NetworkConnect(ctx, config.Network.id, resp.ID, nil)
 		if err != nil {
-			return nil, err
+			return nil, errors.Join(err, c.remove(ctx, resp.ID))
+		}
+	}
+
+	if len(config.Files) > 0 {
+		archive, err := toTarArchive(config.Files)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to pack files for container; %v", err), c.remove(ctx, resp.ID))
+		}
+		if err := c.cli.CopyToContainer(ctx, resp.ID, "/", archive, container.CopyToContainerOptions{}); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to copy files into container; %v", err), c.remove(ctx, resp.ID))
+		}
+	}
+
+	if len(copies) > 0 {
+		archive := toMountArchive(copies)
+		err := c.cli.CopyToContainer(ctx, resp.ID, "/", archive, container.CopyToContainerOptions{})
+		if err = errors.Join(err, archive.Close()); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to copy mounts into container; %v", err), c.remove(ctx, resp.ID))
 		}
 	}
 
-	if err := network.Retry(network.DefaultRetryAttempts, 1*time.Second, func() error {
-		return c.cli.ContainerStart(context.Background(), resp.ID, container.StartOptions{})
+	if err := network.RetryWithBackoff(ctx, network.DefaultBackoff, func() error {
+		return c.cli.ContainerStart(ctx, resp.ID, container.StartOptions{})
 	}); err != nil {
+		return nil, errors.Join(err, c.remove(ctx, resp.ID))
+	}
+
+	// Host ports are only assigned by Docker when the container is started.
+	res := &Container{id: resp.ID, client: c, config: config}
+	if len(config.ExportedPorts) > 0 {
+		info, err := c.cli.ContainerInspect(ctx, resp.ID)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to inspect container; %v", err), res.Cleanup(context.WithoutCancel(ctx)))
+		}
+		if info.NetworkSettings == nil {
+			return nil, errors.Join(fmt.Errorf("no network settings for container"), res.Cleanup(context.WithoutCancel(ctx)))
+		}
+		res.ports, err = fromPortMap(config.ExportedPorts, info.NetworkSettings.Ports)
+		if err != nil {
+			return nil, errors.Join(err, res.Cleanup(context.WithoutCancel(ctx)))
+		}
+	}
+	return res, nil
+}
+
+// remove removes the container with the given ID, used to clean up after a
+// failed start. It is not cancelled with the context of the start, which may
+// have been cancelled.
+func (c *Client) remove(ctx context.Context, id string) error {
+	return c.cli.ContainerRemove(context.WithoutCancel(ctx), id, container.RemoveOptions{Force: true})
+}
+
+// isRemote is true if the Docker host of the client runs on another machine
+// and can thus not access the file system of this machine.
+func (c *Client) isRemote() bool {
+	return isRemoteHost(c.cli.DaemonHost())
+}
+
+// isRemoteHost is true if the Docker host at the given URL is not reached
+// through a socket or the loopback interface of this machine.
+func isRemoteHost(host string) bool {
+	address := getForwardingAddress(host)
+	if address == localhost {
+		return false
+	}
+	ip := net.ParseIP(address)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// toPortBindings forwards the given container ports to host ports on the
+// interface with the given IP. Host ports are left empty such that Docker
+// assigns free ports, avoiding races between concurrently started containers.
+func toPortBindings(ports []network.Port, hostIP string) nat.PortMap {
+	res := nat.PortMap{}
+	for _, port := range ports {
+		res[nat.Port(fmt.Sprintf("%d/tcp", port))] = []nat.PortBinding{{
+			HostIP: hostIP,
+		}}
+	}
+	return res
+}
+
+// fromPortMap extracts the host ports assigned by Docker to the given
+// container ports.
+func fromPortMap(ports []network.Port, bindings nat.PortMap) (map[network.Port]network.Port, error) {
+	res := make(map[network.Port]network.Port, len(ports))
+	for _, port := range ports {
+		binding := bindings[nat.Port(fmt.Sprintf("%d/tcp", port))]
+		if len(binding) == 0 {
+			return nil, fmt.Errorf("no host port assigned to container port %d", port)
+		}
+		hostPort, err := strconv.ParseUint(binding[0].HostPort, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host port assigned to container port %d; %v", port, err)
+		}
+		res[port] = network.Port(hostPort)
+	}
+	return res, nil
+}
+
+// datadirPath is the location of the client's datadir within a container.
+const datadirPath = "/datadir"
+
+// getMounts lists the mounts of the given configuration, including the
+// client's datadir if requested.
+func getMounts(config *ContainerConfig) []Mount {
+	mounts := append([]Mount{}, config.Mounts...)
+	if config.MountDatadir != nil {
+		mounts = append(mounts, Mount{Source: *config.MountDatadir, Target: datadirPath})
+	}
+	return mounts
+}
+
+// toDockerMounts converts the given mounts into Docker's bind mounts.
+func toDockerMounts(mounts []Mount) []mount.Mount {
+	res := make([]mount.Mount, 0, len(mounts))
+	for _, m := range mounts {
+		res = append(res, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+	return res
+}
+
+// toTarArchive packs the given files, indexed by their absolute paths, into
+// a tar archive to be extracted at the root of a container's file system.
+func toTarArchive(files map[string][]byte) (io.Reader, error) {
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for path, content := range files {
+		header := &tar.Header{
+			Name: strings.TrimPrefix(path, "/"),
+			Mode: 0644,
+			Size: int64(len(content)),
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
 		return nil, err
 	}
+	return &buffer, nil
+}
 
-	return &Container{resp.ID, c, config, false, false}, nil
+// toMountArchive packs the host paths of the given mounts into a tar archive
+// placing them at their targets when extracted at the root of a container's
+// file system. The archive is streamed, such that large files like genesis
+// files are not held in memory. Closing the archive aborts the packing.
+func toMountArchive(mounts []Mount) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeMountArchive(writer, mounts))
+	}()
+	return reader
+}
+
+// writeMountArchive writes the tar archive of toMountArchive to out.
+func writeMountArchive(out io.Writer, mounts []Mount) error {
+	writer := tar.NewWriter(out)
+	for _, m := range mounts {
+		// Mounts follow symbolic links to their sources.
+		source, err := filepath.EvalSymlinks(m.Source)
+		if err != nil {
+			return fmt.Errorf("failed to pack %s; %w", m.Source, err)
+		}
+		err = filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			link := ""
+			if entry.Type()&fs.ModeSymlink != 0 {
+				if link, err = os.Readlink(path); err != nil {
+					return err
+				}
+			}
+			header, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			relative, err := filepath.Rel(source, path)
+			if err != nil {
+				return err
+			}
+			header.Name = strings.TrimPrefix(filepath.ToSlash(filepath.Join(m.Target, relative)), "/")
+			if err := writer.WriteHeader(header); err != nil {
+				return err
+			}
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(writer, file)
+			return errors.Join(err, file.Close())
+		})
+		if err != nil {
+			return fmt.Errorf("failed to pack %s; %w", m.Source, err)
+		}
+	}
+	return writer.Close()
+}
+
+// fromTarArchive writes the content of the first file of the given tar
+// archive, as produced by Docker when copying a single file from a container,
+// to the given writer.
+func fromTarArchive(archive io.Reader, out io.Writer) error {
+	reader := tar.NewReader(archive)
+	if _, err := reader.Next(); err != nil {
+		return fmt.Errorf("failed to read archive; %v", err)
+	}
+	_, err := io.Copy(out, reader)
+	return err
 }
 
 // CreateBridgeNetwork creates a new Docker bridge network.
-func (c *Client) CreateBridgeNetwork() (*Network, error) {
+func (c *Client) CreateBridgeNetwork(ctx context.Context) (*Network, error) {
 	// generate random name for network
 	name := fmt.Sprintf("norma_network_%d", rand.Int())
 
 	// create new network
-	resp, err := c.cli.NetworkCreate(context.Background(), name, types.NetworkCreate{
+	resp, err := c.cli.NetworkCreate(ctx, name, types.NetworkCreate{
 		Labels: map[string]string{
 			objectsLabel: "true",
 		},
@@ -218,6 +536,11 @@ func (c *Client) CreateBridgeNetwork() (*Network, error) {
 	}, nil
 }
 
+// GetClient returns the client of the Docker host the network was created on.
+func (n *Network) GetClient() *Client {
+	return n.client
+}
+
 // Hostname returns the hostname of the Container. In this case it is the ID of the
 // Docker Container.
 func (c *Container) Hostname() string {
@@ -228,34 +551,110 @@ func (c *Container) Hostname() string {
 // IsRunning returns true if the Container has not been stopped yet and is
 // expected to offer its services.
 func (c *Container) IsRunning() bool {
-	return !c.stopped
+	return !c.stopped.Load()
 }
 
 // Stop terminates this container. Services within the container will be
 // signaled about the upcoming termination followed by being killed after a set
 // timeout (see ContainerConfig.ShutdownTimeout).
-func (c *Container) Stop() error {
-	if c.stopped {
+func (c *Container) Stop(ctx context.Context) error {
+	// The flag is set before the container is stopped, such that observers do
+	// not mistake the stop for a crash.
+	if !c.stopped.CompareAndSwap(false, true) {
 		return nil
 	}
-	c.stopped = true
 	timeout := int(c.config.ShutdownTimeout.Seconds())
-	return c.client.cli.ContainerStop(context.Background(), c.id, container.StopOptions{
+	return c.client.cli.ContainerStop(ctx, c.id, container.StopOptions{
 		Signal: string(SigInt), Timeout: &timeout})
 }
 
 // Cleanup stops the container (unless it is already stopped) and frees any
 // resources associated to it. After the operation, the Container is to be
 // considered invalid.
-func (c *Container) Cleanup() error {
+func (c *Container) Cleanup(ctx context.Context) error {
 	if c.cleaned {
 		return nil
 	}
-	if err := c.Stop(); err != nil {
+	if err := c.Stop(ctx); err != nil {
 		return err
 	}
 	c.cleaned = true
-	return c.client.cli.ContainerRemove(context.Background(), c.id, container.RemoveOptions{})
+	return c.client.cli.ContainerRemove(ctx, c.id, container.RemoveOptions{})
+}
+
+// GetExitCode returns the exit code of the main process of this Container.
+// If the process is still running, nil is returned. Unlike IsRunning, this
+// method inspects the actual state of the Container, and may thus be used to
+// detect Containers terminated without a call to Stop.
+func (c *Container) GetExitCode(ctx context.Context) (*int, error) {
+	info, err := c.client.cli.ContainerInspect(ctx, c.id)
+	if err != nil {
+		return nil, err
+	}
+	if info.State == nil || info.State.Running {
+		return nil, nil
+	}
+	code := info.State.ExitCode
+	return &code, nil
+}
+
+// Wait blocks until the main process of this Container has terminated and
+// returns its exit code.
+func (c *Container) Wait(ctx context.Context) (int, error) {
+	statusCh, errCh := c.client.cli.ContainerWait(ctx, c.id, container.WaitConditionNotRunning)
+	select {
+	case err := <-errCh:
+		return 0, err
+	case status := <-statusCh:
+		if status.Error != nil {
+			return int(status.StatusCode), fmt.Errorf("failed to wait for container; %s", status.Error.Message)
+		}
+		return int(status.StatusCode), nil
+	}
+}
+
+// cpuPeriod is the CFS scheduler period in microseconds used for limiting the
+// CPU usage of containers. The quota is derived from it.
+const cpuPeriod = 100_000
+
+// toDockerResources converts the given limits into Docker's resource
+// configuration. Zero limits are not set.
+func toDockerResources(limits network.ResourceLimits) container.Resources {
+	res := container.Resources{
+		Memory: limits.Memory,
+	}
+	if limits.Cpus > 0 {
+		res.CPUPeriod = cpuPeriod
+		res.CPUQuota = int64(limits.Cpus * cpuPeriod)
+	}
+	if limits.Pids > 0 {
+		pids := limits.Pids
+		res.PidsLimit = &pids
+	}
+	return res
+}
+
+// GetResourceLimits obtains the resource limits applied by Docker to this
+// container.
+func (c *Container) GetResourceLimits(ctx context.Context) (network.ResourceLimits, error) {
+	info, err := c.client.cli.ContainerInspect(ctx, c.id)
+	if err != nil {
+		return network.ResourceLimits{}, err
+	}
+	res := network.ResourceLimits{}
+	if info.HostConfig == nil {
+		return res, nil
+	}
+	if info.HostConfig.CPUQuota > 0 && info.HostConfig.CPUPeriod > 0 {
+		res.Cpus = float64(info.HostConfig.CPUQuota) / float64(info.HostConfig.CPUPeriod)
+	} else if info.HostConfig.NanoCPUs > 0 {
+		res.Cpus = float64(info.HostConfig.NanoCPUs) / 1e9
+	}
+	res.Memory = info.HostConfig.Memory
+	if info.HostConfig.PidsLimit != nil && *info.HostConfig.PidsLimit > 0 {
+		res.Pids = *info.HostConfig.PidsLimit
+	}
+	return res, nil
 }
 
 // GetAddressForService retrieves the Address of a service running in this
@@ -264,17 +663,18 @@ func (c *Container) Cleanup() error {
 // the Start of the Container), nil will be returned.
 func (c *Container) GetAddressForService(service *network.ServiceDescription) *network.AddressPort {
 	// All services inside the container are reached through port-forwarding
-	// on the localhost. Non-forwarded services are not supported.
-	port, ok := c.config.PortForwarding[service.Port]
+	// on the Docker host, using the host ports assigned by Docker on start.
+	// Non-forwarded services are not supported.
+	port, ok := c.ports[service.Port]
 	if !ok {
 		return nil
 	}
-	res := network.AddressPort(fmt.Sprintf("%s:%d", "localhost", port))
+	res := network.AddressPort(fmt.Sprintf("%s:%d", c.client.address, port))
 	return &res
 }
 
 // SaveLogTo fetches the log of the container and saves it to the given directory.
-func (c *Container) SaveLogTo(directory string) error {
+func (c *Container) SaveLogTo(ctx context.Context, directory string) error {
 	opt := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -282,7 +682,7 @@ func (c *Container) SaveLogTo(directory string) error {
 
 	// TODO if this proves insufficient, an alternative would be to mount certain directories from
 	// the container to temp on the host and here just copy local directories
-	reader, err := c.client.cli.ContainerLogs(context.Background(), c.id, opt)
+	reader, err := c.client.cli.ContainerLogs(ctx, c.id, opt)
 	if err != nil {
 		return err
 	}
@@ -300,14 +700,16 @@ func (c *Container) SaveLogTo(directory string) error {
 	return nil
 }
 
-func (c *Container) StreamLog() (io.ReadCloser, error) {
+// StreamLog follows the log of the container until the container terminates,
+// the returned reader is closed, or the given context is done.
+func (c *Container) StreamLog(ctx context.Context) (io.ReadCloser, error) {
 	opt := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
 		Follow:     true,
 	}
 
-	reader, err := c.client.cli.ContainerLogs(context.Background(), c.id, opt)
+	reader, err := c.client.cli.ContainerLogs(ctx, c.id, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -315,16 +717,55 @@ func (c *Container) StreamLog() (io.ReadCloser, error) {
 	return reader, nil
 }
 
+// WaitForLog blocks until a line containing the given marker appears in the
+// log of this Container. It fails if the log ends before, e.g. because the
+// Container terminated, or if the context is done.
+func (c *Container) WaitForLog(ctx context.Context, marker string) error {
+	reader, err := c.StreamLog(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return waitForMarker(ctx, reader, marker)
+}
+
+// waitForMarker scans the given log for a line containing the marker. The
+// caller is expected to close the log to end the scan if the context is done.
+func waitForMarker(ctx context.Context, reader io.Reader, marker string) error {
+	found := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if strings.Contains(scanner.Text(), marker) {
+				found <- nil
+				return
+			}
+		}
+		err := scanner.Err()
+		if err == nil {
+			err = fmt.Errorf("log ended before %q appeared", marker)
+		}
+		found <- err
+	}()
+	select {
+	case err := <-found:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // SendSignal sends a signal to the container.
-func (c *Container) SendSignal(signal Signal) error {
-	return c.client.cli.ContainerKill(context.Background(), c.id, string(signal))
+func (c *Container) SendSignal(ctx context.Context, signal Signal) error {
+	return c.client.cli.ContainerKill(ctx, c.id, string(signal))
 }
 
 // Exec executes a command in the container.
 // This method is blocking until the command has finished.
 // The output of the command is returned as a string (stdout + stderr).
 // The command is required to be tokenized and interpreted in shell's exec form.
-func (c *Container) Exec(cmd []string) (string, error) {
+func (c *Container) Exec(ctx context.Context, cmd []string) (string, error) {
 	// Create a container exec instance
 	execConfig := types.ExecConfig{
 		Tty:          true,
@@ -332,7 +773,7 @@ func (c *Container) Exec(cmd []string) (string, error) {
 		AttachStdout: true,
 		AttachStderr: true,
 	}
-	execResp, err := c.client.cli.ContainerExecCreate(context.Background(), c.id, execConfig)
+	execResp, err := c.client.cli.ContainerExecCreate(ctx, c.id, execConfig)
 	if err != nil {
 		return "", fmt.Errorf("failed to create exec instance: %s", err)
 	}
@@ -343,7 +784,7 @@ func (c *Container) Exec(cmd []string) (string, error) {
 	}
 
 	// Attach to the exec instance
-	resp, err := c.client.cli.ContainerExecAttach(context.Background(), execResp.ID, types.ExecStartCheck{})
+	resp, err := c.client.cli.ContainerExecAttach(ctx, execResp.ID, types.ExecStartCheck{})
 	if err != nil {
 		return "", fmt.Errorf("failed to attach to exec instance: %s", err)
 	}
@@ -356,7 +797,7 @@ func (c *Container) Exec(cmd []string) (string, error) {
 	}
 
 	// Wait for the exec command to finish
-	execInspect, err := c.client.cli.ContainerExecInspect(context.Background(), execResp.ID)
+	execInspect, err := c.client.cli.ContainerExecInspect(ctx, execResp.ID)
 	if err != nil {
 		return (string)(output), fmt.Errorf("failed to inspect exec instance: %s", err)
 	}
@@ -370,20 +811,36 @@ func (c *Container) Exec(cmd []string) (string, error) {
 	return (string)(output), nil
 }
 
+// CopyFileToHost copies the file at the given absolute path within this
+// Container to the given file on the host. The content is streamed, so it
+// is not required to fit into memory.
+func (c *Container) CopyFileToHost(ctx context.Context, path, file string) error {
+	reader, _, err := c.client.cli.CopyFromContainer(ctx, c.id, path)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s from container; %v", path, err)
+	}
+	defer reader.Close()
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create %s; %v", file, err)
+	}
+	return errors.Join(fromTarArchive(reader, out), out.Close())
+}
+
 // Cleanup removes the network from the Docker host.
-func (n *Network) Cleanup() error {
+func (n *Network) Cleanup(ctx context.Context) error {
 	if n.cleaned {
 		return nil
 	}
 	// remove all containers from the network, so we can remove the network
-	containers, err := n.client.listContainers()
+	containers, err := n.client.listContainers(ctx)
 	if err != nil {
 		return err
 	}
 	for _, c := range containers {
 		for _, cn := range c.NetworkSettings.Networks {
 			if cn.NetworkID == n.id {
-				if err := n.client.cli.NetworkDisconnect(context.Background(), n.id, c.ID, true); err != nil {
+				if err := n.client.cli.NetworkDisconnect(ctx, n.id, c.ID, true); err != nil {
 					return err
 				}
 			}
@@ -391,19 +848,19 @@ func (n *Network) Cleanup() error {
 	}
 	n.cleaned = true
 	// remove the network
-	return n.client.cli.NetworkRemove(context.Background(), n.id)
+	return n.client.cli.NetworkRemove(ctx, n.id)
 }
 
 // listNetworks returns a list of all networks on the Docker host filtered by label.
-func (c *Client) listNetworks() ([]types.NetworkResource, error) {
-	return c.cli.NetworkList(context.Background(), types.NetworkListOptions{
+func (c *Client) listNetworks(ctx context.Context) ([]types.NetworkResource, error) {
+	return c.cli.NetworkList(ctx, types.NetworkListOptions{
 		Filters: filters.NewArgs(getObjectsLabelFilter()),
 	})
 }
 
 // listContainers returns a list of all containers on the Docker host filtered by label.
-func (c *Client) listContainers() ([]types.Container, error) {
-	return c.cli.ContainerList(context.Background(), container.ListOptions{})
+func (c *Client) listContainers(ctx context.Context) ([]types.Container, error) {
+	return c.cli.ContainerList(ctx, container.ListOptions{})
 }
 
 // getObjectsLabelFilter returns a filter for the objects label.
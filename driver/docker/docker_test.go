@@ -17,16 +17,22 @@
 package docker
 
 import (
+	"archive/tar"
 	"bufio"
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/driver/network"
+	"github.com/docker/go-connections/nat"
 )
 
 func TestImplements(t *testing.T) {
@@ -42,7 +48,7 @@ func TestRunAndStopOneContainer(t *testing.T) {
 		t.Errorf("container %s is not running", cont.id)
 	}
 
-	if err := cont.Stop(); err != nil {
+	if err := cont.Stop(context.Background()); err != nil {
 		t.Fatalf("error: %v", err)
 	}
 }
@@ -54,7 +60,7 @@ func TestContainer_StopCanBeCalledMoreThanOnce(t *testing.T) {
 		t.Errorf("started container is not running")
 	}
 
-	if err := cont.Stop(); err != nil {
+	if err := cont.Stop(context.Background()); err != nil {
 		t.Fatalf("error stopping container: %v", err)
 	}
 
@@ -62,7 +68,7 @@ func TestContainer_StopCanBeCalledMoreThanOnce(t *testing.T) {
 		t.Errorf("stopped container is still running")
 	}
 
-	if err := cont.Stop(); err != nil {
+	if err := cont.Stop(context.Background()); err != nil {
 		t.Fatalf("error calling Stop() on stopped container: %v", err)
 	}
 }
@@ -70,7 +76,7 @@ func TestContainer_StopCanBeCalledMoreThanOnce(t *testing.T) {
 func TestContainer_Cleanup(t *testing.T) {
 	cli, cont := startContainer(t)
 
-	if err := cont.Cleanup(); err != nil {
+	if err := cont.Cleanup(context.Background()); err != nil {
 		t.Fatalf("error: %v", err)
 	}
 
@@ -86,7 +92,7 @@ func TestContainer_CleanupCanBeCalledMoreThanOnce(t *testing.T) {
 		t.Errorf("started container is not running")
 	}
 
-	if err := cont.Cleanup(); err != nil {
+	if err := cont.Cleanup(context.Background()); err != nil {
 		t.Fatalf("error cleaning up container: %v", err)
 	}
 
@@ -98,7 +104,7 @@ func TestContainer_CleanupCanBeCalledMoreThanOnce(t *testing.T) {
 		t.Errorf("cleaned up container is still running")
 	}
 
-	if err := cont.Cleanup(); err != nil {
+	if err := cont.Cleanup(context.Background()); err != nil {
 		t.Fatalf("error calling Cleanup() on cleared container: %v", err)
 	}
 }
@@ -107,7 +113,7 @@ func TestContainer_SaveLogTo(t *testing.T) {
 	_, cont := startContainer(t)
 
 	tmp := t.TempDir()
-	if err := cont.SaveLogTo(tmp); err != nil {
+	if err := cont.SaveLogTo(context.Background(), tmp); err != nil {
 		t.Fatalf("cannot save logs: %e", err)
 	}
 
@@ -131,7 +137,7 @@ func TestContainer_SaveLogTo(t *testing.T) {
 func TestContainer_StreamLog(t *testing.T) {
 	_, cont := startContainer(t)
 
-	reader, err := cont.StreamLog()
+	reader, err := cont.StreamLog(context.Background())
 	if err != nil {
 		t.Fatalf("cannot read logs: %e", err)
 	}
@@ -167,15 +173,15 @@ func TestContainer_StreamLog(t *testing.T) {
 func TestContainer_StreamManyReaders(t *testing.T) {
 	_, cont := startContainer(t)
 
-	reader1, err := cont.StreamLog()
+	reader1, err := cont.StreamLog(context.Background())
 	if err != nil {
 		t.Fatalf("cannot read logs: %e", err)
 	}
-	reader2, err := cont.StreamLog()
+	reader2, err := cont.StreamLog(context.Background())
 	if err != nil {
 		t.Fatalf("cannot read logs: %e", err)
 	}
-	reader3, err := cont.StreamLog()
+	reader3, err := cont.StreamLog(context.Background())
 	if err != nil {
 		t.Fatalf("cannot read logs: %e", err)
 	}
@@ -217,7 +223,7 @@ func TestContainer_StreamManyReaders(t *testing.T) {
 func TestContainer_Exec(t *testing.T) {
 	_, cont := startRunningContainer(t, nil)
 	testString := "Hello world!"
-	out, err := cont.Exec([]string{"sh", "-c", "echo " + testString})
+	out, err := cont.Exec(context.Background(), []string{"sh", "-c", "echo " + testString})
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -228,7 +234,7 @@ func TestContainer_Exec(t *testing.T) {
 
 func TestContainer_SendSignal(t *testing.T) {
 	cli, cont := startRunningContainer(t, nil)
-	if err := cont.SendSignal(SigKill); err != nil {
+	if err := cont.SendSignal(context.Background(), SigKill); err != nil {
 		t.Fatalf("error: %v", err)
 	}
 	// check the container is stopped
@@ -241,10 +247,371 @@ func TestContainer_SendSignal(t *testing.T) {
 	}
 }
 
+func TestContainer_GetExitCode(t *testing.T) {
+	_, cont := startRunningContainer(t, nil)
+	code, err := cont.GetExitCode(context.Background())
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if code != nil {
+		t.Errorf("running container should not have an exit code, got %d", *code)
+	}
+
+	if err := cont.SendSignal(context.Background(), SigKill); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := network.Retry(10, 100*time.Millisecond, func() error {
+		code, err = cont.GetExitCode(context.Background())
+		if err == nil && code == nil {
+			return fmt.Errorf("container is still running")
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("failed to obtain exit code: %v", err)
+	}
+	if *code == 0 {
+		t.Errorf("killed container should have a non-zero exit code")
+	}
+}
+
+func TestContainer_GetResourceLimits(t *testing.T) {
+	cli, err := NewClient()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	defer cli.Close()
+
+	limits := network.ResourceLimits{Cpus: 0.5, Memory: 64 << 20, Pids: 100}
+	timeout := time.Second
+	cont, err := cli.Start(context.Background(), &ContainerConfig{
+		ImageName:       "alpine",
+		Entrypoint:      []string{"tail", "-f", "/dev/null"},
+		ShutdownTimeout: &timeout,
+		Resources:       limits,
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	defer cont.Cleanup(context.Background())
+
+	got, err := cont.GetResourceLimits(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get resource limits: %v", err)
+	}
+	if got != limits {
+		t.Errorf("unexpected resource limits, wanted %v, got %v", limits, got)
+	}
+}
+
+func TestToDockerResources_ZeroLimitsAreNotSet(t *testing.T) {
+	res := toDockerResources(network.ResourceLimits{})
+	if res.CPUQuota != 0 || res.CPUPeriod != 0 || res.Memory != 0 || res.PidsLimit != nil {
+		t.Errorf("unexpected resources for zero limits: %+v", res)
+	}
+}
+
+func TestToDockerResources_LimitsAreConverted(t *testing.T) {
+	res := toDockerResources(network.ResourceLimits{Cpus: 1.5, Memory: 1 << 30, Pids: 256})
+	if got, want := res.CPUQuota, int64(150_000); got != want {
+		t.Errorf("unexpected CPU quota, wanted %d, got %d", want, got)
+	}
+	if got, want := res.CPUPeriod, int64(cpuPeriod); got != want {
+		t.Errorf("unexpected CPU period, wanted %d, got %d", want, got)
+	}
+	if got, want := res.Memory, int64(1<<30); got != want {
+		t.Errorf("unexpected memory limit, wanted %d, got %d", want, got)
+	}
+	if res.PidsLimit == nil || *res.PidsLimit != 256 {
+		t.Errorf("unexpected pids limit: %v", res.PidsLimit)
+	}
+}
+
+func TestContainer_Wait(t *testing.T) {
+	cli, err := NewClient()
+	if err != nil {
+		t.Fatalf("failed to create a client: %v", err)
+	}
+	defer cli.Close()
+
+	timeout := time.Second
+	c, err := cli.Start(context.Background(), &ContainerConfig{
+		ImageName:       "alpine",
+		ShutdownTimeout: &timeout,
+		Entrypoint:      []string{"sh", "-c", "exit 3"},
+	})
+	if err != nil {
+		t.Fatalf("failed to start container: %v", err)
+	}
+	defer c.Cleanup(context.Background())
+
+	code, err := c.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("failed to wait for container: %v", err)
+	}
+	if code != 3 {
+		t.Errorf("unexpected exit code: %d", code)
+	}
+}
+
+func TestToDockerMounts_MountsAreConverted(t *testing.T) {
+	datadir := "/tmp/datadir"
+	mounts := toDockerMounts(getMounts(&ContainerConfig{
+		Mounts:       []Mount{{Source: "/tmp/state.g", Target: "/state.g", ReadOnly: true}},
+		MountDatadir: &datadir,
+	}))
+	if got, want := len(mounts), 2; got != want {
+		t.Fatalf("unexpected number of mounts, wanted %d, got %d", want, got)
+	}
+	if got := mounts[0]; got.Source != "/tmp/state.g" || got.Target != "/state.g" || !got.ReadOnly {
+		t.Errorf("unexpected mount: %+v", got)
+	}
+	if got := mounts[1]; got.Source != datadir || got.Target != "/datadir" || got.ReadOnly {
+		t.Errorf("unexpected datadir mount: %+v", got)
+	}
+}
+
+func TestToTarArchive_FilesArePacked(t *testing.T) {
+	archive, err := toTarArchive(map[string][]byte{"/genesis.json": []byte("{}")})
+	if err != nil {
+		t.Fatalf("failed to pack files: %v", err)
+	}
+	reader := tar.NewReader(archive)
+	header, err := reader.Next()
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	if got, want := header.Name, "genesis.json"; got != want {
+		t.Errorf("unexpected file name, wanted %s, got %s", want, got)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if got, want := string(content), "{}"; got != want {
+		t.Errorf("unexpected content, wanted %s, got %s", want, got)
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("archive should contain a single file, got %v", err)
+	}
+}
+
+func TestFromTarArchive_FileIsExtracted(t *testing.T) {
+	archive, err := toTarArchive(map[string][]byte{"/initial_state.g": []byte("state")})
+	if err != nil {
+		t.Fatalf("failed to pack files: %v", err)
+	}
+	var content bytes.Buffer
+	if err := fromTarArchive(archive, &content); err != nil {
+		t.Fatalf("failed to extract file: %v", err)
+	}
+	if got, want := content.String(), "state"; got != want {
+		t.Errorf("unexpected content, wanted %s, got %s", want, got)
+	}
+}
+
+func TestFromTarArchive_EmptyArchiveIsRejected(t *testing.T) {
+	archive, err := toTarArchive(nil)
+	if err != nil {
+		t.Fatalf("failed to pack files: %v", err)
+	}
+	if err := fromTarArchive(archive, io.Discard); err == nil {
+		t.Errorf("extracting from an empty archive should fail")
+	}
+}
+
+func TestToPortBindings_HostPortsAreLeftToDocker(t *testing.T) {
+	bindings := toPortBindings([]network.Port{18545, 6060}, "127.0.0.1")
+	if got, want := len(bindings), 2; got != want {
+		t.Fatalf("unexpected number of bindings, wanted %d, got %d", want, got)
+	}
+	for _, port := range []nat.Port{"18545/tcp", "6060/tcp"} {
+		binding := bindings[port]
+		if len(binding) != 1 || binding[0].HostPort != "" || binding[0].HostIP != "127.0.0.1" {
+			t.Errorf("unexpected binding of port %v: %v", port, binding)
+		}
+	}
+}
+
+func TestToPortBindings_PortsAreBoundToGivenInterface(t *testing.T) {
+	bindings := toPortBindings([]network.Port{5050}, "10.0.0.2")
+	binding := bindings["5050/tcp"]
+	if len(binding) != 1 || binding[0].HostPort != "" || binding[0].HostIP != "10.0.0.2" {
+		t.Errorf("unexpected binding of port 5050: %v", binding)
+	}
+}
+
+func TestGetBindAddress_InterfaceIsDerivedFromAddress(t *testing.T) {
+	tests := []struct {
+		address, bindAddress, want string
+	}{
+		{"localhost", "", "127.0.0.1"},
+		{"10.0.0.2", "", "10.0.0.2"},
+		{"docker-1.example", "10.0.0.3", "10.0.0.3"},
+		{"10.0.0.2", "192.168.0.2", "192.168.0.2"},
+	}
+	for _, test := range tests {
+		got, err := getBindAddress(test.address, test.bindAddress)
+		if err != nil || got != test.want {
+			t.Errorf("unexpected bind address for %q and %q, wanted %s, got %s, %v", test.address, test.bindAddress, test.want, got, err)
+		}
+	}
+}
+
+func TestGetBindAddress_NonIpAddressesAreRejected(t *testing.T) {
+	if _, err := getBindAddress("docker-1.example", ""); err == nil {
+		t.Errorf("host name without bind address should be rejected")
+	}
+	if _, err := getBindAddress("10.0.0.2", "docker-1.example"); err == nil {
+		t.Errorf("host name as bind address should be rejected")
+	}
+}
+
+func TestIsRemoteHost_LocalHostsAreDetected(t *testing.T) {
+	tests := map[string]bool{
+		"":                            false,
+		"unix:///var/run/docker.sock": false,
+		"tcp://127.0.0.1:2375":        false,
+		"tcp://localhost:2375":        false,
+		"tcp://10.0.0.2:2375":         true,
+		"tcp://docker-1.example:2376": true,
+	}
+	for host, want := range tests {
+		if got := isRemoteHost(host); got != want {
+			t.Errorf("unexpected result for host %q, wanted %t, got %t", host, want, got)
+		}
+	}
+}
+
+func TestToMountArchive_FilesAndDirectoriesArePackedAtTargets(t *testing.T) {
+	dir := t.TempDir()
+	state := filepath.Join(dir, "state.g")
+	if err := os.WriteFile(state, []byte("state"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	datadir := filepath.Join(dir, "datadir")
+	if err := os.MkdirAll(filepath.Join(datadir, "chaindata"), 0700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(datadir, "chaindata", "db"), []byte("db"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	archive := toMountArchive([]Mount{
+		{Source: state, Target: "/initial_state.g", ReadOnly: true},
+		{Source: datadir, Target: "/initial_state", ReadOnly: true},
+	})
+	defer archive.Close()
+
+	got := map[string]string{}
+	reader := tar.NewReader(archive)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read archive: %v", err)
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+		got[header.Name] = string(content)
+	}
+	want := map[string]string{
+		"initial_state.g":            "state",
+		"initial_state":              "",
+		"initial_state/chaindata":    "",
+		"initial_state/chaindata/db": "db",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected archive content, wanted %v, got %v", want, got)
+	}
+}
+
+func TestToMountArchive_MissingSourcesAreReported(t *testing.T) {
+	archive := toMountArchive([]Mount{{Source: filepath.Join(t.TempDir(), "missing"), Target: "/missing"}})
+	defer archive.Close()
+	if _, err := io.ReadAll(archive); err == nil {
+		t.Errorf("packing a missing source should fail")
+	}
+}
+
+func TestGetForwardingAddress_AddressIsDerivedFromHost(t *testing.T) {
+	tests := map[string]string{
+		"":                            "localhost",
+		"unix:///var/run/docker.sock": "localhost",
+		"npipe:////./pipe/docker":     "localhost",
+		"tcp://10.0.0.2:2375":         "10.0.0.2",
+		"tcp://docker-1.example:2376": "docker-1.example",
+		"https://10.0.0.3:2376":       "10.0.0.3",
+	}
+	for host, want := range tests {
+		if got := getForwardingAddress(host); got != want {
+			t.Errorf("unexpected address for host %q, wanted %s, got %s", host, want, got)
+		}
+	}
+}
+
+func TestFromPortMap_AssignedHostPortsAreExtracted(t *testing.T) {
+	bindings := nat.PortMap{
+		"18545/tcp": {{HostIP: "127.0.0.1", HostPort: "32768"}},
+		"6060/tcp":  {{HostIP: "127.0.0.1", HostPort: "32769"}},
+	}
+	ports, err := fromPortMap([]network.Port{18545, 6060}, bindings)
+	if err != nil {
+		t.Fatalf("failed to extract ports: %v", err)
+	}
+	if got, want := ports[18545], network.Port(32768); got != want {
+		t.Errorf("unexpected host port, wanted %d, got %d", want, got)
+	}
+	if got, want := ports[6060], network.Port(32769); got != want {
+		t.Errorf("unexpected host port, wanted %d, got %d", want, got)
+	}
+}
+
+func TestFromPortMap_MissingOrInvalidPortsAreReported(t *testing.T) {
+	bindings := nat.PortMap{
+		"6060/tcp": {{HostIP: "127.0.0.1", HostPort: "none"}},
+	}
+	if _, err := fromPortMap([]network.Port{18545}, bindings); err == nil {
+		t.Errorf("missing host port should be reported")
+	}
+	if _, err := fromPortMap([]network.Port{6060}, bindings); err == nil {
+		t.Errorf("invalid host port should be reported")
+	}
+}
+
+func TestWaitForMarker_MarkerIsFound(t *testing.T) {
+	reader := strings.NewReader("starting\nHTTP server started endpoint=0.0.0.0:18545\nmore\n")
+	if err := waitForMarker(context.Background(), reader, "HTTP server started"); err != nil {
+		t.Errorf("marker should be found, got %v", err)
+	}
+}
+
+func TestWaitForMarker_EndOfLogIsReported(t *testing.T) {
+	reader := strings.NewReader("starting\nfailed\n")
+	err := waitForMarker(context.Background(), reader, "HTTP server started")
+	if err == nil || !strings.Contains(err.Error(), "log ended") {
+		t.Errorf("end of log should be reported, got %v", err)
+	}
+}
+
+func TestWaitForMarker_StopsWhenContextIsDone(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := waitForMarker(ctx, reader, "HTTP server started"); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline to be exceeded, got %v", err)
+	}
+	reader.Close()
+}
+
 func TestNetwork_Cleanup(t *testing.T) {
 	cli, net := createNetwork(t)
 
-	if err := net.Cleanup(); err != nil {
+	if err := net.Cleanup(context.Background()); err != nil {
 		t.Fatalf("error: %v", err)
 	}
 
@@ -256,7 +623,7 @@ func TestNetwork_Cleanup(t *testing.T) {
 func TestNetwork_CleanupCanBeCalledMoreThanOnce(t *testing.T) {
 	cli, net := createNetwork(t)
 
-	if err := net.Cleanup(); err != nil {
+	if err := net.Cleanup(context.Background()); err != nil {
 		t.Fatalf("error cleaning up network: %v", err)
 	}
 
@@ -264,7 +631,7 @@ func TestNetwork_CleanupCanBeCalledMoreThanOnce(t *testing.T) {
 		t.Fatalf("network should no longer exist: %s", net.id)
 	}
 
-	if err := net.Cleanup(); err != nil {
+	if err := net.Cleanup(context.Background()); err != nil {
 		t.Fatalf("error calling Cleanup() on cleared network: %v", err)
 	}
 }
@@ -273,7 +640,7 @@ func TestContainerCanJoinNetwork(t *testing.T) {
 	cli, net := createNetwork(t)
 	_, cont := startRunningContainer(t, net)
 
-	containers, err := cli.listContainers()
+	containers, err := cli.listContainers(context.Background())
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -294,7 +661,7 @@ func TestContainerCanJoinNetwork(t *testing.T) {
 func containerExists(t *testing.T, cli *Client, id string) bool {
 	// test the container exists
 	var exists bool
-	containers, err := cli.listContainers()
+	containers, err := cli.listContainers(context.Background())
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -312,7 +679,7 @@ func containerExists(t *testing.T, cli *Client, id string) bool {
 func networkExists(t *testing.T, cli *Client, id string) bool {
 	// test the network exists
 	var exists bool
-	networks, err := cli.listNetworks()
+	networks, err := cli.listNetworks(context.Background())
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -334,7 +701,7 @@ func startContainer(t *testing.T) (*Client, *Container) {
 	}
 
 	timeout := time.Second
-	cont, err := cli.Start(&ContainerConfig{
+	cont, err := cli.Start(context.Background(), &ContainerConfig{
 		ImageName:       "hello-world",
 		ShutdownTimeout: &timeout,
 	})
@@ -343,7 +710,7 @@ func startContainer(t *testing.T) (*Client, *Container) {
 	}
 
 	t.Cleanup(func() {
-		_ = cont.Cleanup()
+		_ = cont.Cleanup(context.Background())
 		_ = cli.Close()
 	})
 
@@ -357,7 +724,7 @@ func startRunningContainer(t *testing.T, dn *Network) (*Client, *Container) {
 	}
 
 	timeout := time.Second
-	cont, err := cli.Start(&ContainerConfig{
+	cont, err := cli.Start(context.Background(), &ContainerConfig{
 		ImageName:       "alpine",                            // use minimal linux image
 		Entrypoint:      []string{"tail", "-f", "/dev/null"}, // keep container running
 		ShutdownTimeout: &timeout,
@@ -368,7 +735,7 @@ func startRunningContainer(t *testing.T, dn *Network) (*Client, *Container) {
 	}
 
 	t.Cleanup(func() {
-		_ = cont.Cleanup()
+		_ = cont.Cleanup(context.Background())
 		_ = cli.Close()
 	})
 
@@ -381,13 +748,13 @@ func createNetwork(t *testing.T) (*Client, *Network) {
 		t.Fatalf("error: %v", err)
 	}
 
-	net, err := cli.CreateBridgeNetwork()
+	net, err := cli.CreateBridgeNetwork(context.Background())
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
 
 	t.Cleanup(func() {
-		_ = net.Cleanup()
+		_ = net.Cleanup(context.Background())
 		_ = cli.Close()
 	})
 
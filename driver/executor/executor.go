@@ -17,22 +17,25 @@
 package executor
 
 import (
+	"context"
 	"fmt"
-	"github.com/Fantom-foundation/Norma/driver/checking"
 	"log"
-	"os"
-	"os/signal"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/checking"
+	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/parser"
 	pq "github.com/jupp0r/go-priority-queue"
 )
 
 // Run executes the given scenario on the given network using the provided clock
 // as a time source. Execution will fail (fast) if the scenario is not valid (see
-// Scenario's Check() function).
-func Run(clock Clock, network driver.Network, scenario *parser.Scenario, skipConsistencyCheck bool) error {
+// Scenario's Check() function). If incidents is not nil, the execution is
+// aborted as soon as an error is received through it, e.g. reported by a
+// watchdog detecting a failed node. Cancelling the given context aborts the
+// execution as well as any network operation currently in progress.
+func Run(ctx context.Context, clock Clock, network driver.Network, scenario *parser.Scenario, skipConsistencyCheck bool, incidents <-chan error) error {
 	if err := scenario.Check(); err != nil {
 		return err
 	}
@@ -45,11 +48,14 @@ func Run(clock Clock, network driver.Network, scenario *parser.Scenario, skipCon
 		return nil
 	}))
 
+	// rulesUpdates records the updates of the network rules submitted during the run.
+	rulesUpdates := &checking.NetworkRulesChecker{}
+
 	// schedule network consistency just before the end of simulation
 	if !skipConsistencyCheck {
 		queue.add(toSingleEvent(endTime-1, "consistency check", func() error {
 			log.Printf("Checking network consistency ...\n")
-			return checking.CheckNetworkConsistency(network)
+			return checking.CheckNetworkConsistency(ctx, network, scenario.GetChecks(), rulesUpdates)
 		}))
 	} else {
 		fmt.Printf("Network checks skipped\n")
@@ -57,21 +63,21 @@ func Run(clock Clock, network driver.Network, scenario *parser.Scenario, skipCon
 
 	// Schedule all operations listed in the scenario.
 	for _, node := range scenario.Nodes {
-		scheduleNodeEvents(&node, queue, network, endTime)
+		if err := scheduleNodeEvents(ctx, &node, queue, network, endTime); err != nil {
+			return err
+		}
 	}
 	for _, app := range scenario.Applications {
-		if err := scheduleApplicationEvents(&app, queue, network, endTime); err != nil {
+		if err := scheduleApplicationEvents(ctx, &app, queue, network, endTime); err != nil {
 			return err
 		}
 	}
 	for _, cheat := range scenario.Cheats {
 		scheduleCheatEvents(&cheat, queue, network, endTime)
 	}
-
-	// Register a handler for Ctrl+C events.
-	abort := make(chan os.Signal, 1)
-	signal.Notify(abort, os.Interrupt)
-	defer signal.Stop(abort)
+	for _, update := range scenario.RuleUpdates {
+		scheduleRulesUpdateEvents(ctx, &update, queue, network, endTime, rulesUpdates)
+	}
 
 	// restart clock as network initialization could time considerable amount of time.
 	clock.Restart()
@@ -86,10 +92,14 @@ func Run(clock Clock, network driver.Network, scenario *parser.Scenario, skipCon
 		select {
 		case <-clock.NotifyAt(event.time()):
 			// continue processing
-		case <-abort:
+		case <-ctx.Done():
 			// abort processing
-			log.Printf("Received user abort, ending execution ...")
-			return fmt.Errorf("aborted by user")
+			log.Printf("Received abort, ending execution ...")
+			return fmt.Errorf("execution aborted; %w", ctx.Err())
+		case incident := <-incidents:
+			// abort processing due to a failure in the network
+			log.Printf("Network failure detected, ending execution ...")
+			return fmt.Errorf("aborted due to network failure; %w", incident)
 		}
 
 		delay := clock.Delay(event.time())
@@ -188,7 +198,7 @@ func toSingleEvent(time Time, name string, action func() error) event {
 // nodes during the scenario execution. The nature of the scheduled nodes is taken from the
 // given node description, and actions are applied to the given network.
 // Node Lifecycle: create -> timer sim events {start, end, kill, restart} -> remove
-func scheduleNodeEvents(node *parser.Node, queue *eventQueue, net driver.Network, end Time) {
+func scheduleNodeEvents(ctx context.Context, node *parser.Node, queue *eventQueue, net driver.Network, end Time) error {
 	instances := 1
 	if node.Instances != nil {
 		instances = *node.Instances
@@ -202,11 +212,26 @@ func scheduleNodeEvents(node *parser.Node, queue *eventQueue, net driver.Network
 		endTime = Seconds(*node.End)
 	}
 
-	nodeIsValidator := false
-	if node.Client.Type == "validator" {
-		nodeIsValidator = true
-	}
 	nodeIsCheater := false
+	nodeType := driver.NodeType(node.Client.Type)
+	if nodeType == "" {
+		nodeType = driver.ObserverNode
+	}
+
+	resources := network.ResourceLimits{}
+	if node.Resources != nil {
+		memory, err := node.Resources.GetMemory()
+		if err != nil {
+			return fmt.Errorf("invalid memory limit of node %s; %v", node.Name, err)
+		}
+		resources.Memory = memory
+		if node.Resources.Cpus != nil {
+			resources.Cpus = float64(*node.Resources.Cpus)
+		}
+		if node.Resources.Pids != nil {
+			resources.Pids = *node.Resources.Pids
+		}
+	}
 
 	for i := 0; i < instances; i++ {
 		name := fmt.Sprintf("%s-%d", node.Name, i)
@@ -216,10 +241,14 @@ func scheduleNodeEvents(node *parser.Node, queue *eventQueue, net driver.Network
 			startTime,
 			fmt.Sprintf("[%s] Creating node", name),
 			func() error {
-				newNode, err := net.CreateNode(&driver.NodeConfig{
+				newNode, err := net.CreateNode(ctx, &driver.NodeConfig{
 					Name:      name,
-					Validator: nodeIsValidator,
+					Type:      nodeType,
 					Cheater:   nodeIsCheater,
+					Resources: resources,
+					Bootstrap: driver.BootstrapMethod(node.GetBootstrap()),
+					Endpoint:  node.GetEndpoint(),
+					Services:  node.Services,
 				})
 
 				*instance = newNode
@@ -235,25 +264,26 @@ func scheduleNodeEvents(node *parser.Node, queue *eventQueue, net driver.Network
 					return nil
 				}
 
-				if err := net.RemoveNode(*instance); err != nil {
+				if err := net.RemoveNode(ctx, *instance); err != nil {
 					return err
 				}
-				if err := (*instance).Stop(); err != nil {
+				if err := (*instance).Stop(ctx); err != nil {
 					return err
 				}
-				if err := (*instance).Cleanup(); err != nil {
+				if err := (*instance).Cleanup(ctx); err != nil {
 					return err
 				}
 				return nil
 			},
 		))
 	}
+	return nil
 }
 
 // scheduleApplicationEvents schedules a number of events covering the life-cycle of a class of
 // applications during the scenario execution. The nature of the scheduled applications is taken from the
 // given application description, and actions are applied to the given network.
-func scheduleApplicationEvents(source *parser.Application, queue *eventQueue, net driver.Network, end Time) error {
+func scheduleApplicationEvents(ctx context.Context, source *parser.Application, queue *eventQueue, net driver.Network, end Time) error {
 	instances := 1
 	if source.Instances != nil {
 		instances = *source.Instances
@@ -273,25 +303,87 @@ func scheduleApplicationEvents(source *parser.Application, queue *eventQueue, ne
 
 	for i := 0; i < instances; i++ {
 		name := fmt.Sprintf("%s-%d", source.Name, i)
-		newApp, err := net.CreateApplication(&driver.ApplicationConfig{
-			Name:  name,
-			Type:  source.Type,
-			Rate:  &source.Rate,
-			Users: users,
+		newApp, err := net.CreateApplication(ctx, &driver.ApplicationConfig{
+			Name:    name,
+			Type:    source.GetType(),
+			Rate:    &source.Rate,
+			Users:   users,
+			TxType:  source.TxType,
+			Mix:     source.Mix,
+			Configs: source.GetConfigs(),
 		})
 		if err != nil {
 			return err
 		}
 		queue.add(toSingleEvent(startTime, fmt.Sprintf("starting app %s", name), func() error {
-			return newApp.Start()
+			return newApp.Start(ctx)
 		}))
 		queue.add(toSingleEvent(endTime, fmt.Sprintf("stopping app %s", name), func() error {
-			return newApp.Stop()
+			return newApp.Stop(ctx)
 		}))
 	}
 	return nil
 }
 
+// scheduleRulesUpdateEvents schedules the submission of an update of the network
+// rules. Updates triggered by an epoch poll the current epoch of the network once
+// per second until the epoch is reached. Submitted updates are recorded in the
+// given checker to verify that all nodes apply them from the same epoch on.
+func scheduleRulesUpdateEvents(ctx context.Context, update *parser.RulesUpdate, queue *eventQueue, net driver.Network, end Time, checker *checking.NetworkRulesChecker) {
+	name := fmt.Sprintf("updating network rules %s", update.Name)
+	rules := driver.NetworkRules(update.Rules)
+
+	apply := func() error {
+		epoch, err := getCurrentEpoch(net)
+		if err != nil {
+			return err
+		}
+		if err := net.UpdateNetworkRules(ctx, rules); err != nil {
+			return fmt.Errorf("failed to apply rules update %s; %w", update.Name, err)
+		}
+		checker.Updates = append(checker.Updates, checking.RulesUpdate{
+			Name:  update.Name,
+			Rules: rules,
+			Epoch: epoch,
+		})
+		return nil
+	}
+
+	if update.Time != nil {
+		queue.add(toSingleEvent(Seconds(*update.Time), name, apply))
+		return
+	}
+
+	var poll func(Time) event
+	poll = func(time Time) event {
+		return toEvent(time, fmt.Sprintf("waiting for epoch %d for %s", *update.Epoch, name), func() ([]event, error) {
+			epoch, err := getCurrentEpoch(net)
+			if err != nil {
+				return nil, err
+			}
+			if epoch >= *update.Epoch {
+				return nil, apply()
+			}
+			next := time + Seconds(1)
+			if next >= end {
+				return nil, fmt.Errorf("epoch %d of rules update %s not reached before the end of the scenario", *update.Epoch, update.Name)
+			}
+			return []event{poll(next)}, nil
+		})
+	}
+	queue.add(poll(0))
+}
+
+// getCurrentEpoch obtains the current epoch of the network.
+func getCurrentEpoch(net driver.Network) (uint64, error) {
+	client, err := net.DialRandomRpc()
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to network; %v", err)
+	}
+	defer client.Close()
+	return checking.GetCurrentEpoch(client)
+}
+
 // scheduleCheatEvents schedules a number of events covering the life-cycle of a class of
 // cheats during the scenario execution. Currently, a cheat is defined a simultaneous start
 // of multiple validator nodes with the same key.
@@ -17,13 +17,19 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
-	"syscall"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/parser"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.uber.org/mock/gomock"
 )
 
@@ -36,7 +42,7 @@ func TestExecutor_RunEmptyScenario(t *testing.T) {
 		Duration: 10,
 	}
 
-	if err := Run(clock, net, &scenario, true); err != nil {
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
 		t.Errorf("failed to run empty scenario: %v", err)
 	}
 	want := Seconds(10)
@@ -64,13 +70,13 @@ func TestExecutor_RunSingleNodeScenario(t *testing.T) {
 
 	// In this scenario, a node is expected to be created and shut down.
 	gomock.InOrder(
-		net.EXPECT().CreateNode(gomock.Any()).Return(node, nil),
-		net.EXPECT().RemoveNode(node),
-		node.EXPECT().Stop(),
-		node.EXPECT().Cleanup(),
+		net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).Return(node, nil),
+		net.EXPECT().RemoveNode(gomock.Any(), node),
+		node.EXPECT().Stop(gomock.Any()),
+		node.EXPECT().Cleanup(gomock.Any()),
 	)
 
-	if err := Run(clock, net, &scenario, true); err != nil {
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
 		t.Errorf("failed to run scenario: %v", err)
 	}
 	want := Seconds(10)
@@ -79,6 +85,180 @@ func TestExecutor_RunSingleNodeScenario(t *testing.T) {
 	}
 }
 
+func TestExecutor_NodeResourcesArePassedToNetwork(t *testing.T) {
+
+	clock := NewSimClock()
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		Nodes: []parser.Node{{
+			Name: "A",
+			Client: parser.ClientType{
+				Type: "rpc",
+			},
+			Resources: &parser.Resources{
+				Cpus:   New[float32](1.5),
+				Memory: New("1g"),
+				Pids:   New[int64](256),
+			},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	want := network.ResourceLimits{Cpus: 1.5, Memory: 1 << 30, Pids: 256}
+	net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, config *driver.NodeConfig) (driver.Node, error) {
+		if config.Type != driver.RpcNode {
+			t.Errorf("unexpected node type: %v", config.Type)
+		}
+		if config.Resources != want {
+			t.Errorf("unexpected resources, wanted %v, got %v", want, config.Resources)
+		}
+		return node, nil
+	})
+	net.EXPECT().RemoveNode(gomock.Any(), node)
+	node.EXPECT().Stop(gomock.Any())
+	node.EXPECT().Cleanup(gomock.Any())
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
+		t.Errorf("failed to run scenario: %v", err)
+	}
+}
+
+func TestExecutor_NodeBootstrapEndpointAndServicesArePassedToNetwork(t *testing.T) {
+	clock := NewSimClock()
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		Nodes: []parser.Node{{
+			Name:      "A",
+			Start:     New[float32](5),
+			Bootstrap: New(parser.SnapshotSyncBootstrap),
+			Endpoint:  New("remote"),
+			Services:  []string{"graphql"},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, config *driver.NodeConfig) (driver.Node, error) {
+		if got, want := config.Bootstrap, driver.SnapshotSync; got != want {
+			t.Errorf("unexpected bootstrap, wanted %v, got %v", want, got)
+		}
+		if got, want := config.Endpoint, "remote"; got != want {
+			t.Errorf("unexpected endpoint, wanted %v, got %v", want, got)
+		}
+		if got, want := config.Services, []string{"graphql"}; !slices.Equal(got, want) {
+			t.Errorf("unexpected services, wanted %v, got %v", want, got)
+		}
+		return node, nil
+	})
+	net.EXPECT().RemoveNode(gomock.Any(), node)
+	node.EXPECT().Stop(gomock.Any())
+	node.EXPECT().Cleanup(gomock.Any())
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
+		t.Errorf("failed to run scenario: %v", err)
+	}
+}
+
+// mockEpochs sets up the given network to report the given sequence of
+// current epochs, repeating the last one once the sequence is exhausted.
+func mockEpochs(ctrl *gomock.Controller, net *driver.MockNetwork, epochs ...uint64) {
+	client := rpc.NewMockRpcClient(ctrl)
+	net.EXPECT().DialRandomRpc().Return(client, nil).AnyTimes()
+	client.EXPECT().Close().AnyTimes()
+	client.EXPECT().Call(gomock.Any(), "eth_currentEpoch").DoAndReturn(func(result any, method string, args ...any) error {
+		*result.(*hexutil.Uint64) = hexutil.Uint64(epochs[0])
+		if len(epochs) > 1 {
+			epochs = epochs[1:]
+		}
+		return nil
+	}).AnyTimes()
+}
+
+func TestExecutor_RulesUpdateIsAppliedAtGivenTime(t *testing.T) {
+	clock := NewSimClock()
+	rules := map[string]any{"Blocks": map[string]any{"MaxBlockGas": 1000}}
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		RuleUpdates: []parser.RulesUpdate{{
+			Name:  "gas",
+			Time:  New[float32](3),
+			Rules: rules,
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	mockEpochs(ctrl, net, 2)
+	net.EXPECT().UpdateNetworkRules(gomock.Any(), driver.NetworkRules(rules)).DoAndReturn(func(context.Context, driver.NetworkRules) error {
+		if got, want := clock.Now(), Seconds(3); got != want {
+			t.Errorf("rules updated at wrong time, wanted %v, got %v", want, got)
+		}
+		return nil
+	})
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
+		t.Errorf("failed to run scenario: %v", err)
+	}
+}
+
+func TestExecutor_RulesUpdateWaitsForEpoch(t *testing.T) {
+	clock := NewSimClock()
+	rules := map[string]any{"Upgrades": map[string]any{"Allegro": true}}
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		RuleUpdates: []parser.RulesUpdate{{
+			Name:  "upgrade",
+			Epoch: New[uint64](3),
+			Rules: rules,
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	mockEpochs(ctrl, net, 1, 1, 2, 3)
+	net.EXPECT().UpdateNetworkRules(gomock.Any(), driver.NetworkRules(rules)).DoAndReturn(func(context.Context, driver.NetworkRules) error {
+		if got, want := clock.Now(), Seconds(3); got != want {
+			t.Errorf("rules updated at wrong time, wanted %v, got %v", want, got)
+		}
+		return nil
+	})
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
+		t.Errorf("failed to run scenario: %v", err)
+	}
+}
+
+func TestExecutor_RulesUpdateFailsIfEpochIsNotReached(t *testing.T) {
+	clock := NewSimClock()
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		RuleUpdates: []parser.RulesUpdate{{
+			Name:  "upgrade",
+			Epoch: New[uint64](30),
+			Rules: map[string]any{"Upgrades": map[string]any{"Allegro": true}},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	mockEpochs(ctrl, net, 1)
+
+	err := Run(context.Background(), clock, net, &scenario, true, nil)
+	if err == nil || !strings.Contains(err.Error(), "epoch 30 of rules update upgrade not reached") {
+		t.Errorf("missed epoch was not reported, got %v", err)
+	}
+}
+
 func TestExecutor_RunMultipleNodeScenario(t *testing.T) {
 
 	clock := NewSimClock()
@@ -100,19 +280,19 @@ func TestExecutor_RunMultipleNodeScenario(t *testing.T) {
 
 	// In this scenario, two nodes are created and stopped.
 	gomock.InOrder(
-		net.EXPECT().CreateNode(gomock.Any()).Return(node1, nil),
-		net.EXPECT().RemoveNode(newIs(node1)),
-		node1.EXPECT().Stop(),
-		node1.EXPECT().Cleanup(),
+		net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).Return(node1, nil),
+		net.EXPECT().RemoveNode(gomock.Any(), newIs(node1)),
+		node1.EXPECT().Stop(gomock.Any()),
+		node1.EXPECT().Cleanup(gomock.Any()),
 	)
 	gomock.InOrder(
-		net.EXPECT().CreateNode(gomock.Any()).Return(node2, nil),
-		net.EXPECT().RemoveNode(newIs(node2)),
-		node2.EXPECT().Stop(),
-		node2.EXPECT().Cleanup(),
+		net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).Return(node2, nil),
+		net.EXPECT().RemoveNode(gomock.Any(), newIs(node2)),
+		node2.EXPECT().Stop(gomock.Any()),
+		node2.EXPECT().Cleanup(gomock.Any()),
 	)
 
-	if err := Run(clock, net, &scenario, true); err != nil {
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
 		t.Errorf("failed to run scenario: %v", err)
 	}
 	want := Seconds(10)
@@ -141,11 +321,11 @@ func TestExecutor_RunSingleApplicationScenario(t *testing.T) {
 	app := driver.NewMockApplication(ctrl)
 
 	// In this scenario, an application is expected to be created and shut down.
-	net.EXPECT().CreateApplication(gomock.Any()).Return(app, nil)
-	app.EXPECT().Start()
-	app.EXPECT().Stop()
+	net.EXPECT().CreateApplication(gomock.Any(), gomock.Any()).Return(app, nil)
+	app.EXPECT().Start(gomock.Any())
+	app.EXPECT().Stop(gomock.Any())
 
-	if err := Run(clock, net, &scenario, true); err != nil {
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
 		t.Errorf("failed to run scenario: %v", err)
 	}
 	want := Seconds(10)
@@ -154,6 +334,71 @@ func TestExecutor_RunSingleApplicationScenario(t *testing.T) {
 	}
 }
 
+func TestExecutor_ApplicationTxTypeIsPassedToNetwork(t *testing.T) {
+	clock := NewSimClock()
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		Applications: []parser.Application{{
+			Name:   "A",
+			Type:   "transfer",
+			TxType: "dynamic_fee",
+			Rate:   parser.Rate{Constant: New[float32](10)},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+
+	net.EXPECT().CreateApplication(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, config *driver.ApplicationConfig) (driver.Application, error) {
+		if got, want := config.TxType, "dynamic_fee"; got != want {
+			t.Errorf("unexpected tx type, wanted %v, got %v", want, got)
+		}
+		return app, nil
+	})
+	app.EXPECT().Start(gomock.Any())
+	app.EXPECT().Stop(gomock.Any())
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
+		t.Errorf("failed to run scenario: %v", err)
+	}
+}
+
+func TestExecutor_ApplicationMixIsPassedToNetwork(t *testing.T) {
+	clock := NewSimClock()
+	mix := []parser.MixComponent{{Type: "transfer", Weight: 7}, {Type: "erc20", Weight: 3}}
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 10,
+		Applications: []parser.Application{{
+			Name: "A",
+			Mix:  mix,
+			Rate: parser.Rate{Constant: New[float32](10)},
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+
+	net.EXPECT().CreateApplication(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, config *driver.ApplicationConfig) (driver.Application, error) {
+		if got, want := config.Type, "mix"; got != want {
+			t.Errorf("unexpected type, wanted %v, got %v", want, got)
+		}
+		if !slices.Equal(config.Mix, mix) {
+			t.Errorf("unexpected mix, wanted %v, got %v", mix, config.Mix)
+		}
+		return app, nil
+	})
+	app.EXPECT().Start(gomock.Any())
+	app.EXPECT().Stop(gomock.Any())
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
+		t.Errorf("failed to run scenario: %v", err)
+	}
+}
+
 func TestExecutor_RunMultipleApplicationScenario(t *testing.T) {
 
 	clock := NewSimClock()
@@ -176,14 +421,14 @@ func TestExecutor_RunMultipleApplicationScenario(t *testing.T) {
 	app2 := driver.NewMockApplication(ctrl)
 
 	// In this scenario, an application is expected to be created and shut down.
-	net.EXPECT().CreateApplication(gomock.Any()).Return(app1, nil)
-	net.EXPECT().CreateApplication(gomock.Any()).Return(app2, nil)
-	app1.EXPECT().Start()
-	app1.EXPECT().Stop()
-	app2.EXPECT().Start()
-	app2.EXPECT().Stop()
-
-	if err := Run(clock, net, &scenario, true); err != nil {
+	net.EXPECT().CreateApplication(gomock.Any(), gomock.Any()).Return(app1, nil)
+	net.EXPECT().CreateApplication(gomock.Any(), gomock.Any()).Return(app2, nil)
+	app1.EXPECT().Start(gomock.Any())
+	app1.EXPECT().Stop(gomock.Any())
+	app2.EXPECT().Start(gomock.Any())
+	app2.EXPECT().Stop(gomock.Any())
+
+	if err := Run(context.Background(), clock, net, &scenario, true, nil); err != nil {
 		t.Errorf("failed to run scenario: %v", err)
 	}
 	want := Seconds(10)
@@ -209,14 +454,16 @@ func TestExecutor_TestUserAbort(t *testing.T) {
 	net := driver.NewMockNetwork(ctrl)
 	node := driver.NewMockNode(ctrl)
 
-	// In this scenario, a node is created, after which a user interrupt is send.
-	net.EXPECT().CreateNode(gomock.Any()).Do(func(_ any) {
-		fmt.Printf("Sending interrupt signal to local process ..\n")
-		syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	// In this scenario, a node is created, after which the user aborts the run.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).Do(func(_, _ any) {
+		cancel()
 	}).Return(node, nil)
 
-	if err := Run(clock, net, &scenario, true); err == nil {
-		t.Errorf("a user interrupt error should be reported")
+	err := Run(ctx, clock, net, &scenario, true, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("a user abort error should be reported, got %v", err)
 	}
 	want := Seconds(1)
 	if got := clock.Now(); got < want || got > want+Seconds(1) {
@@ -224,6 +471,39 @@ func TestExecutor_TestUserAbort(t *testing.T) {
 	}
 }
 
+func TestExecutor_AbortOnIncident(t *testing.T) {
+
+	clock := NewWallTimeClock()
+	scenario := parser.Scenario{
+		Name:     "Test",
+		Duration: 5,
+		Nodes: []parser.Node{{
+			Name:  "A",
+			Start: New[float32](1),
+			End:   New[float32](3),
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	// In this scenario, a node is created, after which an incident is reported.
+	incidents := make(chan error, 1)
+	net.EXPECT().CreateNode(gomock.Any(), gomock.Any()).Do(func(_, _ any) {
+		incidents <- fmt.Errorf("injected failure")
+	}).Return(node, nil)
+
+	err := Run(context.Background(), clock, net, &scenario, true, incidents)
+	if err == nil || !strings.Contains(err.Error(), "injected failure") {
+		t.Errorf("the incident should be reported, got %v", err)
+	}
+	want := Seconds(1)
+	if got := clock.Now(); got < want || got > want+Seconds(1) {
+		t.Errorf("scenario execution did not end on incident, expected end time %v, got %v", want, got)
+	}
+}
+
 func New[T any](value T) *T {
 	res := new(T)
 	*res = value
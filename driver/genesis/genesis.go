@@ -0,0 +1,308 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+// Package genesis builds the genesis of Norma's private networks. A single
+// genesis file is built for each network from the scenario's genesis
+// section and shared by all nodes of the network.
+package genesis
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"github.com/Fantom-foundation/go-opera/evmcore"
+	"github.com/Fantom-foundation/go-opera/inter/validatorpk"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// networkName is the name of Norma's private networks.
+	networkName = "norma-privatenet"
+	// networkId is the chain ID of Norma's private networks.
+	networkId = 4003
+	// numFundedValidatorAccounts is the minimum number of validator accounts
+	// funded in the genesis, allowing validators to be added during a run.
+	numFundedValidatorAccounts = 100
+)
+
+var (
+	// blockZeroTime is the timestamp of the genesis block.
+	blockZeroTime = time.Date(2024, time.November, 1, 0, 0, 0, 0, time.FixedZone("", 60*60))
+	// nodeDriverAddress is the address of the node driver contract through
+	// which the genesis validators are registered in the SFC.
+	nodeDriverAddress = common.HexToAddress("0xd100a01e00000000000000000000000000000000")
+	// validatorAccountBalance is the balance of each funded validator account.
+	validatorAccountBalance = new(big.Int).Mul(big.NewInt(1_000_000_000), ftm)
+	// ftm is the number of wei per FTM.
+	ftm = big.NewInt(1_000_000_000_000_000_000)
+)
+
+// systemContracts contains the accounts of the system contracts and the
+// transaction initializing them. It is the static part of each genesis.
+//
+//go:embed system_contracts.json
+var systemContracts []byte
+
+// Genesis is the JSON genesis format understood by `sonictool genesis json`,
+// as in the client's example genesis. The format has no upgrade heights; the
+// rules, including their upgrades, apply from the genesis block on.
+type Genesis struct {
+	Rules         Rules         `json:"rules"`
+	BlockZeroTime time.Time     `json:"blockZeroTime"`
+	Accounts      []Account     `json:"accounts"`
+	Txs           []Transaction `json:"txs"`
+}
+
+// Rules are the network rules of the genesis.
+type Rules struct {
+	NetworkName         string    `json:"networkName"`
+	NetworkId           uint64    `json:"networkId"`
+	MaxBlockGas         uint64    `json:"MaxBlockGas"`
+	MaxEventGas         uint64    `json:"MaxEventGas"`
+	MaxEpochGas         uint64    `json:"MaxEpochGas"`
+	ShortGasAllocPerSec uint64    `json:"ShortGasAllocPerSec"`
+	LongGasAllocPerSec  uint64    `json:"LongGasAllocPerSec"`
+	Upgrades            *Upgrades `json:"Upgrades,omitempty"` // nil = client defaults
+}
+
+// Upgrades is the set of network upgrades enabled in the genesis.
+type Upgrades struct {
+	Berlin bool `json:"Berlin"`
+	London bool `json:"London"`
+	Llr    bool `json:"Llr"`
+	Sonic  bool `json:"Sonic"`
+}
+
+// Account is an account existing from the start of the network.
+type Account struct {
+	Name    string                      `json:"name"`
+	Address common.Address              `json:"address"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+	Balance *big.Int                    `json:"balance,omitempty"`
+}
+
+// Transaction is a transaction executed while processing the genesis.
+type Transaction struct {
+	Name string         `json:"name"`
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// Build creates the genesis of a network with the given configuration and
+// returns it in JSON format.
+func Build(config *driver.NetworkConfig) ([]byte, error) {
+	genesis, err := create(config)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(genesis, "", "  ")
+}
+
+// create assembles the genesis of a network with the given configuration.
+func create(config *driver.NetworkConfig) (*Genesis, error) {
+	if got, limit := len(config.Genesis.Validators), config.NumberOfValidators; got > limit {
+		return nil, fmt.Errorf("genesis lists %d validators, but the network has only %d", got, limit)
+	}
+
+	var genesis Genesis
+	if err := json.Unmarshal(systemContracts, &genesis); err != nil {
+		return nil, fmt.Errorf("failed to parse system contracts; %v", err)
+	}
+	genesis.BlockZeroTime = blockZeroTime
+
+	rules := config.Genesis.Rules
+	genesis.Rules = Rules{
+		NetworkName:         networkName,
+		NetworkId:           networkId,
+		MaxBlockGas:         config.MaxBlockGas,
+		MaxEventGas:         rules.GetMaxEventGas(),
+		MaxEpochGas:         config.MaxEpochGas,
+		ShortGasAllocPerSec: rules.GetShortGasAllocPerSec(),
+		LongGasAllocPerSec:  rules.GetLongGasAllocPerSec(),
+	}
+	if err := addUpgrades(&genesis, config.Genesis.Upgrades); err != nil {
+		return nil, err
+	}
+
+	// Fund the accounts of validators, including those created during the run.
+	numAccounts := max(numFundedValidatorAccounts, config.NumberOfValidators)
+	for i := 1; i <= numAccounts; i++ {
+		key := evmcore.FakeKey(uint32(i))
+		genesis.Accounts = append(genesis.Accounts, Account{
+			Name:    fmt.Sprintf("validator%d", i),
+			Address: crypto.PubkeyToAddress(key.PublicKey),
+			Balance: new(big.Int).Set(validatorAccountBalance),
+		})
+	}
+
+	// Register and delegate the stake of the genesis validators.
+	for i := 1; i <= config.NumberOfValidators; i++ {
+		stake := uint64(parser.DefaultValidatorStake)
+		if i <= len(config.Genesis.Validators) {
+			stake = config.Genesis.Validators[i-1].GetStake()
+		}
+		txs, err := registerValidator(i, stake)
+		if err != nil {
+			return nil, err
+		}
+		genesis.Txs = append(genesis.Txs, txs...)
+	}
+
+	// Add user defined accounts and contracts.
+	known := map[common.Address]bool{}
+	for _, account := range genesis.Accounts {
+		known[account.Address] = true
+	}
+	for _, account := range config.Genesis.Accounts {
+		converted, err := toAccount(account)
+		if err != nil {
+			return nil, err
+		}
+		if known[converted.Address] {
+			return nil, fmt.Errorf("genesis account %v is already defined", converted.Address)
+		}
+		known[converted.Address] = true
+		genesis.Accounts = append(genesis.Accounts, converted)
+	}
+	return &genesis, nil
+}
+
+// addUpgrades sets the upgrades enabled in the genesis in the network rules.
+// Upgrades enabled later are applied by rule updates during the run instead.
+func addUpgrades(genesis *Genesis, names []string) error {
+	if names == nil {
+		return nil
+	}
+	upgrades := Upgrades{}
+	for _, name := range names {
+		switch name {
+		case "berlin":
+			upgrades.Berlin = true
+		case "london":
+			upgrades.London = true
+		case "llr":
+			upgrades.Llr = true
+		case "sonic":
+			upgrades.Sonic = true
+		default:
+			return fmt.Errorf("unknown network upgrade: %v", name)
+		}
+	}
+	genesis.Rules.Upgrades = &upgrades
+	return nil
+}
+
+// registerValidator creates the transactions registering the validator with
+// the given ID in the SFC and delegating the given stake (in FTM) to it.
+func registerValidator(id int, stake uint64) ([]Transaction, error) {
+	key := evmcore.FakeKey(uint32(id))
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	pubKey := validatorpk.PubKey{
+		Raw:  crypto.FromECDSAPub(&key.PublicKey),
+		Type: validatorpk.Types.Secp256k1,
+	}
+
+	validatorData, err := nodeDriverAbi.Pack("setGenesisValidator", address, big.NewInt(int64(id)), pubKey.Bytes(), big.NewInt(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode validator %d; %v", id, err)
+	}
+	amount := new(big.Int).Mul(new(big.Int).SetUint64(stake), ftm)
+	delegationData, err := nodeDriverAbi.Pack("setGenesisDelegation", address, big.NewInt(int64(id)), amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode delegation of validator %d; %v", id, err)
+	}
+	return []Transaction{
+		{Name: fmt.Sprintf("SetGenesisValidator%d", id), To: nodeDriverAddress, Data: validatorData},
+		{Name: fmt.Sprintf("SetGenesisDelegation%d", id), To: nodeDriverAddress, Data: delegationData},
+	}, nil
+}
+
+// toAccount converts an account of the scenario into a genesis account.
+func toAccount(account parser.GenesisAccount) (Account, error) {
+	if !common.IsHexAddress(account.Address) {
+		return Account{}, fmt.Errorf("invalid genesis account address: %v", account.Address)
+	}
+	res := Account{
+		Name:    account.Name,
+		Address: common.HexToAddress(account.Address),
+		Balance: new(big.Int).Mul(new(big.Int).SetUint64(account.Balance), ftm),
+	}
+
+	code := account.Code
+	if account.CodeFile != nil {
+		content, err := os.ReadFile(*account.CodeFile)
+		if err != nil {
+			return Account{}, fmt.Errorf("failed to read code of genesis account %v; %v", account.Address, err)
+		}
+		hex := strings.TrimSpace(string(content))
+		code = &hex
+	}
+	if code != nil {
+		decoded, err := hexutil.Decode(*code)
+		if err != nil {
+			return Account{}, fmt.Errorf("invalid code of genesis account %v; %v", account.Address, err)
+		}
+		res.Code = decoded
+	}
+
+	if len(account.Storage) > 0 {
+		res.Storage = map[common.Hash]common.Hash{}
+		for key, value := range account.Storage {
+			k, err := hexutil.Decode(key)
+			if err != nil {
+				return Account{}, fmt.Errorf("invalid storage key of genesis account %v; %v", account.Address, err)
+			}
+			v, err := hexutil.Decode(value)
+			if err != nil {
+				return Account{}, fmt.Errorf("invalid storage value of genesis account %v; %v", account.Address, err)
+			}
+			res.Storage[common.BytesToHash(k)] = common.BytesToHash(v)
+		}
+	}
+	return res, nil
+}
+
+// nodeDriverAbi covers the genesis functions of the node driver contract.
+var nodeDriverAbi = mustParseAbi(`[
+	{"type":"function","name":"setGenesisValidator","inputs":[
+		{"name":"auth","type":"address"},
+		{"name":"validatorID","type":"uint256"},
+		{"name":"pubkey","type":"bytes"},
+		{"name":"createdTime","type":"uint256"}]},
+	{"type":"function","name":"setGenesisDelegation","inputs":[
+		{"name":"delegator","type":"address"},
+		{"name":"toValidatorID","type":"uint256"},
+		{"name":"stake","type":"uint256"}]}
+]`)
+
+func mustParseAbi(definition string) abi.ABI {
+	res, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package genesis
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBuild_ProducesValidJson(t *testing.T) {
+	data, err := Build(&driver.NetworkConfig{NumberOfValidators: 2, MaxBlockGas: 1_000, MaxEpochGas: 2_000})
+	if err != nil {
+		t.Fatalf("failed to build genesis: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("genesis is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"rules", "blockZeroTime", "accounts", "txs"} {
+		if _, found := decoded[key]; !found {
+			t.Errorf("genesis is missing key %s", key)
+		}
+	}
+	if _, found := decoded["upgradeHeights"]; found {
+		t.Errorf("genesis should not list upgrade heights by default")
+	}
+}
+
+func TestCreate_RulesAreTakenFromConfig(t *testing.T) {
+	eventGas := uint64(123)
+	genesis, err := create(&driver.NetworkConfig{
+		NumberOfValidators: 1,
+		MaxBlockGas:        1_000,
+		MaxEpochGas:        2_000,
+		Genesis:            parser.Genesis{Rules: parser.NetworkRules{MaxEventGas: &eventGas}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create genesis: %v", err)
+	}
+	want := Rules{
+		NetworkName:         "norma-privatenet",
+		NetworkId:           4003,
+		MaxBlockGas:         1_000,
+		MaxEventGas:         123,
+		MaxEpochGas:         2_000,
+		ShortGasAllocPerSec: parser.DefaultShortGasAllocPerSec,
+		LongGasAllocPerSec:  parser.DefaultLongGasAllocPerSec,
+	}
+	if got := genesis.Rules; got != want {
+		t.Errorf("unexpected rules, wanted %v, got %v", want, got)
+	}
+}
+
+func TestCreate_ValidatorsAreRegisteredWithTheirStakes(t *testing.T) {
+	stake := uint64(1_000)
+	genesis, err := create(&driver.NetworkConfig{
+		NumberOfValidators: 3,
+		Genesis:            parser.Genesis{Validators: []parser.GenesisValidator{{}, {Stake: &stake}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create genesis: %v", err)
+	}
+
+	// The system contracts are initialized first, followed by two
+	// transactions per validator.
+	if got, want := len(genesis.Txs), 1+2*3; got != want {
+		t.Fatalf("unexpected number of transactions, wanted %d, got %d", want, got)
+	}
+	if got, want := genesis.Txs[0].Name, "InitializeAll"; !strings.HasPrefix(got, want) {
+		t.Errorf("unexpected first transaction, wanted %s, got %s", want, got)
+	}
+
+	// The delegation of the first validator uses the default stake of 5M FTM.
+	want := "0xa8ab09ba" +
+		"000000000000000000000000239fa7623354ec26520de878b52f13fe84b06971" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000422ca8b0a00a425000000"
+	if got := genesis.Txs[2].Data.String(); got != want {
+		t.Errorf("unexpected delegation data,\nwanted %s\n   got %s", want, got)
+	}
+	if got, want := genesis.Txs[1].Data.String()[:10], "0x76fed43a"; got != want {
+		t.Errorf("unexpected validator registration selector, wanted %s, got %s", want, got)
+	}
+
+	amount := new(big.Int).Mul(big.NewInt(1_000), ftm)
+	data := genesis.Txs[4].Data
+	if got := new(big.Int).SetBytes(data[len(data)-32:]); got.Cmp(amount) != 0 {
+		t.Errorf("unexpected stake of second validator, wanted %v, got %v", amount, got)
+	}
+	for i, tx := range genesis.Txs[1:] {
+		if tx.To != nodeDriverAddress {
+			t.Errorf("transaction %d is not sent to the node driver", i+1)
+		}
+	}
+}
+
+func TestCreate_ValidatorAccountsAreFunded(t *testing.T) {
+	genesis, err := create(&driver.NetworkConfig{NumberOfValidators: 1})
+	if err != nil {
+		t.Fatalf("failed to create genesis: %v", err)
+	}
+	funded := 0
+	for _, account := range genesis.Accounts {
+		if strings.HasPrefix(account.Name, "validator") {
+			funded++
+		}
+	}
+	if funded != numFundedValidatorAccounts {
+		t.Errorf("unexpected number of funded validator accounts, wanted %d, got %d", numFundedValidatorAccounts, funded)
+	}
+	owner := common.HexToAddress("0x239fA7623354eC26520dE878B52f13Fe84b06971")
+	found := false
+	for _, account := range genesis.Accounts {
+		if account.Address == owner {
+			found = account.Balance.Cmp(validatorAccountBalance) == 0
+		}
+	}
+	if !found {
+		t.Errorf("the SFC owner account is not funded")
+	}
+}
+
+func TestCreate_UserAccountsAreAdded(t *testing.T) {
+	code := "0x6080"
+	file := filepath.Join(t.TempDir(), "code.hex")
+	if err := os.WriteFile(file, []byte("0x60aa\n"), 0600); err != nil {
+		t.Fatalf("failed to write code file: %v", err)
+	}
+	genesis, err := create(&driver.NetworkConfig{
+		NumberOfValidators: 1,
+		Genesis: parser.Genesis{Accounts: []parser.GenesisAccount{
+			{Name: "rich", Address: "0x0000000000000000000000000000000000000042", Balance: 5},
+			{Name: "contract", Address: "0x0000000000000000000000000000000000000043", Code: &code, Storage: map[string]string{"0x01": "0x02"}},
+			{Name: "from file", Address: "0x0000000000000000000000000000000000000044", CodeFile: &file},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create genesis: %v", err)
+	}
+	accounts := genesis.Accounts[len(genesis.Accounts)-3:]
+	if got, want := accounts[0].Balance, new(big.Int).Mul(big.NewInt(5), ftm); got.Cmp(want) != 0 {
+		t.Errorf("unexpected balance, wanted %v, got %v", want, got)
+	}
+	if got, want := accounts[1].Code.String(), "0x6080"; got != want {
+		t.Errorf("unexpected code, wanted %s, got %s", want, got)
+	}
+	if got, want := accounts[1].Storage[common.BigToHash(big.NewInt(1))], common.BigToHash(big.NewInt(2)); got != want {
+		t.Errorf("unexpected storage, wanted %v, got %v", want, got)
+	}
+	if got, want := accounts[2].Code.String(), "0x60aa"; got != want {
+		t.Errorf("unexpected code read from file, wanted %s, got %s", want, got)
+	}
+}
+
+func TestCreate_DuplicateAccountsAreRejected(t *testing.T) {
+	_, err := create(&driver.NetworkConfig{
+		NumberOfValidators: 1,
+		Genesis: parser.Genesis{Accounts: []parser.GenesisAccount{
+			{Address: "0x239fA7623354eC26520dE878B52f13Fe84b06971"},
+		}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "already defined") {
+		t.Errorf("duplicate account was not detected, got %v", err)
+	}
+}
+
+func TestCreate_UpgradesAreConverted(t *testing.T) {
+	genesis, err := create(&driver.NetworkConfig{
+		NumberOfValidators: 1,
+		Genesis:            parser.Genesis{Upgrades: []string{"berlin", "london", "llr"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create genesis: %v", err)
+	}
+	if got, want := genesis.Rules.Upgrades, (&Upgrades{Berlin: true, London: true, Llr: true}); got == nil || *got != *want {
+		t.Errorf("unexpected genesis upgrades, wanted %v, got %v", want, got)
+	}
+}
+
+func TestBuild_OnlyKeysOfClientGenesisAreProduced(t *testing.T) {
+	data, err := Build(&driver.NetworkConfig{
+		NumberOfValidators: 1,
+		Genesis:            parser.Genesis{Upgrades: []string{"sonic"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build genesis: %v", err)
+	}
+	var genesis map[string]json.RawMessage
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		t.Fatalf("failed to parse genesis: %v", err)
+	}
+	// the keys of the example genesis of the client
+	for key := range genesis {
+		if !slices.Contains([]string{"rules", "blockZeroTime", "accounts", "txs"}, key) {
+			t.Errorf("unexpected key %q in genesis, it would be ignored by the client", key)
+		}
+	}
+}
+
+func TestCreate_ClientDefaultsAreKeptWithoutUpgrades(t *testing.T) {
+	genesis, err := create(&driver.NetworkConfig{NumberOfValidators: 1})
+	if err != nil {
+		t.Fatalf("failed to create genesis: %v", err)
+	}
+	if genesis.Rules.Upgrades != nil {
+		t.Errorf("upgrades should be left to the client, got %v", genesis.Rules.Upgrades)
+	}
+}
+
+func TestCreate_TooManyValidatorsAreRejected(t *testing.T) {
+	_, err := create(&driver.NetworkConfig{
+		NumberOfValidators: 1,
+		Genesis:            parser.Genesis{Validators: make([]parser.GenesisValidator, 2)},
+	})
+	if err == nil {
+		t.Errorf("too many validators were not detected")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// ComponentReceivedTransactions is a metric capturing the number of transactions received
+// by each application type of applications mixing several types. The subject of a series
+// is the application and the type of the component, separated by a slash.
+var ComponentReceivedTransactions = mon.Metric[mon.App, mon.Series[mon.Time, int]]{
+	Name:        "ComponentReceivedTransactions",
+	Description: "The number of transactions received by the components of mixed applications over time",
+}
+
+func init() {
+	if err := mon.RegisterSource(ComponentReceivedTransactions, newComponentReceivedTransactionsSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// componentReceivedTransactionsSource is a data source periodically collecting
+// the received transactions of each component of mixed applications.
+type componentReceivedTransactionsSource struct {
+	*utils.PeriodicDataSource[mon.App, int]
+}
+
+func newComponentReceivedTransactionsSource(monitor *mon.Monitor) mon.Source[mon.App, mon.Series[mon.Time, int]] {
+	return newComponentReceivedTransactionsSourceWithPeriod(monitor, time.Second)
+}
+
+func newComponentReceivedTransactionsSourceWithPeriod(monitor *mon.Monitor, period time.Duration) mon.Source[mon.App, mon.Series[mon.Time, int]] {
+	res := &componentReceivedTransactionsSource{
+		PeriodicDataSource: utils.NewPeriodicDataSourceWithPeriod(ComponentReceivedTransactions, monitor, period),
+	}
+
+	monitor.Network().RegisterListener(res)
+	for _, app := range monitor.Network().GetActiveApplications() {
+		res.AfterApplicationCreation(app)
+	}
+
+	return res
+}
+
+// GetComponentSubject returns the subject of the series of the given component
+// of an application.
+func GetComponentSubject(app string, component string) mon.App {
+	return mon.App(fmt.Sprintf("%s/%s", app, component))
+}
+
+func (s *componentReceivedTransactionsSource) AfterNodeCreation(driver.Node) {
+	// ignored
+}
+
+func (s *componentReceivedTransactionsSource) AfterNodeRemoval(driver.Node) {
+	// ignored
+}
+
+func (s *componentReceivedTransactionsSource) AfterApplicationCreation(app driver.Application) {
+	label := app.Config().Name
+	for _, component := range app.Config().Mix {
+		sensor := &componentReceivedTransactionsSensor{app: app, component: component.Type}
+		if err := s.AddSubject(GetComponentSubject(label, component.Type), sensor); err != nil {
+			log.Printf("failed to add component %s of app %s to metric %v: %v", component.Type, label, s.GetMetric().Name, err)
+		}
+	}
+}
+
+type componentReceivedTransactionsSensor struct {
+	app       driver.Application
+	component string
+}
+
+func (s *componentReceivedTransactionsSensor) ReadValue(ctx context.Context) (int, error) {
+	counts, err := s.app.GetReceivedTransactionsByComponent(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, found := counts[s.component]
+	if !found {
+		return 0, fmt.Errorf("no received transactions reported for component %s", s.component)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/exp/slices"
+)
+
+func TestComponentReceivedTransactions_ComponentsOfMixedAppsAreTracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+
+	single := driver.NewMockApplication(ctrl)
+	single.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A", Type: "counter"})
+	mixed := driver.NewMockApplication(ctrl)
+	mixed.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "B", Type: "mix", Mix: []parser.MixComponent{
+		{Type: "transfer", Weight: 7},
+		{Type: "erc20", Weight: 3},
+	}})
+	mixed.EXPECT().GetReceivedTransactionsByComponent(gomock.Any()).AnyTimes().Return(map[string]uint64{"transfer": 70, "erc20": 30}, nil)
+
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().UnregisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{}).AnyTimes()
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{single, mixed}).AnyTimes()
+
+	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to start monitor instance: %v", err)
+	}
+	source := newComponentReceivedTransactionsSourceWithPeriod(monitor, 50*time.Millisecond)
+
+	subjects := source.GetSubjects()
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i] < subjects[j] })
+	want := []mon.App{GetComponentSubject("B", "erc20"), GetComponentSubject("B", "transfer")}
+	if !slices.Equal(subjects, want) {
+		t.Errorf("invalid list of subjects, wanted %v, got %v", want, subjects)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if err := source.Shutdown(); err != nil {
+		t.Errorf("errors encountered during shutdown: %v", err)
+	}
+
+	for subject, count := range map[mon.App]int{want[0]: 30, want[1]: 70} {
+		data, exists := source.GetData(subject)
+		if data == nil || !exists {
+			t.Fatalf("no data found for %s", subject)
+		}
+		points := data.GetRange(mon.Time(0), mon.Time(math.MaxInt64))
+		if len(points) == 0 {
+			t.Errorf("no data collected for %s", subject)
+		}
+		for _, point := range points {
+			if point.Value != count {
+				t.Errorf("unexpected value for %s, wanted %d, got %d", subject, count, point.Value)
+			}
+		}
+	}
+}
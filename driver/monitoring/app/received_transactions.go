@@ -17,6 +17,7 @@
 package appmon
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Fantom-foundation/Norma/driver"
@@ -58,8 +59,8 @@ type receivedTransactionsSensor struct {
 	app driver.Application
 }
 
-func (s *receivedTransactionsSensor) ReadValue() (int, error) {
-	count, err := s.app.GetReceivedTransactions()
+func (s *receivedTransactionsSensor) ReadValue(ctx context.Context) (int, error) {
+	count, err := s.app.GetReceivedTransactions(ctx)
 	if err != nil {
 		return 0, err
 	}
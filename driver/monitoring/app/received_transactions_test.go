@@ -17,6 +17,7 @@
 package appmon
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Fantom-foundation/Norma/driver"
@@ -29,13 +30,13 @@ func TestReceivedTransactionSensorReportsProperValue(t *testing.T) {
 	factory := &receivedTransactionsSensorFactory{}
 	for _, expected := range tests {
 		application := driver.NewMockApplication(ctrl)
-		application.EXPECT().GetReceivedTransactions().Return(expected, nil)
+		application.EXPECT().GetReceivedTransactions(gomock.Any()).Return(expected, nil)
 
 		sensor, err := factory.CreateSensor(application)
 		if err != nil {
 			t.Fatalf("creation of sensor failed: %v", err)
 		}
-		if res, err := sensor.ReadValue(); err != nil || res != int(expected) {
+		if res, err := sensor.ReadValue(context.Background()); err != nil || res != int(expected) {
 			t.Errorf("sensor fetched wrong value, wanted %d, got %d, err %v", expected, res, err)
 		}
 	}
@@ -18,6 +18,7 @@ package monitoring
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"sync"
 	"testing"
@@ -167,7 +168,7 @@ func TestMonitorIntegrationPrometheusLogReceived(t *testing.T) {
 	t.Cleanup(func() {
 		_ = client.Close()
 	})
-	node, err := opera.StartOperaDockerNode(client, nil, &opera.OperaNodeConfig{
+	node, err := opera.StartOperaDockerNode(context.Background(), client, nil, &opera.OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
@@ -175,7 +176,7 @@ func TestMonitorIntegrationPrometheusLogReceived(t *testing.T) {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 
 	// simulate existing nodes
@@ -0,0 +1,157 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockEffectiveGasPrice records the average gas price paid for the gas used
+// in each block, i.e. the fees paid by all transactions of the block divided
+// by the gas they used. The effective gas price of dynamic fee transactions
+// is the base fee of the block plus the tip paid, bounded by the fee cap of
+// the transaction.
+var BlockEffectiveGasPrice = mon.Metric[mon.Network, mon.Series[mon.BlockNumber, int]]{
+	Name:        "BlockEffectiveGasPrice",
+	Description: "The effective gas price of the transactions in a block, weighted by their gas used",
+}
+
+func init() {
+	if err := mon.RegisterSource(BlockEffectiveGasPrice, NewEffectiveGasPriceSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// effectiveGasPriceSource is a monitoring data source periodically collecting
+// the effective gas prices of the blocks added to the chain since its last
+// visit.
+type effectiveGasPriceSource struct {
+	*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, int]
+	data      *mon.SyncedSeries[mon.BlockNumber, int]
+	lastBlock int64 // the last block visited, -1 if none
+	stop      chan<- bool
+	done      <-chan bool
+}
+
+// NewEffectiveGasPriceSource creates a new data source periodically collecting
+// the effective gas prices paid in the blocks of the network.
+func NewEffectiveGasPriceSource(monitor *mon.Monitor) mon.Source[mon.Network, mon.Series[mon.BlockNumber, int]] {
+	return newEffectiveGasPriceSource(monitor, time.Second)
+}
+
+func newEffectiveGasPriceSource(monitor *mon.Monitor, period time.Duration) *effectiveGasPriceSource {
+	stop := make(chan bool)
+	done := make(chan bool)
+
+	res := &effectiveGasPriceSource{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(BlockEffectiveGasPrice),
+		lastBlock:          -1,
+		stop:               stop,
+		done:               done,
+	}
+	res.data = res.GetOrAddSubject(mon.Network{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				rpcClient, err := monitor.Network().DialRandomRpc()
+				if err != nil {
+					log.Printf("failed to dial RPC for effective gas prices; %v", err)
+					continue
+				}
+				if err := res.update(rpcClient); err != nil {
+					log.Printf("failed to collect effective gas prices; %v", err)
+				}
+				rpcClient.Close()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return res
+}
+
+// rpcReceipt is the part of a transaction receipt obtained through the RPC
+// interface needed to derive the effective gas price of a block.
+type rpcReceipt struct {
+	GasUsed           hexutil.Uint64
+	EffectiveGasPrice *hexutil.Big
+}
+
+// update records the effective gas prices of all blocks added since the last
+// update. On the first update, only the head of the chain is recorded.
+func (s *effectiveGasPriceSource) update(rpcClient rpc.RpcClient) error {
+	var head hexutil.Uint64
+	if err := rpcClient.Call(&head, "eth_blockNumber"); err != nil {
+		return err
+	}
+	from := int64(head)
+	if s.lastBlock >= 0 {
+		from = s.lastBlock + 1
+	}
+	for number := from; number <= int64(head); number++ {
+		var receipts []rpcReceipt
+		if err := rpcClient.Call(&receipts, "eth_getBlockReceipts", hexutil.EncodeUint64(uint64(number))); err != nil {
+			return err
+		}
+		if price, ok := getEffectiveGasPrice(receipts); ok {
+			if err := s.data.Append(mon.BlockNumber(number), price); err != nil {
+				return err
+			}
+		}
+		s.lastBlock = number
+	}
+	return nil
+}
+
+// getEffectiveGasPrice computes the gas price paid for the gas used by the
+// transactions with the given receipts, i.e. the average of their effective
+// gas prices weighted by their gas used. It reports false if no gas was used.
+func getEffectiveGasPrice(receipts []rpcReceipt) (int, bool) {
+	fees, gas := new(big.Int), new(big.Int)
+	for _, receipt := range receipts {
+		if receipt.EffectiveGasPrice == nil {
+			continue
+		}
+		used := new(big.Int).SetUint64(uint64(receipt.GasUsed))
+		gas.Add(gas, used)
+		fees.Add(fees, used.Mul(used, receipt.EffectiveGasPrice.ToInt()))
+	}
+	if gas.Sign() == 0 {
+		return 0, false
+	}
+	return int(fees.Div(fees, gas).Int64()), true
+}
+
+func (s *effectiveGasPriceSource) Shutdown() error {
+	close(s.stop)
+	<-s.done
+	return s.SyncedSeriesSource.Shutdown()
+}
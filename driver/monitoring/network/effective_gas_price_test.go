@@ -0,0 +1,99 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEffectiveGasPrice_PricesOfNewBlocksAreRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	receipts := map[uint64]string{
+		3: `[{"gasUsed":"0x5208","effectiveGasPrice":"0x64"},{"gasUsed":"0x5208","effectiveGasPrice":"0xc8"}]`,
+		4: `[]`,
+		5: `[{"gasUsed":"0x5208","effectiveGasPrice":"0x3e8"}]`,
+	}
+	head := uint64(3)
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_blockNumber").AnyTimes().DoAndReturn(
+		func(result any, _ string, _ ...any) error {
+			*result.(*hexutil.Uint64) = hexutil.Uint64(head)
+			return nil
+		})
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_getBlockReceipts", gomock.Any()).AnyTimes().DoAndReturn(
+		func(result any, _ string, args ...any) error {
+			number, err := hexutil.DecodeUint64(args[0].(string))
+			if err != nil {
+				return err
+			}
+			block, found := receipts[number]
+			if !found {
+				return fmt.Errorf("unknown block %d", number)
+			}
+			return json.Unmarshal([]byte(block), result)
+		})
+
+	source := &effectiveGasPriceSource{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(BlockEffectiveGasPrice),
+		lastBlock:          -1,
+	}
+	source.data = source.GetOrAddSubject(monitoring.Network{})
+
+	if err := source.update(rpcClient); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	head = 5
+	if err := source.update(rpcClient); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	data := source.data.GetRange(monitoring.BlockNumber(0), monitoring.BlockNumber(math.MaxInt))
+	if len(data) != 2 {
+		t.Fatalf("unexpected number of data points, wanted 2, got %v", data)
+	}
+	if data[0].Position != 3 || data[0].Value != 150 {
+		t.Errorf("unexpected price of block 3, got %v", data[0])
+	}
+	if data[1].Position != 5 || data[1].Value != 1000 {
+		t.Errorf("unexpected price of block 5, got %v", data[1])
+	}
+}
+
+func TestEffectiveGasPrice_PricesAreWeightedByGasUsed(t *testing.T) {
+	var receipts []rpcReceipt
+	err := json.Unmarshal([]byte(`[{"gasUsed":"0x3","effectiveGasPrice":"0x64"},{"gasUsed":"0x1","effectiveGasPrice":"0x1f4"}]`), &receipts)
+	if err != nil {
+		t.Fatalf("failed to decode receipts: %v", err)
+	}
+	// (3*100 + 1*500) / 4 = 200, while the mean of the prices would be 300.
+	if price, ok := getEffectiveGasPrice(receipts); !ok || price != 200 {
+		t.Errorf("unexpected effective gas price, wanted 200, got %d", price)
+	}
+	if _, ok := getEffectiveGasPrice(nil); ok {
+		t.Errorf("blocks without transactions should have no effective gas price")
+	}
+}
@@ -40,7 +40,7 @@ func TestNumNodeRetrievesNodeCount(t *testing.T) {
 		for i := 0; i < numNodes; i++ {
 			node := driver.NewMockNode(ctrl)
 			node.EXPECT().GetLabel().Return(fmt.Sprintf("%d", i)).AnyTimes()
-			node.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader(monitoring.Node1TestLog)), nil)
+			node.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader(monitoring.Node1TestLog)), nil)
 			url1 := driver.URL("node")
 			node.EXPECT().GetServiceUrl(gomock.Any()).AnyTimes().Return(&url1)
 			nodes = append(nodes, node)
@@ -54,6 +54,9 @@ func newNodeBlockStatusSource(monitor *mon.Monitor, period time.Duration) mon.So
 type blockProgressSensorFactory struct{}
 
 func (f *blockProgressSensorFactory) CreateSensor(node driver.Node) (utils.Sensor[mon.BlockStatus], error) {
+	if node.GetType() == driver.ObserverNode {
+		return nil, fmt.Errorf("observer nodes do not offer the eth API")
+	}
 	url := node.GetServiceUrl(&opera.OperaRpcService)
 	if url == nil {
 		return nil, fmt.Errorf("node does not export an RPC server")
@@ -70,9 +73,9 @@ type blockProgressSensor struct {
 	rpcClient *rpc.Client
 }
 
-func (s *blockProgressSensor) ReadValue() (mon.BlockStatus, error) {
+func (s *blockProgressSensor) ReadValue(ctx context.Context) (mon.BlockStatus, error) {
 	var raw map[string]interface{}
-	err := s.rpcClient.Call(&raw, "eth_getBlockByNumber", "latest", false)
+	err := s.rpcClient.CallContext(ctx, &raw, "eth_getBlockByNumber", "latest", false)
 	if err != nil {
 		return mon.BlockStatus{}, err
 	}
@@ -87,5 +90,5 @@ func (s *blockProgressSensor) ReadValue() (mon.BlockStatus, error) {
 		return mon.BlockStatus{}, err
 	}
 
-	return mon.BlockStatus{epoch, number}, nil
+	return mon.BlockStatus{Epoch: epoch, BlockHeight: number}, nil
 }
@@ -62,9 +62,13 @@ func TestNodeBlockHeightSourceRetrievesBlockHeight(t *testing.T) {
 	node2.EXPECT().GetServiceUrl(gomock.Any()).AnyTimes().Return(&url)
 	node3.EXPECT().GetServiceUrl(gomock.Any()).AnyTimes().Return(&url)
 
-	node1.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
-	node2.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
-	node3.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	node1.EXPECT().GetType().AnyTimes().Return(driver.ValidatorNode)
+	node2.EXPECT().GetType().AnyTimes().Return(driver.RpcNode)
+	node3.EXPECT().GetType().AnyTimes().Return(driver.ValidatorNode)
+
+	node1.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	node2.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	node3.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
 
 	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
 	net.EXPECT().UnregisterListener(gomock.Any()).AnyTimes()
@@ -206,3 +210,14 @@ func getBlockHeight(w http.ResponseWriter, r *http.Request) {
 	}`
 	io.WriteString(w, response)
 }
+
+func TestNodeBlockHeightSourceSkipsObservers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetType().Return(driver.ObserverNode)
+
+	factory := &blockProgressSensorFactory{}
+	if _, err := factory.CreateSensor(node); err == nil {
+		t.Errorf("no sensor should be created for observer nodes")
+	}
+}
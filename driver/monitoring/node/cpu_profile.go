@@ -17,6 +17,7 @@
 package nodemon
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -92,7 +93,7 @@ type cpuProfileSensor struct {
 	numProfiles int
 }
 
-func (s *cpuProfileSensor) ReadValue() (string, error) {
+func (s *cpuProfileSensor) ReadValue(context.Context) (string, error) {
 	data, err := GetPprofData(s.node, s.duration)
 	if err != nil {
 		return "", err
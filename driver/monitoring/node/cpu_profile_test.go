@@ -17,6 +17,7 @@
 package nodemon
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -33,7 +34,7 @@ func TestCanCollectCpuProfileDateFromOperaNode(t *testing.T) {
 	t.Cleanup(func() {
 		_ = docker.Close()
 	})
-	node, err := opera.StartOperaDockerNode(docker, nil, &opera.OperaNodeConfig{
+	node, err := opera.StartOperaDockerNode(context.Background(), docker, nil, &opera.OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
@@ -41,7 +42,7 @@ func TestCanCollectCpuProfileDateFromOperaNode(t *testing.T) {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 	data, err := GetPprofData(node, time.Second)
 	if err != nil {
@@ -75,6 +75,7 @@ func (s *periodicNodeDataSource[T]) AfterNodeCreation(node driver.Node) {
 	sensor, err := s.factory.CreateSensor(node)
 	if err != nil {
 		log.Printf("failed to create sensor for metric %v / node %s: %v", s.GetMetric().Name, label, err)
+		return
 	}
 	s.AddSubject(mon.Node(label), sensor)
 }
@@ -17,6 +17,7 @@
 package nodemon
 
 import (
+	"context"
 	"io"
 	"math"
 	"sort"
@@ -45,7 +46,7 @@ type testSensor struct {
 	next int
 }
 
-func (s *testSensor) ReadValue() (int, error) {
+func (s *testSensor) ReadValue(context.Context) (int, error) {
 	s.next++
 	return s.next, nil
 }
@@ -83,9 +84,9 @@ func TestNodeSourceRetrievesSensorData(t *testing.T) {
 	net.EXPECT().UnregisterListener(gomock.Any()).AnyTimes()
 	net.EXPECT().GetActiveNodes().Return([]driver.Node{node1, node2}).AnyTimes()
 
-	node1.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
-	node2.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
-	node3.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	node1.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	node2.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	node3.EXPECT().StreamLog(gomock.Any()).AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
 
 	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
 	if err != nil {
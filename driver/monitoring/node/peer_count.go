@@ -0,0 +1,74 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// NodePeerCount records the number of peers nodes are connected to, such that
+// gaps in the topology, e.g. after restarts of nodes, can be identified.
+var NodePeerCount = mon.Metric[mon.Node, mon.Series[mon.Time, int]]{
+	Name:        "NodePeerCount",
+	Description: "The number of peers connected to nodes at various times.",
+}
+
+func init() {
+	if err := mon.RegisterSource(NodePeerCount, NewNodePeerCountSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// NewNodePeerCountSource creates a new data source periodically recording the
+// number of peers of nodes.
+func NewNodePeerCountSource(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, int]] {
+	return newNodePeerCountSource(monitor, 5*time.Second)
+}
+
+func newNodePeerCountSource(monitor *mon.Monitor, period time.Duration) mon.Source[mon.Node, mon.Series[mon.Time, int]] {
+	return newPeriodicNodeDataSource[int](NodePeerCount, monitor, period, &peerCountSensorFactory{})
+}
+
+type peerCountSensorFactory struct{}
+
+func (f *peerCountSensorFactory) CreateSensor(node driver.Node) (utils.Sensor[int], error) {
+	return &peerCountSensor{node}, nil
+}
+
+type peerCountSensor struct {
+	node driver.Node
+}
+
+func (s *peerCountSensor) ReadValue(context.Context) (int, error) {
+	rpcClient, err := s.node.DialRpc()
+	if err != nil {
+		return 0, err
+	}
+	defer rpcClient.Close()
+	var peers []json.RawMessage
+	if err := rpcClient.Call(&peers, "admin_peers"); err != nil {
+		return 0, err
+	}
+	return len(peers), nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPeerCountSensor_ReportsNumberOfPeers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	client := rpc.NewMockRpcClient(ctrl)
+	node.EXPECT().DialRpc().Return(client, nil)
+	client.EXPECT().Call(gomock.Any(), "admin_peers").DoAndReturn(func(result any, method string, args ...any) error {
+		*result.(*[]json.RawMessage) = []json.RawMessage{[]byte(`{"enode":"enode://a"}`), []byte(`{"enode":"enode://b"}`)}
+		return nil
+	})
+	client.EXPECT().Close()
+
+	sensor, err := (&peerCountSensorFactory{}).CreateSensor(node)
+	if err != nil {
+		t.Fatalf("failed to create sensor: %v", err)
+	}
+	value, err := sensor.ReadValue(context.Background())
+	if err != nil {
+		t.Fatalf("failed to read value: %v", err)
+	}
+	if value != 2 {
+		t.Errorf("unexpected number of peers, wanted 2, got %d", value)
+	}
+}
+
+func TestPeerCountSensor_ForwardsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	client := rpc.NewMockRpcClient(ctrl)
+	node.EXPECT().DialRpc().Return(client, nil)
+	client.EXPECT().Call(gomock.Any(), "admin_peers").Return(fmt.Errorf("injected"))
+	client.EXPECT().Close()
+
+	sensor, err := (&peerCountSensorFactory{}).CreateSensor(node)
+	if err != nil {
+		t.Fatalf("failed to create sensor: %v", err)
+	}
+	if _, err := sensor.ReadValue(context.Background()); err == nil {
+		t.Errorf("failure to obtain peers should be reported")
+	}
+}
@@ -17,6 +17,7 @@
 package nodemon
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"testing"
@@ -98,7 +99,7 @@ func TestLogsIntegrationGetRealMetric(t *testing.T) {
 	t.Cleanup(func() {
 		_ = client.Close()
 	})
-	node, err := opera.StartOperaDockerNode(client, nil, &opera.OperaNodeConfig{
+	node, err := opera.StartOperaDockerNode(context.Background(), client, nil, &opera.OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
@@ -106,7 +107,7 @@ func TestLogsIntegrationGetRealMetric(t *testing.T) {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 
 	// simulate existing nodes
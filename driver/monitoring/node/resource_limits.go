@@ -0,0 +1,68 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// NodeResourceLimits records the host resource limits applied to nodes, such
+// that measurements can be related to the resources available to each node.
+var NodeResourceLimits = mon.Metric[mon.Node, mon.Series[mon.Time, string]]{
+	Name:        "NodeResourceLimits",
+	Description: "The CPU, memory, and process limits applied to nodes at various times.",
+}
+
+func init() {
+	if err := mon.RegisterSource(NodeResourceLimits, NewNodeResourceLimitsSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// NewNodeResourceLimitsSource creates a new data source periodically recording
+// the resource limits applied to nodes.
+func NewNodeResourceLimitsSource(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, string]] {
+	return newNodeResourceLimitsSource(monitor, 10*time.Second)
+}
+
+func newNodeResourceLimitsSource(monitor *mon.Monitor, period time.Duration) mon.Source[mon.Node, mon.Series[mon.Time, string]] {
+	return newPeriodicNodeDataSource[string](NodeResourceLimits, monitor, period, &resourceLimitsSensorFactory{})
+}
+
+type resourceLimitsSensorFactory struct{}
+
+func (f *resourceLimitsSensorFactory) CreateSensor(node driver.Node) (utils.Sensor[string], error) {
+	return &resourceLimitsSensor{node}, nil
+}
+
+type resourceLimitsSensor struct {
+	node driver.Node
+}
+
+func (s *resourceLimitsSensor) ReadValue(ctx context.Context) (string, error) {
+	limits, err := s.node.GetResourceLimits(ctx)
+	if err != nil {
+		return "", err
+	}
+	return limits.String(), nil
+}
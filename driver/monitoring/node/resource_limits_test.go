@@ -0,0 +1,59 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/network"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResourceLimitsSensor_ReportsLimitsOfNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetResourceLimits(gomock.Any()).Return(network.ResourceLimits{Cpus: 2, Pids: 64}, nil)
+
+	sensor, err := (&resourceLimitsSensorFactory{}).CreateSensor(node)
+	if err != nil {
+		t.Fatalf("failed to create sensor: %v", err)
+	}
+	value, err := sensor.ReadValue(context.Background())
+	if err != nil {
+		t.Fatalf("failed to read value: %v", err)
+	}
+	if want := "cpus=2 pids=64"; value != want {
+		t.Errorf("unexpected value, wanted %q, got %q", want, value)
+	}
+}
+
+func TestResourceLimitsSensor_ForwardsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetResourceLimits(gomock.Any()).Return(network.ResourceLimits{}, fmt.Errorf("injected"))
+
+	sensor, err := (&resourceLimitsSensorFactory{}).CreateSensor(node)
+	if err != nil {
+		t.Fatalf("failed to create sensor: %v", err)
+	}
+	if _, err := sensor.ReadValue(context.Background()); err == nil {
+		t.Errorf("failure to obtain limits should be reported")
+	}
+}
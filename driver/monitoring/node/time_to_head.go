@@ -0,0 +1,121 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// NodeTimeToHead records the time nodes joining the network during a run
+// needed to catch up with the network. It is measured from the start of the
+// node, including the bootstrapping of its state, until the node processed a
+// block at the height the network was at when the node joined.
+var NodeTimeToHead = monitoring.Metric[monitoring.Node, monitoring.Series[monitoring.Time, time.Duration]]{
+	Name:        "NodeTimeToHead",
+	Description: "The time needed by nodes joining the network to reach the head of the chain.",
+}
+
+func init() {
+	if err := monitoring.RegisterSource(NodeTimeToHead, newTimeToHeadSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// TimeToHeadSource tracks the block heights reported by the logs of all
+// nodes to determine when nodes joining the network reached its head.
+type TimeToHeadSource struct {
+	*utils.SyncedSeriesSource[monitoring.Node, monitoring.Time, time.Duration]
+	monitor *monitoring.Monitor
+	now     func() time.Time
+	head    int                             // highest block seen by any node
+	joining map[monitoring.Node]joiningNode // nodes not yet at the head
+	mutex   sync.Mutex
+}
+
+type joiningNode struct {
+	started time.Time
+	target  int
+}
+
+// NewTimeToHeadSource creates a source recording the time to head of nodes
+// created after the source.
+func NewTimeToHeadSource(monitor *monitoring.Monitor) *TimeToHeadSource {
+	res := &TimeToHeadSource{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(NodeTimeToHead),
+		monitor:            monitor,
+		now:                time.Now,
+		joining:            map[monitoring.Node]joiningNode{},
+	}
+	monitor.Network().RegisterListener(res)
+	monitor.NodeLogProvider().RegisterLogListener(res)
+	return res
+}
+
+// newTimeToHeadSource is the same as its public counterpart, it only returns the Source interface instead of the struct to be used in factories
+func newTimeToHeadSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.Node, monitoring.Series[monitoring.Time, time.Duration]] {
+	return NewTimeToHeadSource(monitor)
+}
+
+func (s *TimeToHeadSource) Shutdown() error {
+	s.monitor.Network().UnregisterListener(s)
+	s.monitor.NodeLogProvider().UnregisterLogListener(s)
+	return s.SyncedSeriesSource.Shutdown()
+}
+
+func (s *TimeToHeadSource) AfterNodeCreation(node driver.Node) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.joining[monitoring.Node(node.GetLabel())] = joiningNode{
+		started: node.GetStartTime(),
+		target:  s.head,
+	}
+}
+
+func (s *TimeToHeadSource) AfterNodeRemoval(node driver.Node) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.joining, monitoring.Node(node.GetLabel()))
+}
+
+func (s *TimeToHeadSource) AfterApplicationCreation(driver.Application) {
+	// ignored
+}
+
+func (s *TimeToHeadSource) OnBlock(node monitoring.Node, block monitoring.Block) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if block.Height > s.head {
+		s.head = block.Height
+	}
+	joining, found := s.joining[node]
+	if !found || block.Height < joining.target {
+		return
+	}
+	delete(s.joining, node)
+	now := s.now()
+	series := s.GetOrAddSubject(node)
+	if err := series.Append(monitoring.NewTime(now), now.Sub(joining.started)); err != nil {
+		log.Printf("failed to add time to head of node %s: %v", node, err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimeToHeadSource_RecordsTimeUntilJoiningNodeReachesHead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().UnregisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+	source := NewTimeToHeadSource(monitor)
+	defer source.Shutdown()
+
+	start := time.Unix(1000, 0)
+	now := start
+	source.now = func() time.Time { return now }
+
+	// The network is at block 10 when the new node joins.
+	source.OnBlock("A", monitoring.Block{Height: 10})
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().AnyTimes().Return("B")
+	node.EXPECT().GetStartTime().Return(start)
+	source.AfterNodeCreation(node)
+
+	now = start.Add(5 * time.Second)
+	source.OnBlock("B", monitoring.Block{Height: 5})
+	if _, exists := source.GetData("B"); exists {
+		t.Fatalf("node should not be at head yet")
+	}
+
+	now = start.Add(8 * time.Second)
+	source.OnBlock("B", monitoring.Block{Height: 10})
+	now = start.Add(9 * time.Second)
+	source.OnBlock("B", monitoring.Block{Height: 11})
+
+	series, exists := source.GetData("B")
+	if !exists {
+		t.Fatalf("time to head of node was not recorded")
+	}
+	points := series.GetRange(monitoring.NewTime(start), monitoring.NewTime(now.Add(time.Second)))
+	if len(points) != 1 {
+		t.Fatalf("expected a single data point, got %v", points)
+	}
+	if got, want := points[0].Value, 8*time.Second; got != want {
+		t.Errorf("unexpected time to head, wanted %v, got %v", want, got)
+	}
+	if _, exists := source.GetData("A"); exists {
+		t.Errorf("time to head should only be recorded for joining nodes")
+	}
+}
+
+func TestTimeToHeadSource_RemovedNodesAreNotTracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().UnregisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+	source := NewTimeToHeadSource(monitor)
+	defer source.Shutdown()
+
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().AnyTimes().Return("A")
+	node.EXPECT().GetStartTime().Return(time.Now())
+	source.AfterNodeCreation(node)
+	source.AfterNodeRemoval(node)
+
+	source.OnBlock("A", monitoring.Block{Height: 1})
+	if _, exists := source.GetData("A"); exists {
+		t.Errorf("time to head of removed node should not be recorded")
+	}
+}
@@ -17,6 +17,7 @@
 package monitoring
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -117,13 +118,15 @@ func (n *NodeLogDispatcher) AfterNodeCreation(node driver.Node) {
 
 	// open new log stream only when the node has not been in the map yet
 	if _, exists := n.nodes[Node(nodeId)]; !exists {
+		// The logs are followed until the node terminates.
+		ctx := context.Background()
 		n.wg.Add(1)
 
 		// Start a goroutine collecting the log and writting it into a file.
-		go n.runLogCollector(node)
+		go n.runLogCollector(ctx, node)
 
 		// Start a goroutine parsing the log and dispatching block information.
-		logStream, err := node.StreamLog()
+		logStream, err := node.StreamLog(ctx)
 		if err != nil {
 			log.Printf("failed to obtain logs of node, will not be able to track blocks: %v", err)
 			return // do not start dispatch on error
@@ -184,10 +187,10 @@ func (n *NodeLogDispatcher) startDispatcher(node Node, reader io.ReadCloser) {
 	}()
 }
 
-func (n *NodeLogDispatcher) runLogCollector(node driver.Node) {
+func (n *NodeLogDispatcher) runLogCollector(ctx context.Context, node driver.Node) {
 	defer n.wg.Done()
 	label := node.GetLabel()
-	in, err := node.StreamLog()
+	in, err := node.StreamLog(ctx)
 	if err != nil {
 		log.Printf("failed to obtain logs of node %v, log is not captured: %v", label, err)
 		return
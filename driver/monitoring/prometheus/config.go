@@ -56,11 +56,12 @@ type promTargetConfig struct {
 	Label string
 }
 
-// renderConfigForNode renders the Prometheus configuration for a node.
-func renderConfigForNode(node driver.Node) (string, error) {
+// renderConfigForNode renders the Prometheus configuration for a node whose
+// metrics are scraped from the given host and port.
+func renderConfigForNode(node driver.Node, host string, port int) (string, error) {
 	cfg := promTargetConfig{
-		Host:  node.Hostname(),
-		Port:  node.MetricsPort(),
+		Host:  host,
+		Port:  port,
 		Label: node.GetLabel(),
 	}
 	tmpl, err := template.New("promTargetCfg").Parse(promTargetCfgTmpl)
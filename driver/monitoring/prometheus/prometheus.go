@@ -17,72 +17,88 @@
 package prometheusmon
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	neturl "net/url"
+	"strconv"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/docker"
 	"github.com/Fantom-foundation/Norma/driver/network"
+	opera "github.com/Fantom-foundation/Norma/driver/node"
 )
 
 // PrometheusPort is the default port for the Prometheus service.
 const PrometheusPort = 9090
 
+// prometheusService is the Prometheus API exported by its container.
+var prometheusService = network.ServiceDescription{
+	Name:     "Prometheus",
+	Port:     PrometheusPort,
+	Protocol: "http",
+}
+
 // prometheusImage is the default Docker image for the Prometheus service.
 const prometheusImage = "prom/prometheus:v2.44.0"
 
 // Prometheus is a Prometheus instance running in a Docker container.
 type Prometheus struct {
 	container *docker.Container
-	port      network.Port
 	net       driver.Network
+	ctx       context.Context    // context for reconfigurations triggered by network events
+	cancel    context.CancelFunc // cancels ctx on shutdown
 }
 
-// Start starts a Prometheus instance in a Docker container.
-func Start(net driver.Network, dn *docker.Network) (*Prometheus, error) {
+// Start starts a Prometheus instance in a Docker container. The container
+// runs on the Docker host of the given Docker network and joins it.
+func Start(ctx context.Context, net driver.Network, dn *docker.Network) (*Prometheus, error) {
 	timeout := 1 * time.Second
 
-	client, err := docker.NewClient()
-	if err != nil {
-		return nil, err
-	}
-
-	ports, err := network.GetFreePorts(1)
-	if err != nil {
-		return nil, err
+	var client *docker.Client
+	if dn != nil {
+		client = dn.GetClient()
+	} else {
+		var err error
+		client, err = docker.NewClient()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// start the container
-	container, err := client.Start(&docker.ContainerConfig{
+	container, err := client.Start(ctx, &docker.ContainerConfig{
 		ImageName:       prometheusImage,
 		ShutdownTimeout: &timeout,
-		PortForwarding: map[network.Port]network.Port{
-			PrometheusPort: ports[0],
-		},
-		Network: dn,
+		ExportedPorts:   []network.Port{PrometheusPort},
+		Network:         dn,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	listenerCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
 	prometheus := &Prometheus{
 		container: container,
 		net:       net,
-		port:      ports[0],
+		ctx:       listenerCtx,
+		cancel:    cancel,
 	}
 
 	// initialize the config
-	err = prometheus.initializeConfig()
+	err = prometheus.initializeConfig(ctx)
 	if err != nil {
-		_ = container.Cleanup()
+		cancel()
+		_ = container.Cleanup(context.WithoutCancel(ctx))
 		return nil, err
 	}
 
 	// wait until the prometheus inside the Container is ready.
 	// this is necessary for SIGHUP signal to be delivered correctly
-	if err := network.Retry(network.DefaultRetryAttempts, 1*time.Second, func() error {
+	if err := network.RetryWithBackoff(ctx, network.DefaultBackoff, func() error {
 		resp, err := http.Get(prometheus.GetUrl() + "/-/ready")
 		if err == nil && resp.StatusCode != http.StatusOK {
 			err = fmt.Errorf("not yet HTTP OK")
@@ -96,45 +112,75 @@ func Start(net driver.Network, dn *docker.Network) (*Prometheus, error) {
 
 		// get nodes that have been started before this instance creation
 		for _, node := range prometheus.net.GetActiveNodes() {
-			prometheus.AfterNodeCreation(node)
+			if err := prometheus.AddNode(ctx, node); err != nil {
+				log.Printf("failed to add node %s to Prometheus: %s", node.Hostname(), err)
+			}
 		}
 
 		return prometheus, nil
 	}
 
 	// if we reach this point, the prometheus instance is not ready
-	_ = container.Cleanup()
+	cancel()
+	_ = container.Cleanup(context.WithoutCancel(ctx))
 	return nil, fmt.Errorf("prometheus instance is not ready")
 }
 
 // AddNode adds a new target to the Prometheus configuration to be observed.
-func (p *Prometheus) AddNode(node driver.Node) error {
-	cfg, err := renderConfigForNode(node)
+func (p *Prometheus) AddNode(ctx context.Context, node driver.Node) error {
+	address, _, err := net.SplitHostPort(string(*p.container.GetAddressForService(&prometheusService)))
+	if err != nil {
+		return fmt.Errorf("invalid address of Prometheus; %v", err)
+	}
+	host, port := getScrapeTarget(node, address)
+	cfg, err := renderConfigForNode(node, host, port)
 	if err != nil {
 		return err
 	}
-	_, err = p.container.Exec(
+	_, err = p.container.Exec(ctx,
 		[]string{"sh", "-c", fmt.Sprintf("echo '%s' > /etc/prometheus/opera-%s.json", cfg, node.Hostname())})
 	if err != nil {
 		return err
 	}
 	// we also need to reload the config
-	return p.reloadConfig()
+	return p.reloadConfig(ctx)
+}
+
+// getScrapeTarget determines the host and port the metrics of the given node
+// are scraped from by a Prometheus instance whose forwarded ports are reached
+// through the given address. Nodes running on the same Docker host are reached
+// through the Docker network, nodes on other hosts through the metrics port
+// forwarded by their host.
+func getScrapeTarget(node driver.Node, address string) (string, int) {
+	hostname, port := node.Hostname(), node.MetricsPort()
+	url := node.GetServiceUrl(&opera.OperaDebugService)
+	if url == nil {
+		return hostname, port
+	}
+	forwarded, err := neturl.Parse(string(*url))
+	if err != nil || forwarded.Hostname() == address {
+		return hostname, port
+	}
+	if forwardedPort, err := strconv.Atoi(forwarded.Port()); err == nil {
+		return forwarded.Hostname(), forwardedPort
+	}
+	return hostname, port
 }
 
 // Shutdown shuts down the Prometheus instance.
-func (p *Prometheus) Shutdown() error {
+func (p *Prometheus) Shutdown(ctx context.Context) error {
 	p.net.UnregisterListener(p)
-	return p.container.Cleanup()
+	p.cancel()
+	return p.container.Cleanup(ctx)
 }
 
 // GetUrl returns the URL of the Prometheus instance.
 func (p *Prometheus) GetUrl() string {
-	return fmt.Sprintf("http://localhost:%d", p.port)
+	return fmt.Sprintf("http://%s", *p.container.GetAddressForService(&prometheusService))
 }
 
 func (p *Prometheus) AfterNodeCreation(node driver.Node) {
-	if err := p.AddNode(node); err != nil {
+	if err := p.AddNode(p.ctx, node); err != nil {
 		log.Printf("failed to add node %s to Prometheus: %s", node.Hostname(), err)
 	}
 }
@@ -149,13 +195,13 @@ func (p *Prometheus) AfterApplicationCreation(driver.Application) {
 
 // initializeConfig initializes the Prometheus configuration file by echoing config content
 // into container's config location.
-func (p *Prometheus) initializeConfig() error {
-	_, err := p.container.Exec(
+func (p *Prometheus) initializeConfig(ctx context.Context) error {
+	_, err := p.container.Exec(ctx,
 		[]string{"sh", "-c", fmt.Sprintf("echo '%s' > /etc/prometheus/prometheus.yml", promCfg)})
 	return err
 }
 
 // reloadConfig reloads the Prometheus configuration by sending "SIGHUP" signal.
-func (p *Prometheus) reloadConfig() error {
-	return p.container.SendSignal(docker.SigHup)
+func (p *Prometheus) reloadConfig(ctx context.Context) error {
+	return p.container.SendSignal(ctx, docker.SigHup)
 }
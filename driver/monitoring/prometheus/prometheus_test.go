@@ -18,6 +18,7 @@ package prometheusmon
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +28,8 @@ import (
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/network/local"
+	opera "github.com/Fantom-foundation/Norma/driver/node"
+	"go.uber.org/mock/gomock"
 )
 
 func TestPrometheusCanBeRun(t *testing.T) {
@@ -50,7 +53,7 @@ func TestPrometheusCanBeShutdown(t *testing.T) {
 	prom := startPrometheus(t, net)
 
 	// shutdown prometheus
-	err := prom.Shutdown()
+	err := prom.Shutdown(context.Background())
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -74,7 +77,7 @@ func TestNodeCanBeAdded(t *testing.T) {
 	node := nodes[0]
 
 	// add node
-	if err := prom.AddNode(node); err != nil {
+	if err := prom.AddNode(context.Background(), node); err != nil {
 		t.Fatalf("error: %v", err)
 	}
 	// wait for prometheus to reload config
@@ -109,12 +112,12 @@ func TestNodeCanBeAdded(t *testing.T) {
 
 // startPrometheus starts a prometheus node and returns it.
 func startPrometheus(t *testing.T, net *local.LocalNetwork) *Prometheus {
-	prom, err := Start(net, net.GetDockerNetwork())
+	prom, err := Start(context.Background(), net, net.GetDockerNetwork())
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = prom.Shutdown()
+		_ = prom.Shutdown(context.Background())
 	})
 	return prom
 }
@@ -122,7 +125,7 @@ func startPrometheus(t *testing.T, net *local.LocalNetwork) *Prometheus {
 // createLocalNetwork creates a docker network and returns it.
 func createLocalNetwork(t *testing.T) *local.LocalNetwork {
 	config := driver.NetworkConfig{NumberOfValidators: 1}
-	net, err := local.NewLocalNetwork(&config)
+	net, err := local.NewLocalNetwork(context.Background(), &config)
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
@@ -131,3 +134,36 @@ func createLocalNetwork(t *testing.T) *local.LocalNetwork {
 	})
 	return net
 }
+
+func TestGetScrapeTarget_NodesOnSameHostAreReachedThroughDockerNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	url := driver.URL("http://localhost:32768")
+	node.EXPECT().Hostname().Return("a1b2c3d4e5f6")
+	node.EXPECT().MetricsPort().Return(6060)
+	node.EXPECT().GetServiceUrl(gomock.Any()).Return(&url)
+
+	host, port := getScrapeTarget(node, "localhost")
+	if host != "a1b2c3d4e5f6" || port != 6060 {
+		t.Errorf("unexpected target, wanted a1b2c3d4e5f6:6060, got %s:%d", host, port)
+	}
+}
+
+func TestGetScrapeTarget_NodesOnOtherHostsAreReachedThroughForwardedPort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	url := driver.URL("http://10.0.0.2:32768")
+	node.EXPECT().Hostname().Return("a1b2c3d4e5f6")
+	node.EXPECT().MetricsPort().Return(6060)
+	node.EXPECT().GetServiceUrl(gomock.Any()).DoAndReturn(func(service *network.ServiceDescription) *driver.URL {
+		if service != &opera.OperaDebugService {
+			t.Errorf("unexpected service, wanted %s, got %s", opera.OperaDebugService.Name, service.Name)
+		}
+		return &url
+	})
+
+	host, port := getScrapeTarget(node, "localhost")
+	if host != "10.0.0.2" || port != 32768 {
+		t.Errorf("unexpected target, wanted 10.0.0.2:32768, got %s:%d", host, port)
+	}
+}
@@ -17,6 +17,7 @@
 package user
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Fantom-foundation/Norma/driver"
@@ -46,7 +47,7 @@ func TestSentTransactionSensorReportsProperValue(t *testing.T) {
 		if err != nil {
 			t.Fatalf("creation of sensor failed: %v", err)
 		}
-		if res, err := sensor.ReadValue(); err != nil || res != int(test.count) {
+		if res, err := sensor.ReadValue(context.Background()); err != nil || res != int(test.count) {
 			t.Errorf("sensor fetched wrong value, wanted %d, got %d, err %v", test.count, res, err)
 		}
 	}
@@ -17,6 +17,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Fantom-foundation/Norma/driver"
@@ -60,7 +61,7 @@ type sentTransactionsSensor struct {
 	user int
 }
 
-func (s *sentTransactionsSensor) ReadValue() (int, error) {
+func (s *sentTransactionsSensor) ReadValue(context.Context) (int, error) {
 	count, err := s.app.GetSentTransactions(s.user)
 	if err != nil {
 		return 0, err
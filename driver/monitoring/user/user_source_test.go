@@ -17,6 +17,7 @@
 package user
 
 import (
+	"context"
 	"math"
 	"sort"
 	"testing"
@@ -43,7 +44,7 @@ type testSensor struct {
 	next int
 }
 
-func (s *testSensor) ReadValue() (int, error) {
+func (s *testSensor) ReadValue(context.Context) (int, error) {
 	s.next++
 	return s.next, nil
 }
@@ -17,6 +17,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"math/rand"
 	"time"
@@ -27,7 +28,9 @@ import (
 // Sensor is an abstraction of some input device capable of probing a node
 // for some metric of type T.
 type Sensor[T any] interface {
-	ReadValue() (T, error)
+	// ReadValue probes the current value of the metric. The given context
+	// is cancelled when the sensor's subject is removed from the source.
+	ReadValue(ctx context.Context) (T, error)
 }
 
 // PeriodicDataSource is a generic data source periodically querying
@@ -40,17 +43,20 @@ type PeriodicDataSource[S comparable, T any] struct {
 
 // process represents a structure that encapsulates a stop signal and potential done.
 // It is used to communicate the stop signal and any done that occur during the execution of a subject.
-// The stop signal is sent through the 'stop' channel of type 'chan bool'.
+// The stop signal is sent through the 'stop' channel of type 'chan bool'
+// and by cancelling the context of ongoing sensor reads.
 // Any done that occur are stored in the 'done' field of type 'error'.
 // This structure is typically used in the context of controlling the execution of a subject.
 type process struct {
-	stop chan bool
-	done chan error
+	stop   chan bool
+	done   chan error
+	cancel context.CancelFunc
 }
 
 // Stop stops the instance by closing the stop channel and
 // returning the error received from the done channel.
 func (s process) Stop() error {
+	s.cancel()
 	close(s.stop)
 	return <-s.done
 }
@@ -108,7 +114,8 @@ func (s *PeriodicDataSource[S, T]) AddSubject(subject S, sensor Sensor[T]) error
 		return err
 	}
 
-	subjectStop := process{make(chan bool), make(chan error, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	subjectStop := process{make(chan bool), make(chan error, 1), cancel}
 	s.subjects[subject] = subjectStop
 
 	// Start background routine collecting sensor data.
@@ -123,7 +130,7 @@ func (s *PeriodicDataSource[S, T]) AddSubject(subject S, sensor Sensor[T]) error
 		for {
 			select {
 			case now := <-ticker.C:
-				value, err := sensor.ReadValue()
+				value, err := sensor.ReadValue(ctx)
 				if err != nil {
 					errs = append(errs, err)
 				} else {
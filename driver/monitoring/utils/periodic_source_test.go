@@ -17,6 +17,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"sync/atomic"
 	"testing"
@@ -188,7 +189,7 @@ type testSensor struct {
 	counts atomic.Int32
 }
 
-func (s *testSensor) ReadValue() (int, error) {
+func (s *testSensor) ReadValue(context.Context) (int, error) {
 	s.counts.Add(1)
 	return 123, nil
 }
@@ -201,7 +202,7 @@ type buggySensor struct {
 	testSensor
 }
 
-func (s *buggySensor) ReadValue() (int, error) {
+func (s *buggySensor) ReadValue(context.Context) (int, error) {
 	s.counts.Add(1)
 	return 123, fmt.Errorf("buggy senzor")
 }
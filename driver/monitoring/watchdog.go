@@ -0,0 +1,254 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+// WatchdogEventType classifies the incidents reported by a Watchdog.
+type WatchdogEventType string
+
+const (
+	// NodeCrashed is reported if the client of a node terminated without
+	// the node being stopped or removed from the network.
+	NodeCrashed WatchdogEventType = "crashed"
+	// NodeStalled is reported if a node did not produce a new block for
+	// longer than the configured stall timeout.
+	NodeStalled WatchdogEventType = "stalled"
+)
+
+// WatchdogEvent describes a single incident detected by a Watchdog. It
+// implements the error interface such that it can be used as the cause
+// of a failed scenario run.
+type WatchdogEvent struct {
+	Time    time.Time
+	Node    Node
+	Type    WatchdogEventType
+	Details string
+}
+
+func (e WatchdogEvent) String() string {
+	return fmt.Sprintf("%s: node %s %s (%s)", e.Time.Format(time.RFC3339), e.Node, e.Type, e.Details)
+}
+
+func (e WatchdogEvent) Error() string {
+	return e.String()
+}
+
+// WatchdogConfig is a collection of parameters of a Watchdog.
+type WatchdogConfig struct {
+	// StallTimeout is the maximum time a node may go without producing a
+	// new block before being reported as stalled. Zero disables the check.
+	StallTimeout time.Duration
+	// CheckPeriod is the interval in which the state of nodes is checked,
+	// one second if zero.
+	CheckPeriod time.Duration
+}
+
+// Watchdog follows the nodes of a network during a scenario run and reports
+// nodes which crashed or stopped producing blocks. Crashes are detected by
+// comparing the expected run state of the node with the actual state of its
+// client process, while block progress is obtained from a NodeLogProvider.
+// Detected incidents are logged, retained for later inspection, and
+// forwarded to an incident channel which may be used to abort a run early.
+type Watchdog struct {
+	network     driver.Network
+	logProvider NodeLogProvider
+	config      WatchdogConfig
+
+	nodes      map[Node]*watchedNode
+	events     []WatchdogEvent
+	nodesMutex sync.Mutex
+
+	incidents chan error
+	stop      context.CancelFunc
+	done      <-chan bool
+}
+
+// watchedNode is the state tracked by the Watchdog for an individual node.
+type watchedNode struct {
+	node         driver.Node
+	lastBlock    int
+	lastProgress time.Time
+	crashed      bool
+	stalled      bool
+}
+
+// NewWatchdog creates a new Watchdog following all current and future nodes
+// of the given network. The watchdog needs to be shut down eventually.
+func NewWatchdog(network driver.Network, logProvider NodeLogProvider, config WatchdogConfig) *Watchdog {
+	if config.CheckPeriod <= 0 {
+		config.CheckPeriod = time.Second
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan bool)
+	res := &Watchdog{
+		network:     network,
+		logProvider: logProvider,
+		config:      config,
+		nodes:       map[Node]*watchedNode{},
+		incidents:   make(chan error, 1),
+		stop:        stop,
+		done:        done,
+	}
+
+	network.RegisterListener(res)
+	for _, node := range network.GetActiveNodes() {
+		res.AfterNodeCreation(node)
+	}
+	logProvider.RegisterLogListener(res)
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(config.CheckPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				res.check(ctx, now)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res
+}
+
+// Incidents provides a channel on which the first detected incident is
+// reported. Subsequent incidents are dropped if the channel is not drained.
+func (w *Watchdog) Incidents() <-chan error {
+	return w.incidents
+}
+
+// GetEvents returns all incidents detected so far in the order of detection.
+func (w *Watchdog) GetEvents() []WatchdogEvent {
+	w.nodesMutex.Lock()
+	defer w.nodesMutex.Unlock()
+	res := make([]WatchdogEvent, len(w.events))
+	copy(res, w.events)
+	return res
+}
+
+// Shutdown stops the watchdog from tracking nodes.
+func (w *Watchdog) Shutdown() {
+	w.logProvider.UnregisterLogListener(w)
+	w.network.UnregisterListener(w)
+	w.stop()
+	<-w.done
+}
+
+func (w *Watchdog) AfterNodeCreation(node driver.Node) {
+	w.nodesMutex.Lock()
+	defer w.nodesMutex.Unlock()
+	label := Node(node.GetLabel())
+	if _, exists := w.nodes[label]; !exists {
+		w.nodes[label] = &watchedNode{
+			node:         node,
+			lastProgress: time.Now(),
+		}
+	}
+}
+
+func (w *Watchdog) AfterNodeRemoval(node driver.Node) {
+	w.nodesMutex.Lock()
+	defer w.nodesMutex.Unlock()
+	delete(w.nodes, Node(node.GetLabel()))
+}
+
+func (w *Watchdog) AfterApplicationCreation(driver.Application) {
+	// ignored
+}
+
+func (w *Watchdog) OnBlock(node Node, block Block) {
+	w.nodesMutex.Lock()
+	defer w.nodesMutex.Unlock()
+	state, exists := w.nodes[node]
+	if !exists || block.Height <= state.lastBlock {
+		return
+	}
+	state.lastBlock = block.Height
+	state.lastProgress = time.Now()
+	state.stalled = false
+}
+
+// check inspects the state of all tracked nodes and reports new incidents.
+func (w *Watchdog) check(ctx context.Context, now time.Time) {
+	w.nodesMutex.Lock()
+	nodes := make(map[Node]*watchedNode, len(w.nodes))
+	for label, state := range w.nodes {
+		nodes[label] = state
+	}
+	w.nodesMutex.Unlock()
+
+	for label, state := range nodes {
+		// Nodes which have been stopped on purpose are no longer checked.
+		if !state.node.IsRunning() {
+			continue
+		}
+		// The exit code is obtained without holding the lock since it may
+		// involve a request to the node's host.
+		code, err := state.node.GetExitCode(ctx)
+		if err != nil {
+			log.Printf("watchdog failed to obtain state of node %s: %v", label, err)
+			continue
+		}
+		// The node may have been stopped on purpose while obtaining the exit code.
+		if code != nil && !state.node.IsRunning() {
+			continue
+		}
+
+		w.nodesMutex.Lock()
+		if code != nil && !state.crashed {
+			state.crashed = true
+			w.report(WatchdogEvent{
+				Time:    now,
+				Node:    label,
+				Type:    NodeCrashed,
+				Details: fmt.Sprintf("exit code %d, last block %d", *code, state.lastBlock),
+			})
+		}
+		timeout := w.config.StallTimeout
+		if !state.crashed && !state.stalled && timeout > 0 && now.Sub(state.lastProgress) > timeout {
+			state.stalled = true
+			w.report(WatchdogEvent{
+				Time:    now,
+				Node:    label,
+				Type:    NodeStalled,
+				Details: fmt.Sprintf("no new block for %v, last block %d", now.Sub(state.lastProgress).Round(time.Second), state.lastBlock),
+			})
+		}
+		w.nodesMutex.Unlock()
+	}
+}
+
+// report records the given event. It must be called while holding the lock.
+func (w *Watchdog) report(event WatchdogEvent) {
+	log.Printf("Watchdog: %v", event)
+	w.events = append(w.events, event)
+	select {
+	case w.incidents <- event:
+	default:
+	}
+}
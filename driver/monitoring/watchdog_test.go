@@ -0,0 +1,204 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package monitoring
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWatchdog_ImplementsListeners(t *testing.T) {
+	var inst Watchdog
+	var _ driver.NetworkListener = &inst
+	var _ LogListener = &inst
+}
+
+func TestWatchdog_ReportsCrashedNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	logs := NewMockNodeLogProvider(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	exitCode := 137
+	node.EXPECT().GetLabel().AnyTimes().Return("A")
+	node.EXPECT().IsRunning().AnyTimes().Return(true)
+	node.EXPECT().GetExitCode(gomock.Any()).AnyTimes().Return(&exitCode, nil)
+
+	net.EXPECT().RegisterListener(gomock.Any())
+	net.EXPECT().UnregisterListener(gomock.Any())
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	logs.EXPECT().RegisterLogListener(gomock.Any())
+	logs.EXPECT().UnregisterLogListener(gomock.Any())
+
+	watchdog := NewWatchdog(net, logs, WatchdogConfig{CheckPeriod: 10 * time.Millisecond})
+	defer watchdog.Shutdown()
+
+	select {
+	case incident := <-watchdog.Incidents():
+		var event WatchdogEvent
+		if !errors.As(incident, &event) {
+			t.Fatalf("unexpected incident type: %v", incident)
+		}
+		if event.Node != "A" || event.Type != NodeCrashed {
+			t.Errorf("unexpected incident: %v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("crash of node was not reported")
+	}
+
+	// A crash must only be reported once.
+	time.Sleep(50 * time.Millisecond)
+	if got := len(watchdog.GetEvents()); got != 1 {
+		t.Errorf("unexpected number of events, wanted 1, got %d", got)
+	}
+}
+
+func TestWatchdog_IgnoresStoppedNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	logs := NewMockNodeLogProvider(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	node.EXPECT().GetLabel().AnyTimes().Return("A")
+	node.EXPECT().IsRunning().AnyTimes().Return(false)
+
+	net.EXPECT().RegisterListener(gomock.Any())
+	net.EXPECT().UnregisterListener(gomock.Any())
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	logs.EXPECT().RegisterLogListener(gomock.Any())
+	logs.EXPECT().UnregisterLogListener(gomock.Any())
+
+	watchdog := NewWatchdog(net, logs, WatchdogConfig{
+		StallTimeout: 10 * time.Millisecond,
+		CheckPeriod:  10 * time.Millisecond,
+	})
+	time.Sleep(100 * time.Millisecond)
+	watchdog.Shutdown()
+
+	if events := watchdog.GetEvents(); len(events) != 0 {
+		t.Errorf("stopped node should not be reported, got %v", events)
+	}
+}
+
+func TestWatchdog_IgnoresNodesStoppedWhileChecked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	logs := NewMockNodeLogProvider(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	// The node is stopped after it was found running, but before its exit
+	// code is obtained.
+	exitCode := 0
+	node.EXPECT().GetLabel().AnyTimes().Return("A")
+	gomock.InOrder(
+		node.EXPECT().IsRunning().Return(true),
+		node.EXPECT().GetExitCode(gomock.Any()).Return(&exitCode, nil),
+		node.EXPECT().IsRunning().AnyTimes().Return(false),
+	)
+
+	net.EXPECT().RegisterListener(gomock.Any())
+	net.EXPECT().UnregisterListener(gomock.Any())
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	logs.EXPECT().RegisterLogListener(gomock.Any())
+	logs.EXPECT().UnregisterLogListener(gomock.Any())
+
+	watchdog := NewWatchdog(net, logs, WatchdogConfig{CheckPeriod: 10 * time.Millisecond})
+	time.Sleep(100 * time.Millisecond)
+	watchdog.Shutdown()
+
+	if events := watchdog.GetEvents(); len(events) != 0 {
+		t.Errorf("node stopped on purpose should not be reported, got %v", events)
+	}
+}
+
+func TestWatchdog_ReportsStalledNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	logs := NewMockNodeLogProvider(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	node.EXPECT().GetLabel().AnyTimes().Return("A")
+	node.EXPECT().IsRunning().AnyTimes().Return(true)
+	node.EXPECT().GetExitCode(gomock.Any()).AnyTimes().Return(nil, nil)
+
+	net.EXPECT().RegisterListener(gomock.Any())
+	net.EXPECT().UnregisterListener(gomock.Any())
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	logs.EXPECT().RegisterLogListener(gomock.Any())
+	logs.EXPECT().UnregisterLogListener(gomock.Any())
+
+	watchdog := NewWatchdog(net, logs, WatchdogConfig{
+		StallTimeout: 200 * time.Millisecond,
+		CheckPeriod:  10 * time.Millisecond,
+	})
+	defer watchdog.Shutdown()
+
+	// While blocks are produced, the node is not considered stalled.
+	for i := 1; i <= 10; i++ {
+		watchdog.OnBlock("A", Block{Height: i})
+		time.Sleep(50 * time.Millisecond)
+	}
+	if events := watchdog.GetEvents(); len(events) != 0 {
+		t.Fatalf("progressing node should not be reported, got %v", events)
+	}
+
+	select {
+	case incident := <-watchdog.Incidents():
+		var event WatchdogEvent
+		if !errors.As(incident, &event) {
+			t.Fatalf("unexpected incident type: %v", incident)
+		}
+		if event.Node != "A" || event.Type != NodeStalled {
+			t.Errorf("unexpected incident: %v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("stall of node was not reported")
+	}
+}
+
+func TestWatchdog_RemovedNodesAreNoLongerTracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	logs := NewMockNodeLogProvider(ctrl)
+	node := driver.NewMockNode(ctrl)
+
+	node.EXPECT().GetLabel().AnyTimes().Return("A")
+
+	net.EXPECT().RegisterListener(gomock.Any())
+	net.EXPECT().UnregisterListener(gomock.Any())
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{})
+	logs.EXPECT().RegisterLogListener(gomock.Any())
+	logs.EXPECT().UnregisterLogListener(gomock.Any())
+
+	watchdog := NewWatchdog(net, logs, WatchdogConfig{
+		StallTimeout: 10 * time.Millisecond,
+		CheckPeriod:  10 * time.Millisecond,
+	})
+	defer watchdog.Shutdown()
+
+	watchdog.AfterNodeCreation(node)
+	watchdog.AfterNodeRemoval(node)
+	time.Sleep(50 * time.Millisecond)
+
+	if events := watchdog.GetEvents(); len(events) != 0 {
+		t.Errorf("removed node should not be reported, got %v", events)
+	}
+}
@@ -17,10 +17,13 @@
 package driver
 
 import (
+	"context"
 	"time"
 
+	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/parser"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/Fantom-foundation/Norma/load/app"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -29,19 +32,20 @@ import (
 // Network abstracts an execution environment for running scenarios.
 // Implementations may run nodes and applications locally, in docker images, or
 // remotely, on actual nodes. The interface is used by the scenario driver
-// to execute scenario descriptions.
+// to execute scenario descriptions. Operations accepting a context are
+// aborted when the context is cancelled or its deadline is exceeded.
 type Network interface {
 	// CreateNode creates a new node instance running a network client based on
 	// the given configuration. It is used by the scenario executor to add
 	// nodes to the network as needed.
-	CreateNode(config *NodeConfig) (Node, error)
+	CreateNode(ctx context.Context, config *NodeConfig) (Node, error)
 
 	// RemoveNode ends the client gracefully and removes node from the network
-	RemoveNode(Node) error
+	RemoveNode(ctx context.Context, node Node) error
 
 	// CreateApplication creates a new application in this network, ready to
 	// produce load as defined by its configuration.
-	CreateApplication(config *ApplicationConfig) (Application, error)
+	CreateApplication(ctx context.Context, config *ApplicationConfig) (Application, error)
 
 	// GetActiveNodes obtains a list of active nodes in the network.
 	GetActiveNodes() []Node
@@ -64,6 +68,33 @@ type Network interface {
 	SendTransaction(tx *types.Transaction)
 
 	DialRandomRpc() (rpc.RpcClient, error)
+
+	// UpdateNetworkRules submits an update of the network rules. The update
+	// takes effect with the epoch following the one including it.
+	UpdateNetworkRules(ctx context.Context, rules NetworkRules) error
+
+	// GetValidators lists all validators registered in the network, including
+	// validators created during the run, and whether they are part of the
+	// validator set of the current epoch.
+	GetValidators(ctx context.Context) ([]ValidatorInfo, error)
+}
+
+// NetworkRules is a partial set of network rules, listing only the modified
+// fields using the names of the client's rule definitions. For instance,
+// {"Blocks": {"MaxBlockGas": 1000000}} updates the block gas limit.
+type NetworkRules map[string]any
+
+// ValidatorInfo summarizes the state of a single validator in the network.
+type ValidatorInfo struct {
+	// ID is the ID assigned to the validator by the SFC contract.
+	ID int
+	// Nodes lists the labels of the running nodes operating the validator.
+	// It is empty if the validator is not run by any node of the network.
+	Nodes []string
+	// Active is true if the validator is part of the validator set of the
+	// current epoch. Newly registered validators become active with the
+	// next epoch.
+	Active bool
 }
 
 // NetworkConfig is a collection of network parameters to be used by factories
@@ -77,6 +108,38 @@ type NetworkConfig struct {
 	MaxEpochGas uint64
 	// RoundTripTime is the average round trip time between nodes in the network.
 	RoundTripTime time.Duration
+	// Topology defines which nodes are peered with each other.
+	Topology parser.Topology
+	// Routing selects the nodes user transactions are sent to.
+	Routing parser.NodeSelection
+	// Genesis defines the initial state of the network.
+	Genesis parser.Genesis
+	// InitialState is a prepared state replacing the genesis if defined.
+	InitialState parser.InitialState
+	// DatadirDirectory is the host directory in which the datadirs of the
+	// nodes are kept after the run, one sub-directory per node. If empty,
+	// datadirs are removed together with their nodes.
+	DatadirDirectory string
+	// Endpoints are the Docker hosts running the nodes of the network. If
+	// empty, all nodes run on the Docker host configured by the environment.
+	Endpoints []DockerEndpoint
+}
+
+// DockerEndpoint is a Docker host running a part of the nodes of a network.
+type DockerEndpoint struct {
+	// Label identifies the endpoint when placing nodes.
+	Label string
+	// Host is the URL of the Docker host, e.g. tcp://10.0.0.2:2375.
+	Host string
+	// Address is the address under which ports forwarded by the host are
+	// reachable by this process and other hosts. If empty, it is derived
+	// from the host URL.
+	Address string
+	// BindAddress is the IP of the host's interface forwarded ports are bound
+	// to. If empty, ports of remote hosts are bound to the Address, which
+	// has to be an IP then, and ports of local hosts to the loopback
+	// interface.
+	BindAddress string
 }
 
 // NetworkListener can be registered to networks to get callbacks whenever there
@@ -92,14 +155,31 @@ type NetworkListener interface {
 
 type NodeConfig struct {
 	Name      string
-	Validator bool
+	Type      NodeType // the role of the node, validators are registered in the SFC
 	Cheater   bool
+	Resources network.ResourceLimits // the host resources available to the node
+	Bootstrap BootstrapMethod        // the way the node obtains the chain, full sync if empty
+	Endpoint  string                 // label of the Docker endpoint to run the node on, round-robin if empty
+	Services  []string               // optional services enabled on the node in addition to the default ones
 	// TODO: add other parameters as needed
 	//  - features to include on the node
 	//  - state DB configuration
 	//  - EVM configuration
 }
 
+// BootstrapMethod defines how a node joining the network obtains the state
+// of the chain.
+type BootstrapMethod string
+
+const (
+	// FullSync makes the node replay the chain received from its peers,
+	// starting from the genesis of the network.
+	FullSync BootstrapMethod = "full"
+	// SnapshotSync makes the node import the current state of the chain,
+	// exported from a running node of the network, before it starts.
+	SnapshotSync BootstrapMethod = "snapshot"
+)
+
 type ApplicationConfig struct {
 	Name string
 
@@ -112,6 +192,18 @@ type ApplicationConfig struct {
 	// Users defines the number of users sending transactions to the app.
 	Users int
 
+	// TxType defines the kind of transactions sent by the users, legacy
+	// transactions if empty.
+	TxType string
+
+	// Mix defines the application types the transactions of a mix application
+	// are drawn from.
+	Mix []parser.MixComponent
+
+	// Configs defines the options of the application types used by the
+	// application, where types without options take their defaults.
+	Configs []app.Config
+
 	// TODO: add other parameters as needed
 	//  - application type
 }
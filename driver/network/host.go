@@ -16,13 +16,44 @@
 
 package network
 
-import "io"
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
 
 //go:generate mockgen -source host.go -destination host_mock.go -package network
 
 // AddressPort is a string addressing an IP and a port in the format <IP>:<port>.
 type AddressPort string
 
+// ResourceLimits restricts the resources of the physical machine a host is
+// allowed to use. Zero values indicate the absence of a limit.
+type ResourceLimits struct {
+	Cpus   float64 // the number of CPUs, fractions are allowed
+	Memory int64   // the amount of memory in bytes
+	Pids   int64   // the maximum number of processes
+}
+
+// String summarizes the set limits, e.g. "cpus=1.5 memory=1073741824".
+func (l ResourceLimits) String() string {
+	limits := []string{}
+	if l.Cpus > 0 {
+		limits = append(limits, fmt.Sprintf("cpus=%g", l.Cpus))
+	}
+	if l.Memory > 0 {
+		limits = append(limits, fmt.Sprintf("memory=%d", l.Memory))
+	}
+	if l.Pids > 0 {
+		limits = append(limits, fmt.Sprintf("pids=%d", l.Pids))
+	}
+	if len(limits) == 0 {
+		return "unlimited"
+	}
+	return strings.Join(limits, " ")
+}
+
 // Host is an execution environment for Nodes. A host could be an actual physical
 // machine with its dedicated hardware, a virtual machine with shared resources,
 // or a Docker container hosting services. Hosts may be implicitly created by a
@@ -41,10 +72,10 @@ type Host interface {
 	// Stop shuts down the services running on the host gracefully, using
 	// their regular shutdown procedure (not killed). After stopping the
 	// service, no more interactions are expected to succeed.
-	Stop() error
+	Stop(ctx context.Context) error
 
 	// SaveLogTo transfers the logs of the host to the given file directory.
-	SaveLogTo(directory string) error
+	SaveLogTo(ctx context.Context, directory string) error
 
 	// StreamLog provides a reader that is continuously providing the host log.
 	// The log is tailed via the reader and blocked until next log lines are ready.
@@ -53,10 +84,11 @@ type Host interface {
 	// The reader should reach its end (EOF) only when the host/container is stopped or interrupted.
 	// If this method is called many times, it should dispatch the log to all returned
 	// readers, i.e. all of them see the same output.
-	// It is up to the caller to close the stream.
-	StreamLog() (io.ReadCloser, error)
+	// It is up to the caller to close the stream, which also ends when the
+	// given context is done.
+	StreamLog(ctx context.Context) (io.ReadCloser, error)
 
 	// Cleanup releases all underlying resources. After the cleanup no more
 	// operations on this host are expected to succeed.
-	Cleanup() error
+	Cleanup(ctx context.Context) error
 }
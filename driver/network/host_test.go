@@ -0,0 +1,33 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import "testing"
+
+func TestResourceLimits_String(t *testing.T) {
+	tests := map[ResourceLimits]string{
+		{}:                                 "unlimited",
+		{Cpus: 1.5}:                        "cpus=1.5",
+		{Memory: 1 << 30, Pids: 512}:       "memory=1073741824 pids=512",
+		{Cpus: 2, Memory: 1024, Pids: 100}: "cpus=2 memory=1024 pids=100",
+	}
+	for limits, want := range tests {
+		if got := limits.String(); got != want {
+			t.Errorf("unexpected string for %#v, wanted %q, got %q", limits, want, got)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/docker"
+)
+
+// defaultEndpointLabel labels the Docker host configured by the environment,
+// which runs all nodes if no endpoints are configured.
+const defaultEndpointLabel = "local"
+
+// endpoint is a Docker host running a part of the nodes of the network. The
+// nodes of an endpoint are connected through a bridge network of their own,
+// while nodes on different endpoints connect through forwarded ports.
+type endpoint struct {
+	label   string
+	client  *docker.Client
+	network *docker.Network
+}
+
+// startEndpoints connects to the given Docker hosts and creates a bridge
+// network on each of them. If no hosts are given, the Docker host configured
+// by the environment is used.
+func startEndpoints(ctx context.Context, configs []driver.DockerEndpoint) ([]*endpoint, error) {
+	if len(configs) == 0 {
+		configs = []driver.DockerEndpoint{{Label: defaultEndpointLabel}}
+	}
+	res := make([]*endpoint, 0, len(configs))
+	labels := map[string]bool{}
+	for _, config := range configs {
+		if config.Label == "" || labels[config.Label] {
+			return nil, errors.Join(
+				fmt.Errorf("labels of endpoints must be unique and not empty, got %q", config.Label),
+				stopEndpoints(context.WithoutCancel(ctx), res),
+			)
+		}
+		labels[config.Label] = true
+
+		client, err := docker.NewClientForHost(config.Host, config.Address, config.BindAddress)
+		if err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("failed to create docker client for endpoint %s; %v", config.Label, err),
+				stopEndpoints(context.WithoutCancel(ctx), res),
+			)
+		}
+		network, err := client.CreateBridgeNetwork(ctx)
+		if err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("failed to create bridge network on endpoint %s; %v", config.Label, err),
+				client.Close(),
+				stopEndpoints(context.WithoutCancel(ctx), res),
+			)
+		}
+		res = append(res, &endpoint{
+			label:   config.Label,
+			client:  client,
+			network: network,
+		})
+	}
+	return res, nil
+}
+
+// stopEndpoints removes the bridge networks of the given endpoints and closes
+// their Docker clients.
+func stopEndpoints(ctx context.Context, endpoints []*endpoint) error {
+	errs := make([]error, 0, 2*len(endpoints))
+	for _, endpoint := range endpoints {
+		errs = append(errs, endpoint.network.Cleanup(ctx))
+		errs = append(errs, endpoint.client.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// toForwardedNodeId converts the ID of a node, which is its enode URL, such
+// that the node is reached at the address of the given p2p service URL, e.g.
+// the p2p port forwarded by the Docker host of the node.
+func toForwardedNodeId(id driver.NodeID, p2p driver.URL) (driver.NodeID, error) {
+	enode, err := url.Parse(string(id))
+	if err != nil {
+		return "", fmt.Errorf("invalid node id %s; %v", id, err)
+	}
+	if enode.Scheme != "enode" {
+		return "", fmt.Errorf("invalid node id %s; not an enode URL", id)
+	}
+	service, err := url.Parse(string(p2p))
+	if err != nil {
+		return "", fmt.Errorf("invalid p2p service URL %s; %v", p2p, err)
+	}
+	enode.Host = service.Host
+	// The discovery port is not forwarded, so the p2p port is used instead.
+	enode.RawQuery = ""
+	return driver.NodeID(enode.String()), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+func TestStartEndpoints_EmptyLabelsAreRejected(t *testing.T) {
+	_, err := startEndpoints(context.Background(), []driver.DockerEndpoint{{Host: "tcp://10.0.0.2:2375"}})
+	if err == nil || !strings.Contains(err.Error(), "labels of endpoints must be unique and not empty") {
+		t.Errorf("empty label should be rejected, got %v", err)
+	}
+}
+
+func TestLocalNetwork_NodesArePlacedOnEndpointsByLabelOrRoundRobin(t *testing.T) {
+	net := &LocalNetwork{
+		endpoints: []*endpoint{{label: "a"}, {label: "b"}, {label: "c"}},
+	}
+	for _, want := range []string{"a", "b", "c", "a"} {
+		got, err := net.getEndpoint("")
+		if err != nil {
+			t.Fatalf("failed to get endpoint: %v", err)
+		}
+		if got.label != want {
+			t.Errorf("unexpected round-robin endpoint, wanted %s, got %s", want, got.label)
+		}
+	}
+	got, err := net.getEndpoint("c")
+	if err != nil {
+		t.Fatalf("failed to get endpoint: %v", err)
+	}
+	if got.label != "c" {
+		t.Errorf("unexpected endpoint, wanted c, got %s", got.label)
+	}
+	if _, err := net.getEndpoint("d"); err == nil {
+		t.Errorf("unknown endpoint should be reported")
+	}
+}
+
+func TestToForwardedNodeId_AddressIsReplaced(t *testing.T) {
+	id := driver.NodeID("enode://8f3a@172.17.0.3:5050?discport=5051")
+	got, err := toForwardedNodeId(id, driver.URL("tcp://10.0.0.2:32770"))
+	if err != nil {
+		t.Fatalf("failed to convert node id: %v", err)
+	}
+	if want := driver.NodeID("enode://8f3a@10.0.0.2:32770"); got != want {
+		t.Errorf("unexpected node id, wanted %s, got %s", want, got)
+	}
+}
+
+func TestToForwardedNodeId_InvalidIdsAreReported(t *testing.T) {
+	for _, id := range []driver.NodeID{"", "http://10.0.0.2:5050", "enode://%zz"} {
+		if _, err := toForwardedNodeId(id, driver.URL("tcp://10.0.0.2:32770")); err == nil {
+			t.Errorf("invalid node id %q should be reported", id)
+		}
+	}
+}
@@ -18,15 +18,19 @@ package local
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/docker"
+	"github.com/Fantom-foundation/Norma/driver/genesis"
 	"github.com/Fantom-foundation/Norma/driver/network/rpc"
 	"github.com/Fantom-foundation/Norma/driver/node"
 	rpcdriver "github.com/Fantom-foundation/Norma/driver/rpc"
@@ -39,22 +43,38 @@ import (
 // LocalNetwork is a Docker based network running each individual node
 // within its own, dedicated Docker Container.
 type LocalNetwork struct {
-	docker         *docker.Client
-	network        *docker.Network
 	config         driver.NetworkConfig
 	primaryAccount *app.Account
 
-	// validators lists the validator nodes in the network. Validators
+	// endpoints lists the Docker hosts running the nodes of the network.
+	endpoints []*endpoint
+
+	// nextEndpoint is the index of the endpoint to place the next node on
+	// which is not assigned to an endpoint explicitly.
+	nextEndpoint atomic.Uint32
+
+	// placement maps the labels of nodes to the endpoints running them.
+	placement map[string]*endpoint
+
+	// stopPeerMaintenance stops the loop re-establishing missing peer
+	// connections, which signals peerMaintenanceDone once it is finished.
+	stopPeerMaintenance context.CancelFunc
+	peerMaintenanceDone <-chan bool
+
+	// validators lists the genesis validator nodes of the network. They
 	// are created during network startup and run for the full duration
-	// of the network.
-	// TODO dynamically created validators are not added to this list. Can't be added without old epoch is sealed, because method randomValidatorRPC could return a non validator node.
+	// of the network. Validators created during the run are only tracked
+	// by their nodes, see GetValidators.
 	validators []*node.OperaNode
 
 	// nodes provide a register for all nodes in the network, including
 	// validator nodes created during startup.
 	nodes map[driver.NodeID]*node.OperaNode
 
-	// nodesMutex synchronizes access to the list of nodes.
+	// topology tracks the intended peer connections among the nodes.
+	topology *peerTopology
+
+	// nodesMutex synchronizes access to the list of nodes and the topology.
 	nodesMutex sync.Mutex
 
 	// apps maintains a list of all applications created on the network.
@@ -74,19 +94,20 @@ type LocalNetwork struct {
 
 	rpcWorkerPool *rpc.RpcWorkerPool
 
+	// genesis is the genesis file shared by all nodes of the network.
+	genesis []byte
+
 	// a context for app management operations on the network
 	appContext app.AppContext
 }
 
-func NewLocalNetwork(config *driver.NetworkConfig) (*LocalNetwork, error) {
-	client, err := docker.NewClient()
+// NewLocalNetwork creates a network of local Docker containers running the
+// validators of the given configuration. If the context is cancelled during
+// the start, the already started parts of the network are shut down.
+func NewLocalNetwork(ctx context.Context, config *driver.NetworkConfig) (*LocalNetwork, error) {
+	genesisFile, err := genesis.Build(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create docker client; %v", err)
-	}
-
-	dn, err := client.CreateBridgeNetwork()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create bridge network; %v", err)
+		return nil, fmt.Errorf("failed to build genesis; %v", err)
 	}
 
 	// Create chain account, which will be used for the initialization
@@ -95,37 +116,49 @@ func NewLocalNetwork(config *driver.NetworkConfig) (*LocalNetwork, error) {
 		return nil, fmt.Errorf("failed to create primary account; %v", err)
 	}
 
+	endpoints, err := startEndpoints(ctx, config.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the empty network.
 	net := &LocalNetwork{
-		docker:         client,
-		network:        dn,
 		config:         *config,
 		primaryAccount: primaryAccount,
+		endpoints:      endpoints,
+		placement:      map[string]*endpoint{},
 		nodes:          map[driver.NodeID]*node.OperaNode{},
+		topology:       newPeerTopology(config.Topology),
 		apps:           []driver.Application{},
 		listeners:      map[driver.NetworkListener]bool{},
-		rpcWorkerPool:  rpc.NewRpcWorkerPool(),
+		genesis:        genesisFile,
+	}
+	net.rpcWorkerPool = rpc.NewRpcWorkerPool(net.getRoutingTargets)
+	if config.Routing.ActiveValidators {
+		net.rpcWorkerPool.RefreshPeriodically(routingRefreshPeriod)
 	}
 
 	// Let the RPC pool to start RPC workers when a node start.
 	net.RegisterListener(net.rpcWorkerPool)
 
-	// Start all validators.
+	// Start all validators, distributed among all endpoints.
 	net.validators = make([]*node.OperaNode, config.NumberOfValidators)
 	errs := make([]error, config.NumberOfValidators)
 	var wg sync.WaitGroup
 	for i := 0; i < config.NumberOfValidators; i++ {
 		wg.Add(1)
 		i := i
+		endpoint := net.nextRoundRobinEndpoint()
 		go func() {
 			defer wg.Done()
 			validatorId := i + 1
 			nodeConfig := node.OperaNodeConfig{
 				ValidatorId:   &validatorId,
+				Type:          driver.ValidatorNode,
 				NetworkConfig: config,
 				Label:         fmt.Sprintf("_validator-%d", validatorId),
 			}
-			net.validators[i], errs[i] = net.createNode(&nodeConfig)
+			net.validators[i], errs[i] = net.createNode(ctx, &nodeConfig, endpoint)
 		}()
 	}
 	wg.Wait()
@@ -136,7 +169,7 @@ func NewLocalNetwork(config *driver.NetworkConfig) (*LocalNetwork, error) {
 	}
 
 	// Setup infrastructure for managing applications on the network.
-	appContext, err := app.NewContext(net, primaryAccount)
+	appContext, err := app.NewContext(ctx, net, primaryAccount)
 	if err != nil {
 		return nil, errors.Join(
 			fmt.Errorf("failed to create app context; %w", err),
@@ -145,31 +178,33 @@ func NewLocalNetwork(config *driver.NetworkConfig) (*LocalNetwork, error) {
 	}
 	net.appContext = appContext
 
+	net.startPeerMaintenance(peerMaintenancePeriod)
+
 	return net, nil
 }
 
 // StartNode starts a node after it has been created.
-func (n *LocalNetwork) StartNode(nd driver.Node) (driver.Node, error) {
+func (n *LocalNetwork) StartNode(ctx context.Context, nd driver.Node) (driver.Node, error) {
 	opera, ok := nd.(*node.OperaNode)
 	if !ok {
 		return nil, fmt.Errorf("trying to start non-sonic node")
 	}
-	return n.startNode(opera)
+	return n.startNode(ctx, opera)
 }
 
-func (n *LocalNetwork) startNode(node *node.OperaNode) (*node.OperaNode, error) {
+func (n *LocalNetwork) startNode(ctx context.Context, node *node.OperaNode) (*node.OperaNode, error) {
 	n.nodesMutex.Lock()
-	id, err := node.GetNodeID()
+	id, err := node.GetNodeID(ctx)
 	if err != nil {
+		n.nodesMutex.Unlock()
 		return nil, fmt.Errorf("failed to get node id; %v", err)
 	}
-	for _, other := range n.nodes {
-		if err = other.AddPeer(id); err != nil {
-			n.nodesMutex.Unlock()
-			return nil, fmt.Errorf("failed to add peer; %v", err)
-		}
-	}
 	n.nodes[id] = node
+	connect, disconnect := n.topology.join(node.GetLabel())
+	if err := n.updatePeers(ctx, connect, disconnect); err != nil {
+		n.nodesMutex.Unlock()
+		return nil, err
+	}
 	n.nodesMutex.Unlock()
 
 	n.listenerMutex.Lock()
@@ -182,58 +217,121 @@ func (n *LocalNetwork) startNode(node *node.OperaNode) (*node.OperaNode, error)
 }
 
 // createNode is an internal version of CreateNode enabling the creation
-// of validator and non-validator nodes in the network.
-func (n *LocalNetwork) createNode(nodeConfig *node.OperaNodeConfig) (*node.OperaNode, error) {
-	node, err := node.StartOperaDockerNode(n.docker, n.network, nodeConfig)
+// of validator and non-validator nodes in the network on the given endpoint.
+func (n *LocalNetwork) createNode(ctx context.Context, nodeConfig *node.OperaNodeConfig, endpoint *endpoint) (*node.OperaNode, error) {
+	nodeConfig.Genesis = n.genesis
+	node, err := node.StartOperaDockerNode(ctx, endpoint.client, endpoint.network, nodeConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start opera docker; %v", err)
+		return nil, fmt.Errorf("failed to start opera docker on endpoint %s; %v", endpoint.label, err)
+	}
+	n.nodesMutex.Lock()
+	n.placement[node.GetLabel()] = endpoint
+	n.nodesMutex.Unlock()
+	return n.startNode(ctx, node)
+}
+
+// getEndpoint returns the endpoint with the given label, or the next endpoint
+// in a round-robin order if the label is empty.
+func (n *LocalNetwork) getEndpoint(label string) (*endpoint, error) {
+	if label == "" {
+		return n.nextRoundRobinEndpoint(), nil
 	}
-	return n.startNode(node)
+	for _, endpoint := range n.endpoints {
+		if endpoint.label == label {
+			return endpoint, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown endpoint %s", label)
+}
+
+// nextRoundRobinEndpoint returns the endpoint to place the next node on which
+// is not assigned to an endpoint explicitly.
+func (n *LocalNetwork) nextRoundRobinEndpoint() *endpoint {
+	next := n.nextEndpoint.Add(1) - 1
+	return n.endpoints[int(next)%len(n.endpoints)]
 }
 
 // CreateNode creates nodes in the network during run.
-func (n *LocalNetwork) CreateNode(config *driver.NodeConfig) (driver.Node, error) {
+func (n *LocalNetwork) CreateNode(ctx context.Context, config *driver.NodeConfig) (driver.Node, error) {
+	endpoint, err := n.getEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	newValId := 0
-	if config.Validator {
+	if config.Type == driver.ValidatorNode {
 		var err error
-		newValId, err = app.RegisterValidatorNode(n)
+		newValId, err = app.RegisterValidatorNode(ctx, n)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	bootstrapFrom := n.getBootstrapNode(config.Bootstrap)
 	if config.Cheater {
-		_, err := n.createNode(&node.OperaNodeConfig{
+		_, err := n.createNode(ctx, &node.OperaNodeConfig{
 			Label:         "cheater-" + config.Name,
+			Type:          config.Type,
+			Resources:     config.Resources,
 			NetworkConfig: &n.config,
 			ValidatorId:   &newValId,
-		})
+			BootstrapFrom: bootstrapFrom,
+			Services:      config.Services,
+		}, endpoint)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return n.createNode(&node.OperaNodeConfig{
+	return n.createNode(ctx, &node.OperaNodeConfig{
 		Label:         config.Name,
+		Type:          config.Type,
+		Resources:     config.Resources,
 		NetworkConfig: &n.config,
 		ValidatorId:   &newValId,
-	})
+		BootstrapFrom: bootstrapFrom,
+		Services:      config.Services,
+	}, endpoint)
+}
+
+// getBootstrapNode selects the node the state of a new node is exported from
+// for the given bootstrap method, or nil if the new node syncs from its peers.
+// Genesis validators are used since they run for the full duration of the
+// network and are at the head of the chain.
+func (n *LocalNetwork) getBootstrapNode(method driver.BootstrapMethod) *node.OperaNode {
+	if method != driver.SnapshotSync {
+		return nil
+	}
+	return n.validators[rand.Intn(len(n.validators))]
 }
 
-func (n *LocalNetwork) RemoveNode(node driver.Node) error {
+func (n *LocalNetwork) RemoveNode(ctx context.Context, node driver.Node) error {
 	n.nodesMutex.Lock()
-	id, err := node.GetNodeID()
-	if err != nil {
-		return fmt.Errorf("failed to get node id; %v", err)
+	// The node is looked up by the ID it was registered with, since the node
+	// may no longer be reachable to obtain it.
+	var id driver.NodeID
+	for cur, other := range n.nodes {
+		if driver.Node(other) == node {
+			id = cur
+		}
+	}
+	if id == "" {
+		n.nodesMutex.Unlock()
+		return fmt.Errorf("node %s is not part of the network", node.GetLabel())
 	}
 
 	delete(n.nodes, id)
+	delete(n.placement, node.GetLabel())
 	for _, other := range n.nodes {
-		if err = other.RemovePeer(id); err != nil {
+		if err := other.RemovePeer(ctx, id); err != nil {
 			n.nodesMutex.Unlock()
 			return fmt.Errorf("failed to remove peer; %v", err)
 		}
 	}
+	if err := n.updatePeers(ctx, n.topology.leave(node.GetLabel()), nil); err != nil {
+		n.nodesMutex.Unlock()
+		return err
+	}
 	n.nodesMutex.Unlock()
 
 	n.listenerMutex.Lock()
@@ -245,19 +343,164 @@ func (n *LocalNetwork) RemoveNode(node driver.Node) error {
 	return nil
 }
 
-func (n *LocalNetwork) KillNode(node driver.Node) error {
-	return node.Kill()
+// updatePeers establishes and drops the given peer connections among the
+// nodes of the network. It must be called while holding the nodes mutex.
+func (n *LocalNetwork) updatePeers(ctx context.Context, connect, disconnect []peering) error {
+	byLabel := make(map[string]*node.OperaNode, len(n.nodes))
+	for _, node := range n.nodes {
+		byLabel[node.GetLabel()] = node
+	}
+	for _, p := range disconnect {
+		from, to := byLabel[p.from], byLabel[p.to]
+		if from == nil || to == nil {
+			continue
+		}
+		id, err := to.GetNodeID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get node id; %v", err)
+		}
+		if err := from.RemovePeer(ctx, id); err != nil {
+			return fmt.Errorf("failed to remove peer; %v", err)
+		}
+	}
+	for _, p := range connect {
+		from, to := byLabel[p.from], byLabel[p.to]
+		if from == nil || to == nil {
+			continue
+		}
+		id, err := n.getPeerId(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		if err := from.AddPeer(ctx, id); err != nil {
+			return fmt.Errorf("failed to add peer; %v", err)
+		}
+	}
+	return nil
+}
+
+// getPeerId returns the ID under which the node from connects to the node to.
+// Nodes on the same endpoint are connected through its bridge network, nodes
+// on different endpoints through the p2p port forwarded by the endpoint of
+// the node to. It must be called while holding the nodes mutex.
+func (n *LocalNetwork) getPeerId(ctx context.Context, from, to *node.OperaNode) (driver.NodeID, error) {
+	id, err := to.GetNodeID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get node id; %v", err)
+	}
+	return n.toPeerId(from, to, id)
+}
+
+// toPeerId converts the given ID of the node to into the ID under which the
+// node from connects to it, see getPeerId.
+func (n *LocalNetwork) toPeerId(from, to *node.OperaNode, id driver.NodeID) (driver.NodeID, error) {
+	if n.placement[from.GetLabel()] == n.placement[to.GetLabel()] {
+		return id, nil
+	}
+	p2p := to.GetServiceUrl(&node.OperaP2pService)
+	if p2p == nil {
+		return "", fmt.Errorf("p2p port of node %s is not forwarded", to.GetLabel())
+	}
+	return toForwardedNodeId(id, *p2p)
+}
+
+func (n *LocalNetwork) KillNode(ctx context.Context, node driver.Node) error {
+	return node.Kill(ctx)
 }
 
 func (n *LocalNetwork) SendTransaction(tx *types.Transaction) {
 	n.rpcWorkerPool.SendTransaction(tx)
 }
 
+// DialRandomRpc dials a random node serving RPC requests. RPC nodes are
+// preferred, validators are used if there are no RPC nodes. Observers are
+// never used since they do not offer the required APIs.
 func (n *LocalNetwork) DialRandomRpc() (rpcdriver.RpcClient, error) {
-	nodes := n.GetActiveNodes()
+	nodes := n.getRpcServingNodes()
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no active node serving RPC requests")
+	}
 	return nodes[rand.Intn(len(nodes))].DialRpc()
 }
 
+// getRpcServingNodes returns the active RPC nodes, or the active validators
+// if there is no RPC node.
+func (n *LocalNetwork) getRpcServingNodes() []driver.Node {
+	var rpcNodes, validators []driver.Node
+	for _, node := range n.GetActiveNodes() {
+		switch node.GetType() {
+		case driver.RpcNode:
+			rpcNodes = append(rpcNodes, node)
+		case driver.ValidatorNode:
+			validators = append(validators, node)
+		}
+	}
+	if len(rpcNodes) > 0 {
+		return rpcNodes
+	}
+	return validators
+}
+
+// UpdateNetworkRules submits the given rules update through the NodeDriverAuth
+// contract using the network's primary account, which owns the contract.
+func (n *LocalNetwork) UpdateNetworkRules(ctx context.Context, rules driver.NetworkRules) error {
+	diff, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("failed to encode network rules; %v", err)
+	}
+	return app.UpdateNetworkRules(ctx, n.appContext, diff)
+}
+
+// GetValidators lists all validators registered in the SFC contract together
+// with the nodes running them and their activation status in the current epoch.
+func (n *LocalNetwork) GetValidators(ctx context.Context) ([]driver.ValidatorInfo, error) {
+	last, active, err := app.GetValidatorStatus(ctx, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator status; %v", err)
+	}
+	isActive := make(map[int]bool, len(active))
+	for _, id := range active {
+		isActive[id] = true
+	}
+	nodes := map[int][]string{}
+	for _, node := range n.GetActiveNodes() {
+		if id := node.GetValidatorId(); id != nil {
+			nodes[*id] = append(nodes[*id], node.GetLabel())
+		}
+	}
+
+	res := make([]driver.ValidatorInfo, 0, last)
+	for id := 1; id <= last; id++ {
+		labels := nodes[id]
+		sort.Strings(labels)
+		res = append(res, driver.ValidatorInfo{
+			ID:     id,
+			Nodes:  labels,
+			Active: isActive[id],
+		})
+	}
+	return res, nil
+}
+
+// routingRefreshPeriod is the time between two re-evaluations of the nodes
+// user transactions are sent to if they depend on the validator set.
+const routingRefreshPeriod = 10 * time.Second
+
+// getRoutingTargets selects the nodes user transactions are sent to according
+// to the routing of the network. If the routing is restricted to active
+// validators, the selection is repeated periodically to follow changes of
+// the validator set. Observers never receive transactions since they do not
+// offer the required APIs.
+func (n *LocalNetwork) getRoutingTargets(ctx context.Context, nodes []driver.Node) ([]driver.Node, error) {
+	candidates := make([]driver.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.GetType() != driver.ObserverNode {
+			candidates = append(candidates, node)
+		}
+	}
+	return driver.SelectNodes(ctx, n, candidates, n.config.Routing)
+}
+
 // dialRandomGenesisValidatorRpc dials a random genesis validator node.
 // When network starts and still doesn't have any traffic, then first transaction must come from validator node.
 // Caused by: the regular nodes even when connected won't send transactions from their txpool,
@@ -280,8 +523,10 @@ type localApplication struct {
 	done       *sync.WaitGroup
 }
 
-func (a *localApplication) Start() error {
-	ctx, cancel := context.WithCancel(context.Background())
+// Start runs the application in the background until it is stopped. The
+// application is also stopped if the given context is cancelled.
+func (a *localApplication) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
 	a.cancel = cancel
 
 	a.done.Add(1)
@@ -295,7 +540,7 @@ func (a *localApplication) Start() error {
 	return nil
 }
 
-func (a *localApplication) Stop() error {
+func (a *localApplication) Stop(context.Context) error {
 	if a.cancel != nil {
 		a.cancel()
 	}
@@ -318,19 +563,36 @@ func (a *localApplication) GetSentTransactions(user int) (uint64, error) {
 	return a.controller.GetTransactionsSentBy(user)
 }
 
-func (a *localApplication) GetReceivedTransactions() (uint64, error) {
-	return a.controller.GetReceivedTransactions()
+func (a *localApplication) GetReceivedTransactions(ctx context.Context) (uint64, error) {
+	return a.controller.GetReceivedTransactions(ctx)
 }
 
-func (n *LocalNetwork) CreateApplication(config *driver.ApplicationConfig) (driver.Application, error) {
+func (a *localApplication) GetReceivedTransactionsByComponent(ctx context.Context) (map[string]uint64, error) {
+	return a.controller.GetReceivedTransactionsByComponent(ctx)
+}
+
+func (n *LocalNetwork) CreateApplication(ctx context.Context, config *driver.ApplicationConfig) (driver.Application, error) {
 	rpcClient, err := n.dialRandomGenesisValidatorRpc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RPC to initialize the application; %v", err)
 	}
 	defer rpcClient.Close()
 
+	txType, err := app.ParseTxType(config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
 	appId := n.nextAppId.Add(1)
-	application, err := app.NewApplication(config.Type, n.appContext, 0, appId)
+	mix := make([]app.MixComponent, 0, len(config.Mix))
+	for _, component := range config.Mix {
+		mix = append(mix, app.MixComponent{Type: component.Type, Weight: component.Weight})
+	}
+	application, err := app.NewApplication(ctx, config.Type, n.appContext, 0, appId, app.AppConfig{
+		TxType:  txType,
+		Mix:     mix,
+		Configs: config.Configs,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize on-chain app; %v", err)
 	}
@@ -340,7 +602,7 @@ func (n *LocalNetwork) CreateApplication(config *driver.ApplicationConfig) (driv
 		return nil, fmt.Errorf("failed to parse shaper; %v", err)
 	}
 
-	appController, err := controller.NewAppController(application, sh, config.Users, n.appContext, n)
+	appController, err := controller.NewAppController(ctx, application, sh, config.Users, n.appContext, n)
 	if err != nil {
 		return nil, err
 	}
@@ -398,10 +660,16 @@ func (n *LocalNetwork) UnregisterListener(listener driver.NetworkListener) {
 func (n *LocalNetwork) Shutdown() error {
 	var errs []error
 
+	// Resources are released even if the operations creating them have
+	// been cancelled, so shutting down does not depend on any caller context.
+	ctx := context.Background()
+
+	n.shutdownPeerMaintenance()
+
 	// First stop all generators.
 	for _, app := range n.apps {
 		// TODO: shutdown apps in parallel.
-		if err := app.Stop(); err != nil {
+		if err := app.Stop(ctx); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -414,20 +682,18 @@ func (n *LocalNetwork) Shutdown() error {
 	// Second, shut down the nodes.
 	for _, node := range n.nodes {
 		// TODO: shutdown nodes in parallel.
-		if err := node.Stop(); err != nil {
+		if err := node.Stop(ctx); err != nil {
 			errs = append(errs, err)
 		}
-		if err := node.Cleanup(); err != nil {
+		if err := node.Cleanup(ctx); err != nil {
 			errs = append(errs, err)
 		}
 	}
 	n.nodes = map[driver.NodeID]*node.OperaNode{}
 
-	// Third, shut down the docker network.
-	if n.network != nil {
-		if err := n.network.Cleanup(); err != nil {
-			errs = append(errs, err)
-		}
+	// Third, shut down the docker networks of all endpoints.
+	if err := stopEndpoints(ctx, n.endpoints); err != nil {
+		errs = append(errs, err)
 	}
 
 	errs = append(errs, n.rpcWorkerPool.Close())
@@ -435,7 +701,8 @@ func (n *LocalNetwork) Shutdown() error {
 	return errors.Join(errs...)
 }
 
-// GetDockerNetwork returns the underlying docker network.
+// GetDockerNetwork returns the docker network of the first endpoint, which is
+// the one auxiliary services like monitoring tools are expected to join.
 func (n *LocalNetwork) GetDockerNetwork() *docker.Network {
-	return n.network
+	return n.endpoints[0].network
 }
@@ -18,6 +18,7 @@ package local
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -40,7 +41,7 @@ func TestLocalNetwork_CanStartNodesAndShutThemDown(t *testing.T) {
 		N := N
 		t.Run(fmt.Sprintf("num_nodes=%d", N), func(t *testing.T) {
 			t.Parallel()
-			net, err := NewLocalNetwork(&config)
+			net, err := NewLocalNetwork(context.Background(), &config)
 			if err != nil {
 				t.Fatalf("failed to create new local network: %v", err)
 			}
@@ -50,7 +51,7 @@ func TestLocalNetwork_CanStartNodesAndShutThemDown(t *testing.T) {
 
 			nodes := []driver.Node{}
 			for i := 0; i < N; i++ {
-				node, err := net.CreateNode(&driver.NodeConfig{
+				node, err := net.CreateNode(context.Background(), &driver.NodeConfig{
 					Name: fmt.Sprintf("T-%d", i),
 				})
 				if err != nil {
@@ -61,13 +62,13 @@ func TestLocalNetwork_CanStartNodesAndShutThemDown(t *testing.T) {
 			}
 
 			for _, node := range nodes {
-				if err := node.Stop(); err != nil {
+				if err := node.Stop(context.Background()); err != nil {
 					t.Errorf("failed to stop node: %v", err)
 				}
 			}
 
 			for _, node := range nodes {
-				if err := node.Cleanup(); err != nil {
+				if err := node.Cleanup(context.Background()); err != nil {
 					t.Errorf("failed to cleanup node: %v", err)
 				}
 			}
@@ -85,7 +86,7 @@ func TestLocalNetwork_CanEnforceNetworkLatency(t *testing.T) {
 				NumberOfValidators: 2,
 				RoundTripTime:      rtt,
 			}
-			net, err := NewLocalNetwork(&config)
+			net, err := NewLocalNetwork(context.Background(), &config)
 			if err != nil {
 				t.Fatalf("failed to create new local network: %v", err)
 			}
@@ -98,7 +99,7 @@ func TestLocalNetwork_CanEnforceNetworkLatency(t *testing.T) {
 			if got, want := len(nodes), 2; got != want {
 				t.Fatalf("invalid number of active nodes, got %d, want %d", got, want)
 			}
-			got, err := nodes[0].(*node.OperaNode).GetRoundTripTime(nodes[1].Hostname())
+			got, err := nodes[0].(*node.OperaNode).GetRoundTripTime(context.Background(), nodes[1].Hostname())
 			if err != nil {
 				t.Errorf("failed to measure network delay: %v", err)
 			}
@@ -120,7 +121,7 @@ func TestLocalNetwork_CanStartApplicationsAndShutThemDown(t *testing.T) {
 		t.Run(fmt.Sprintf("num_nodes=%d", N), func(t *testing.T) {
 			t.Parallel()
 
-			net, err := NewLocalNetwork(&config)
+			net, err := NewLocalNetwork(context.Background(), &config)
 			if err != nil {
 				t.Fatalf("failed to create new local network: %v", err)
 			}
@@ -130,7 +131,7 @@ func TestLocalNetwork_CanStartApplicationsAndShutThemDown(t *testing.T) {
 
 			apps := []driver.Application{}
 			for i := 0; i < N; i++ {
-				app, err := net.CreateApplication(&driver.ApplicationConfig{
+				app, err := net.CreateApplication(context.Background(), &driver.ApplicationConfig{
 					Name: fmt.Sprintf("T-%d", i),
 				})
 				if err != nil {
@@ -146,13 +147,13 @@ func TestLocalNetwork_CanStartApplicationsAndShutThemDown(t *testing.T) {
 			}
 
 			for _, app := range apps {
-				if err := app.Start(); err != nil {
+				if err := app.Start(context.Background()); err != nil {
 					t.Errorf("failed to start app: %v", err)
 				}
 			}
 
 			for _, app := range apps {
-				if err := app.Stop(); err != nil {
+				if err := app.Stop(context.Background()); err != nil {
 					t.Errorf("failed to stop app: %v", err)
 				}
 			}
@@ -165,7 +166,7 @@ func TestLocalNetwork_CanPerformNetworkShutdown(t *testing.T) {
 	N := 2
 	config := driver.NetworkConfig{NumberOfValidators: 1}
 
-	net, err := NewLocalNetwork(&config)
+	net, err := NewLocalNetwork(context.Background(), &config)
 	if err != nil {
 		t.Fatalf("failed to create new local network: %v", err)
 	}
@@ -174,7 +175,7 @@ func TestLocalNetwork_CanPerformNetworkShutdown(t *testing.T) {
 	})
 
 	for i := 0; i < N; i++ {
-		_, err := net.CreateNode(&driver.NodeConfig{
+		_, err := net.CreateNode(context.Background(), &driver.NodeConfig{
 			Name: fmt.Sprintf("T-%d", i),
 		})
 		if err != nil {
@@ -183,7 +184,7 @@ func TestLocalNetwork_CanPerformNetworkShutdown(t *testing.T) {
 	}
 
 	for i := 0; i < N; i++ {
-		_, err := net.CreateApplication(&driver.ApplicationConfig{
+		_, err := net.CreateApplication(context.Background(), &driver.ApplicationConfig{
 			Name: fmt.Sprintf("T-%d", i),
 		})
 		if err != nil {
@@ -201,7 +202,7 @@ func TestLocalNetwork_Shutdown_Graceful(t *testing.T) {
 	N := 3
 	config := driver.NetworkConfig{NumberOfValidators: 1}
 
-	net, err := NewLocalNetwork(&config)
+	net, err := NewLocalNetwork(context.Background(), &config)
 	if err != nil {
 		t.Fatalf("failed to create new local network: %v", err)
 	}
@@ -211,7 +212,7 @@ func TestLocalNetwork_Shutdown_Graceful(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	listener := driver.NewMockNetworkListener(ctrl)
 	listener.EXPECT().AfterNodeCreation(gomock.Any()).DoAndReturn(func(node driver.Node) {
-		reader, err := node.StreamLog()
+		reader, err := node.StreamLog(context.Background())
 		if err != nil {
 			t.Errorf("error: %v", err)
 		}
@@ -234,7 +235,7 @@ func TestLocalNetwork_Shutdown_Graceful(t *testing.T) {
 	net.RegisterListener(listener)
 
 	for i := 0; i < N; i++ {
-		_, err := net.CreateNode(&driver.NodeConfig{
+		_, err := net.CreateNode(context.Background(), &driver.NodeConfig{
 			Name: fmt.Sprintf("T-%d", i),
 		})
 		if err != nil {
@@ -264,7 +265,7 @@ func TestLocalNetwork_CanRunWithMultipleValidators(t *testing.T) {
 		config := driver.NetworkConfig{NumberOfValidators: N}
 		t.Run(fmt.Sprintf("num_validators=%d", N), func(t *testing.T) {
 			t.Parallel()
-			net, err := NewLocalNetwork(&config)
+			net, err := NewLocalNetwork(context.Background(), &config)
 			if err != nil {
 				t.Fatalf("failed to create new local network: %v", err)
 			}
@@ -272,18 +273,18 @@ func TestLocalNetwork_CanRunWithMultipleValidators(t *testing.T) {
 				_ = net.Shutdown()
 			})
 
-			app, err := net.CreateApplication(&driver.ApplicationConfig{
+			app, err := net.CreateApplication(context.Background(), &driver.ApplicationConfig{
 				Name: "TestApp",
 			})
 			if err != nil {
 				t.Fatalf("failed to create app: %v", err)
 			}
 
-			if err := app.Start(); err != nil {
+			if err := app.Start(context.Background()); err != nil {
 				t.Errorf("failed to start app: %v", err)
 			}
 
-			if err := app.Stop(); err != nil {
+			if err := app.Stop(context.Background()); err != nil {
 				t.Errorf("failed to stop app: %v", err)
 			}
 		})
@@ -296,7 +297,7 @@ func TestLocalNetwork_NotifiesListenersOnNodeStartup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	listener := driver.NewMockNetworkListener(ctrl)
 
-	net, err := NewLocalNetwork(&config)
+	net, err := NewLocalNetwork(context.Background(), &config)
 	if err != nil {
 		t.Fatalf("failed to create new local network: %v", err)
 	}
@@ -312,7 +313,7 @@ func TestLocalNetwork_NotifiesListenersOnNodeStartup(t *testing.T) {
 	net.RegisterListener(listener)
 	listener.EXPECT().AfterNodeCreation(gomock.Any())
 
-	net.CreateNode(&driver.NodeConfig{
+	net.CreateNode(context.Background(), &driver.NodeConfig{
 		Name: "Test",
 	})
 
@@ -329,7 +330,7 @@ func TestLocalNetwork_NotifiesListenersOnAppStartup(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	listener := driver.NewMockNetworkListener(ctrl)
 
-	net, err := NewLocalNetwork(&config)
+	net, err := NewLocalNetwork(context.Background(), &config)
 	if err != nil {
 		t.Fatalf("failed to create new local network: %v", err)
 	}
@@ -340,7 +341,7 @@ func TestLocalNetwork_NotifiesListenersOnAppStartup(t *testing.T) {
 	net.RegisterListener(listener)
 	listener.EXPECT().AfterApplicationCreation(gomock.Any())
 
-	_, err = net.CreateApplication(&driver.ApplicationConfig{
+	_, err = net.CreateApplication(context.Background(), &driver.ApplicationConfig{
 		Name: "TestApp",
 	})
 	if err != nil {
@@ -355,7 +356,7 @@ func TestLocalNetwork_CanRemoveNode(t *testing.T) {
 		N := N
 		t.Run(fmt.Sprintf("num_nodes=%d", N), func(t *testing.T) {
 			t.Parallel()
-			net, err := NewLocalNetwork(&config)
+			net, err := NewLocalNetwork(context.Background(), &config)
 			ctrl := gomock.NewController(t)
 			listener := driver.NewMockNetworkListener(ctrl)
 			listener.EXPECT().AfterNodeCreation(gomock.Any()).Times(N)
@@ -371,7 +372,7 @@ func TestLocalNetwork_CanRemoveNode(t *testing.T) {
 
 			nodes := make([]driver.Node, 0, N)
 			for i := 0; i < N; i++ {
-				node, err := net.CreateNode(&driver.NodeConfig{
+				node, err := net.CreateNode(context.Background(), &driver.NodeConfig{
 					Name: fmt.Sprintf("T-%d", i),
 				})
 				if err != nil {
@@ -382,11 +383,11 @@ func TestLocalNetwork_CanRemoveNode(t *testing.T) {
 
 			// remove nodes one by one
 			for _, node := range nodes {
-				if err := net.RemoveNode(node); err != nil {
+				if err := net.RemoveNode(context.Background(), node); err != nil {
 					t.Errorf("cannot remove node: %s", err)
 				}
 
-				id, err := node.GetNodeID()
+				id, err := node.GetNodeID(context.Background())
 				if err != nil {
 					t.Errorf("cannot get node ID: %s", err)
 				}
@@ -399,10 +400,10 @@ func TestLocalNetwork_CanRemoveNode(t *testing.T) {
 
 			// removed nodes are only detached from the network, but still running - i.e. they can be turned off
 			for _, node := range nodes {
-				if err := node.Stop(); err != nil {
+				if err := node.Stop(context.Background()); err != nil {
 					t.Errorf("failed to stop node: %v", err)
 				}
-				if err := node.Cleanup(); err != nil {
+				if err := node.Cleanup(context.Background()); err != nil {
 					t.Errorf("failed to cleanup node: %v", err)
 				}
 			}
@@ -417,7 +418,7 @@ func TestLocalNetwork_Num_Validators_Started(t *testing.T) {
 		t.Run(fmt.Sprintf("num_validators=%d", i), func(t *testing.T) {
 			t.Parallel()
 			config := driver.NetworkConfig{NumberOfValidators: i}
-			net, err := NewLocalNetwork(&config)
+			net, err := NewLocalNetwork(context.Background(), &config)
 			if err != nil {
 				t.Fatalf("failed to create new local network: %v", err)
 			}
@@ -0,0 +1,181 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/node"
+)
+
+// peerMaintenancePeriod is the time between two comparisons of the peers of
+// all nodes with the intended topology.
+const peerMaintenancePeriod = 10 * time.Second
+
+// startPeerMaintenance starts a background loop periodically re-establishing
+// missing connections of the intended topology, e.g. after nodes have been
+// restarted or their addresses have changed. The loop runs until the network
+// is shut down.
+func (n *LocalNetwork) startPeerMaintenance(period time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	n.stopPeerMaintenance = cancel
+	n.peerMaintenanceDone = done
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := n.maintainPeers(ctx); err != nil && ctx.Err() == nil {
+					log.Printf("failed to maintain peers; %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// shutdownPeerMaintenance stops the loop started by startPeerMaintenance and
+// waits for it to finish.
+func (n *LocalNetwork) shutdownPeerMaintenance() {
+	if n.stopPeerMaintenance == nil {
+		return
+	}
+	n.stopPeerMaintenance()
+	<-n.peerMaintenanceDone
+	n.stopPeerMaintenance = nil
+}
+
+// peerRpcTimeout bounds each RPC call made to maintain peers, such that an
+// unresponsive node does not block the maintenance of the others.
+const peerRpcTimeout = 5 * time.Second
+
+// maintainPeers compares the peers of all nodes with the intended topology
+// and re-adds missing connections. Nodes which are not reachable are skipped.
+// The nodes and the topology are read under the nodes mutex, but the nodes are
+// contacted without holding it, such that nodes can join and leave meanwhile.
+func (n *LocalNetwork) maintainPeers(ctx context.Context) error {
+	n.nodesMutex.Lock()
+	nodes := make(map[driver.NodeID]*node.OperaNode, len(n.nodes))
+	for id, node := range n.nodes {
+		nodes[id] = node
+	}
+	intended := n.topology.getPeerings()
+	n.nodesMutex.Unlock()
+
+	byLabel := make(map[string]*node.OperaNode, len(nodes))
+	keys := make(map[string]string, len(nodes))
+	connected := make(map[string][]driver.NodeID, len(nodes))
+	var errs []error
+	for id, node := range nodes {
+		if !node.IsRunning() {
+			continue
+		}
+		peers, err := withTimeout(ctx, node.GetPeers)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get peers of node %s; %v", node.GetLabel(), err))
+			continue
+		}
+		byLabel[node.GetLabel()] = node
+		keys[node.GetLabel()] = getEnodeKey(id)
+		connected[node.GetLabel()] = peers
+	}
+
+	missing := getMissingPeerings(intended, keys, connected)
+	for _, p := range missing {
+		from, to := byLabel[p.from], byLabel[p.to]
+		// The address of the node may have changed since it was started.
+		id, err := withTimeout(ctx, to.GetCurrentNodeID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get node id of %s; %v", to.GetLabel(), err))
+			continue
+		}
+		n.nodesMutex.Lock()
+		id, err = n.toPeerId(from, to, id)
+		n.nodesMutex.Unlock()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		addCtx, cancel := context.WithTimeout(ctx, peerRpcTimeout)
+		err = from.AddPeer(addCtx, id)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to re-add peer %s to %s; %v", to.GetLabel(), from.GetLabel(), err))
+		}
+	}
+	if len(missing) > 0 {
+		log.Printf("re-added %d missing peer connection(s)", len(missing))
+	}
+	return errors.Join(errs...)
+}
+
+// withTimeout runs the given query with a context derived from ctx which is
+// cancelled after peerRpcTimeout.
+func withTimeout[T any](ctx context.Context, query func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, peerRpcTimeout)
+	defer cancel()
+	return query(ctx)
+}
+
+// getMissingPeerings returns the intended connections which are established
+// by neither of the connected nodes. Nodes are identified by their labels,
+// keys maps them to the keys of their enodes, and connected lists the enodes
+// of the current peers of each node. Connections of nodes without a key are
+// ignored, since their peers are unknown.
+func getMissingPeerings(intended []peering, keys map[string]string, connected map[string][]driver.NodeID) []peering {
+	isConnected := func(from, to string) bool {
+		for _, peer := range connected[from] {
+			if getEnodeKey(peer) == keys[to] {
+				return true
+			}
+		}
+		return false
+	}
+	res := []peering{}
+	for _, p := range intended {
+		if _, found := keys[p.from]; !found {
+			continue
+		}
+		if _, found := keys[p.to]; !found {
+			continue
+		}
+		if !isConnected(p.from, p.to) && !isConnected(p.to, p.from) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// getEnodeKey extracts the public key identifying a node from its enode URL,
+// which remains the same if the address of the node changes.
+func getEnodeKey(id driver.NodeID) string {
+	enode, err := url.Parse(string(id))
+	if err != nil || enode.User == nil {
+		return string(id)
+	}
+	return enode.User.Username()
+}
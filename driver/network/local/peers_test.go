@@ -0,0 +1,72 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/node"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+func TestGetMissingPeerings_ConnectionsOfEitherNodeAreConsidered(t *testing.T) {
+	intended := []peering{{"A", "B"}, {"A", "C"}, {"B", "C"}}
+	keys := map[string]string{"A": "a", "B": "b", "C": "c"}
+	connected := map[string][]driver.NodeID{
+		"A": {"enode://b@172.17.0.3:5050"},
+		"B": {"enode://a@172.17.0.2:5050"},
+		"C": {"enode://b@10.0.0.2:32770"}, // reached through a forwarded port
+	}
+	got := getMissingPeerings(intended, keys, connected)
+	want := []peering{{"A", "C"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected missing peerings, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGetMissingPeerings_UnknownNodesAreIgnored(t *testing.T) {
+	intended := []peering{{"A", "B"}, {"A", "C"}}
+	keys := map[string]string{"A": "a", "B": "b"}
+	got := getMissingPeerings(intended, keys, map[string][]driver.NodeID{})
+	want := []peering{{"A", "B"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("unexpected missing peerings, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGetEnodeKey_KeyIsIndependentOfAddress(t *testing.T) {
+	a := getEnodeKey("enode://8f3a@172.17.0.3:5050?discport=5051")
+	b := getEnodeKey("enode://8f3a@10.0.0.2:32770")
+	if a != "8f3a" || b != "8f3a" {
+		t.Errorf("unexpected keys, wanted 8f3a, got %s and %s", a, b)
+	}
+}
+
+func TestLocalNetwork_PeerMaintenanceCanBeStartedAndStopped(t *testing.T) {
+	net := &LocalNetwork{
+		nodes:    map[driver.NodeID]*node.OperaNode{},
+		topology: newPeerTopology(parser.Topology{}),
+	}
+	net.startPeerMaintenance(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	net.shutdownPeerMaintenance()
+	// Stopping the maintenance twice is a no-op.
+	net.shutdownPeerMaintenance()
+}
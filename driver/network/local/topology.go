@@ -0,0 +1,263 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+// peering is an undirected connection between two nodes, identified by
+// their labels.
+type peering struct {
+	from, to string
+}
+
+// peerTopology keeps track of the intended peer connections in a network.
+// Nodes are added and removed incrementally as they join and leave the
+// network, and the topology reports the connections to be established or
+// dropped to maintain the configured shape.
+type peerTopology struct {
+	config parser.Topology
+	// nodes lists the labels of all nodes in the order they joined.
+	nodes []string
+	// peers is the adjacency set of the current topology.
+	peers map[string]map[string]bool
+}
+
+func newPeerTopology(config parser.Topology) *peerTopology {
+	if config.Type == "" {
+		config.Type = parser.FullMeshTopology
+	}
+	return &peerTopology{
+		config: config,
+		peers:  map[string]map[string]bool{},
+	}
+}
+
+// isFixed returns true if the topology is not a full mesh, in which case
+// clients should not discover additional peers on their own.
+func (t *peerTopology) isFixed() bool {
+	return t.config.Type != parser.FullMeshTopology
+}
+
+// getPeers returns the labels of nodes the given node should be connected to.
+func (t *peerTopology) getPeers(label string) []string {
+	res := make([]string, 0, len(t.peers[label]))
+	for peer := range t.peers[label] {
+		res = append(res, peer)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// getPeerings returns all intended connections of the topology, each of
+// them listed once.
+func (t *peerTopology) getPeerings() []peering {
+	res := []peering{}
+	for _, from := range t.nodes {
+		for _, to := range t.getPeers(from) {
+			if from < to {
+				res = append(res, peering{from, to})
+			}
+		}
+	}
+	return res
+}
+
+// join adds a node to the topology and returns the connections to be
+// established and dropped to integrate it.
+func (t *peerTopology) join(label string) (connect, disconnect []peering) {
+	if slices.Contains(t.nodes, label) {
+		return nil, nil
+	}
+	existing := t.nodes
+	t.nodes = append(t.nodes, label)
+	t.peers[label] = map[string]bool{}
+
+	switch t.config.Type {
+	case parser.RingTopology:
+		// The new node is inserted between the last and the first node.
+		if len(existing) > 0 {
+			first, last := existing[0], existing[len(existing)-1]
+			if len(existing) > 2 {
+				disconnect = append(disconnect, peering{last, first})
+			}
+			connect = append(connect, peering{last, label})
+			if first != last {
+				connect = append(connect, peering{first, label})
+			}
+		}
+	case parser.RandomTopology:
+		connect, disconnect = t.joinRandom(label, existing)
+	case parser.StarTopology:
+		// Hubs are connected to all nodes, all other nodes only to hubs. As
+		// long as there are no hubs, nodes are connected to each other to
+		// keep the network operational.
+		isHub := t.isHub(label)
+		hubs := 0
+		for _, other := range existing {
+			if t.isHub(other) {
+				hubs++
+			}
+		}
+		for _, other := range existing {
+			if isHub || t.isHub(other) || hubs == 0 {
+				connect = append(connect, peering{other, label})
+			}
+		}
+		// With the first hub, the connections among the nodes joined before
+		// are no longer needed.
+		if isHub && hubs == 0 {
+			disconnect = t.getPeerings()
+		}
+	case parser.ExplicitTopology:
+		for _, other := range existing {
+			if t.isExplicitPeer(label, other) {
+				connect = append(connect, peering{other, label})
+			}
+		}
+	default:
+		for _, other := range existing {
+			connect = append(connect, peering{other, label})
+		}
+	}
+
+	for _, p := range disconnect {
+		delete(t.peers[p.from], p.to)
+		delete(t.peers[p.to], p.from)
+	}
+	for _, p := range connect {
+		t.peers[p.from][p.to] = true
+		t.peers[p.to][p.from] = true
+	}
+	return connect, disconnect
+}
+
+// joinRandom connects the given new node to random existing nodes without
+// exceeding the configured degree for any node. The new node is connected to
+// the least connected nodes first. Once all of them have reached the degree,
+// connections among existing nodes are replaced by connections to the new
+// node, which retains the degree of the existing nodes. Connections are
+// applied to the topology right away.
+func (t *peerTopology) joinRandom(label string, existing []string) (connect, disconnect []peering) {
+	degree := t.config.GetDegree()
+	candidates := slices.Clone(existing)
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(t.peers[candidates[i]]) < len(t.peers[candidates[j]])
+	})
+	for _, candidate := range candidates {
+		if len(t.peers[label]) >= degree {
+			return connect, disconnect
+		}
+		if len(t.peers[candidate]) < degree {
+			connect = append(connect, peering{candidate, label})
+			t.peers[candidate][label] = true
+			t.peers[label][candidate] = true
+		}
+	}
+	peerings := t.getPeerings()
+	rand.Shuffle(len(peerings), func(i, j int) {
+		peerings[i], peerings[j] = peerings[j], peerings[i]
+	})
+	for _, p := range peerings {
+		if len(t.peers[label])+2 > degree {
+			break
+		}
+		if p.from == label || p.to == label || t.peers[label][p.from] || t.peers[label][p.to] {
+			continue
+		}
+		disconnect = append(disconnect, p)
+		delete(t.peers[p.from], p.to)
+		delete(t.peers[p.to], p.from)
+		for _, peer := range []string{p.from, p.to} {
+			connect = append(connect, peering{peer, label})
+			t.peers[peer][label] = true
+			t.peers[label][peer] = true
+		}
+	}
+	return connect, disconnect
+}
+
+// leave removes a node from the topology and returns the connections to be
+// established among the remaining nodes to retain the configured shape.
+func (t *peerTopology) leave(label string) (connect []peering) {
+	pos := slices.Index(t.nodes, label)
+	if pos < 0 {
+		return nil
+	}
+	t.nodes = slices.Delete(t.nodes, pos, pos+1)
+	for peer := range t.peers[label] {
+		delete(t.peers[peer], label)
+	}
+	delete(t.peers, label)
+
+	// Only a ring needs repairing by connecting the former neighbours.
+	if t.config.Type == parser.RingTopology && len(t.nodes) > 1 {
+		prev := t.nodes[(pos+len(t.nodes)-1)%len(t.nodes)]
+		next := t.nodes[pos%len(t.nodes)]
+		if prev != next && !t.peers[prev][next] {
+			connect = append(connect, peering{prev, next})
+			t.peers[prev][next] = true
+			t.peers[next][prev] = true
+		}
+	}
+	return connect
+}
+
+func (t *peerTopology) isHub(label string) bool {
+	for _, hub := range t.config.Hubs {
+		if isReferencedBy(label, hub) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *peerTopology) isExplicitPeer(a, b string) bool {
+	for node, peers := range t.config.Peers {
+		for _, peer := range peers {
+			if isReferencedBy(a, node) && isReferencedBy(b, peer) ||
+				isReferencedBy(b, node) && isReferencedBy(a, peer) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isReferencedBy checks whether the node with the given label is referenced
+// by the given name, either directly or through the name of its node group.
+func isReferencedBy(label, ref string) bool {
+	if label == ref {
+		return true
+	}
+	instance, found := strings.CutPrefix(label, ref+"-")
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(instance)
+	return err == nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package local
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+func TestPeerTopology_FullMeshConnectsAllNodes(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{})
+	if topology.isFixed() {
+		t.Errorf("full mesh should not be a fixed topology")
+	}
+	joinAll(topology, "A", "B", "C", "D")
+	for _, node := range []string{"A", "B", "C", "D"} {
+		if got := len(topology.getPeers(node)); got != 3 {
+			t.Errorf("node %s should have 3 peers, got %d", node, got)
+		}
+	}
+}
+
+func TestPeerTopology_RingIsMaintainedWhenNodesJoinAndLeave(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{Type: parser.RingTopology})
+	if !topology.isFixed() {
+		t.Errorf("ring should be a fixed topology")
+	}
+
+	joinAll(topology, "A", "B", "C", "D")
+	want := map[string][]string{
+		"A": {"B", "D"},
+		"B": {"A", "C"},
+		"C": {"B", "D"},
+		"D": {"A", "C"},
+	}
+	checkPeers(t, topology, want)
+
+	connect := topology.leave("B")
+	if want := []peering{{"A", "C"}}; !slices.Equal(connect, want) {
+		t.Errorf("unexpected repair of ring, wanted %v, got %v", want, connect)
+	}
+	want = map[string][]string{
+		"A": {"C", "D"},
+		"C": {"A", "D"},
+		"D": {"A", "C"},
+	}
+	checkPeers(t, topology, want)
+}
+
+func TestPeerTopology_RingInsertsNodesBetweenLastAndFirst(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{Type: parser.RingTopology})
+	joinAll(topology, "A", "B", "C")
+	connect, disconnect := topology.join("D")
+	if want := []peering{{"C", "A"}}; !slices.Equal(disconnect, want) {
+		t.Errorf("unexpected dropped connections, wanted %v, got %v", want, disconnect)
+	}
+	if want := []peering{{"C", "D"}, {"A", "D"}}; !slices.Equal(connect, want) {
+		t.Errorf("unexpected new connections, wanted %v, got %v", want, connect)
+	}
+}
+
+func TestPeerTopology_RandomTopologyKeepsDegree(t *testing.T) {
+	degree := 3
+	topology := newPeerTopology(parser.Topology{Type: parser.RandomTopology, Degree: &degree})
+	nodes := []string{}
+	for i := 0; i < 20; i++ {
+		nodes = append(nodes, fmt.Sprintf("N-%d", i))
+	}
+	joinAll(topology, nodes...)
+	// Connections are replaced in pairs, so a single node may miss one.
+	missing := 0
+	for _, node := range nodes {
+		got := len(topology.getPeers(node))
+		if got < degree-1 || got > degree {
+			t.Errorf("node %s has an unexpected number of peers: %d", node, got)
+		}
+		missing += degree - got
+	}
+	if missing > 1 {
+		t.Errorf("too many connections are missing: %d", missing)
+	}
+}
+
+func TestPeerTopology_RandomTopologyDoesNotExceedDegreeWhenNodesLeave(t *testing.T) {
+	degree := 4
+	topology := newPeerTopology(parser.Topology{Type: parser.RandomTopology, Degree: &degree})
+	for i := 0; i < 30; i++ {
+		joinAll(topology, fmt.Sprintf("N-%d", i))
+		if i%3 == 0 {
+			topology.leave(fmt.Sprintf("N-%d", i/2))
+		}
+		for _, node := range topology.nodes {
+			if got := len(topology.getPeers(node)); got > degree {
+				t.Fatalf("node %s has more than %d peers: %d", node, degree, got)
+			}
+		}
+	}
+}
+
+func TestPeerTopology_StarConnectsNodesOnlyToHubs(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{Type: parser.StarTopology, Hubs: []string{"rpc"}})
+	joinAll(topology, "A-0", "rpc-0", "A-1", "rpc-1", "A-2")
+	want := map[string][]string{
+		"A-0":   {"rpc-0", "rpc-1"},
+		"A-1":   {"rpc-0", "rpc-1"},
+		"A-2":   {"rpc-0", "rpc-1"},
+		"rpc-0": {"A-0", "A-1", "A-2", "rpc-1"},
+		"rpc-1": {"A-0", "A-1", "A-2", "rpc-0"},
+	}
+	checkPeers(t, topology, want)
+}
+
+func TestPeerTopology_StarDropsConnectionsAmongNodesJoinedBeforeHub(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{Type: parser.StarTopology, Hubs: []string{"rpc"}})
+	joinAll(topology, "A-0", "A-1", "A-2")
+	_, disconnect := topology.join("rpc-0")
+	if got := len(disconnect); got != 3 {
+		t.Errorf("unexpected number of dropped connections, wanted 3, got %d", got)
+	}
+	want := map[string][]string{
+		"A-0":   {"rpc-0"},
+		"A-1":   {"rpc-0"},
+		"A-2":   {"rpc-0"},
+		"rpc-0": {"A-0", "A-1", "A-2"},
+	}
+	checkPeers(t, topology, want)
+}
+
+func TestPeerTopology_ExplicitTopologyFollowsAdjacencyLists(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{
+		Type: parser.ExplicitTopology,
+		Peers: map[string][]string{
+			"_validator-1": {"A"},
+			"A-0":          {"B-1"},
+		},
+	})
+	joinAll(topology, "_validator-1", "A-0", "A-1", "B-0", "B-1")
+	want := map[string][]string{
+		"_validator-1": {"A-0", "A-1"},
+		"A-0":          {"B-1", "_validator-1"},
+		"A-1":          {"_validator-1"},
+		"B-0":          {},
+		"B-1":          {"A-0"},
+	}
+	checkPeers(t, topology, want)
+}
+
+func TestIsReferencedBy(t *testing.T) {
+	tests := []struct {
+		label, ref string
+		want       bool
+	}{
+		{"A-0", "A-0", true},
+		{"A-0", "A", true},
+		{"A-12", "A", true},
+		{"AB-0", "A", false},
+		{"A-x-0", "A", false},
+		{"_validator-1", "_validator-1", true},
+		{"_validator-1", "_validator-2", false},
+	}
+	for _, test := range tests {
+		if got := isReferencedBy(test.label, test.ref); got != test.want {
+			t.Errorf("isReferencedBy(%s, %s) = %v, wanted %v", test.label, test.ref, got, test.want)
+		}
+	}
+}
+
+func TestPeerTopology_PeeringsAreListedOnce(t *testing.T) {
+	topology := newPeerTopology(parser.Topology{Type: parser.RingTopology})
+	joinAll(topology, "A", "B", "C", "D")
+	want := []peering{{"A", "B"}, {"A", "D"}, {"B", "C"}, {"C", "D"}}
+	if got := topology.getPeerings(); !slices.Equal(got, want) {
+		t.Errorf("unexpected peerings, wanted %v, got %v", want, got)
+	}
+}
+
+func joinAll(topology *peerTopology, labels ...string) {
+	for _, label := range labels {
+		topology.join(label)
+	}
+}
+
+func checkPeers(t *testing.T, topology *peerTopology, want map[string][]string) {
+	t.Helper()
+	for node, peers := range want {
+		if got := topology.getPeers(node); !slices.Equal(got, peers) {
+			t.Errorf("unexpected peers of node %s, wanted %v, got %v", node, peers, got)
+		}
+	}
+}
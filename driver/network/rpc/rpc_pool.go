@@ -31,17 +31,27 @@ import (
 
 type RpcWorkerPool struct {
 	txs     chan *types.Transaction
+	nodes   map[driver.Node]bool
 	workers map[driver.Node]*workerGroup
+	mutex   sync.Mutex
+	accept  func(context.Context, []driver.Node) ([]driver.Node, error)
 	ctx     context.Context
 	cancel  context.CancelFunc
+	done    sync.WaitGroup
 }
 
-func NewRpcWorkerPool() *RpcWorkerPool {
+// NewRpcWorkerPool creates a pool sending transactions to the nodes of a
+// network. If accept is not nil, only the nodes selected by it are sent
+// transactions to. The selection is made when nodes join the network and
+// repeated on every Refresh. The selection is aborted if the pool is closed.
+func NewRpcWorkerPool(accept func(context.Context, []driver.Node) ([]driver.Node, error)) *RpcWorkerPool {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &RpcWorkerPool{
 		txs:     make(chan *types.Transaction),
+		nodes:   make(map[driver.Node]bool, 10),
 		workers: make(map[driver.Node]*workerGroup, 10),
+		accept:  accept,
 		ctx:     ctx,
 		cancel:  cancel,
 	}
@@ -55,20 +65,119 @@ func (p *RpcWorkerPool) AfterNodeCreation(newNode driver.Node) {
 	if p.ctx.Err() == context.Canceled {
 		return
 	}
+	if newNode.GetServiceUrl(&node.OperaWsService) == nil {
+		return
+	}
+	accepted := true
+	if p.accept != nil {
+		selected, err := p.accept(p.ctx, []driver.Node{newNode})
+		if err != nil {
+			log.Printf("failed to check routing for node %s; %v", newNode.GetLabel(), err)
+		}
+		accepted = len(selected) > 0
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.nodes[newNode] = true
+	if accepted {
+		p.startWorkers(newNode)
+	}
+}
+
+func (p *RpcWorkerPool) AfterNodeRemoval(node driver.Node) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.nodes, node)
+	p.stopWorkers(node)
+}
+
+// Refresh repeats the selection of the nodes transactions are sent to, for
+// instance, to follow changes of the validator set. Workers are started for
+// newly selected nodes and stopped for nodes no longer selected.
+func (p *RpcWorkerPool) Refresh() {
+	if p.accept == nil || p.ctx.Err() == context.Canceled {
+		return
+	}
+
+	// The selection may involve RPC calls, so the pool is not locked.
+	p.mutex.Lock()
+	nodes := make([]driver.Node, 0, len(p.nodes))
+	for target := range p.nodes {
+		nodes = append(nodes, target)
+	}
+	p.mutex.Unlock()
+
+	accepted, err := p.accept(p.ctx, nodes)
+	if err != nil {
+		log.Printf("failed to refresh routing; %v", err)
+		return
+	}
+	selected := map[driver.Node]bool{}
+	for _, target := range accepted {
+		selected[target] = true
+	}
 
-	rpcUrl := newNode.GetServiceUrl(&node.OperaWsService)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.ctx.Err() == context.Canceled {
+		return
+	}
+	for _, target := range nodes {
+		if !p.nodes[target] {
+			continue // removed in the meantime
+		}
+		if selected[target] {
+			p.startWorkers(target)
+		} else {
+			p.stopWorkers(target)
+		}
+	}
+}
+
+// RefreshPeriodically starts a background loop calling Refresh with the
+// given period until the pool is closed.
+func (p *RpcWorkerPool) RefreshPeriodically(period time.Duration) {
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-ticker.C:
+				p.Refresh()
+			}
+		}
+	}()
+}
+
+// startWorkers starts the workers sending transactions to the given node,
+// unless they are running already. The pool must be locked.
+func (p *RpcWorkerPool) startWorkers(target driver.Node) {
+	if _, found := p.workers[target]; found {
+		return
+	}
+	rpcUrl := target.GetServiceUrl(&node.OperaWsService)
 	if rpcUrl == nil {
 		return
 	}
 	wg := workerGroup{}
-	p.workers[newNode] = &wg
+	p.workers[target] = &wg
 	for i := 0; i < 150; i++ {
 		wg.add(*rpcUrl, p.txs)
 	}
 }
 
-func (p *RpcWorkerPool) AfterNodeRemoval(node driver.Node) {
-	p.workers[node].close()
+// stopWorkers stops the workers sending transactions to the given node, if
+// there are any. The pool must be locked.
+func (p *RpcWorkerPool) stopWorkers(target driver.Node) {
+	if wg, found := p.workers[target]; found {
+		wg.close()
+		delete(p.workers, target)
+	}
 }
 
 func (p *RpcWorkerPool) AfterApplicationCreation(application driver.Application) {
@@ -80,10 +189,13 @@ func (p *RpcWorkerPool) Close() error {
 		return nil
 	}
 	p.cancel()
+	p.done.Wait()
 	log.Printf("waiting for worker pool to close")
+	p.mutex.Lock()
 	for _, wg := range p.workers {
 		wg.close()
 	}
+	p.mutex.Unlock()
 	log.Printf("worker pool has closed")
 	close(p.txs)
 	return nil
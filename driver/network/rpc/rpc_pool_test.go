@@ -17,12 +17,16 @@
 package rpc
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/mock/gomock"
 )
 
 func TestRetryRpcReturnGracefully(t *testing.T) {
@@ -43,7 +47,7 @@ func TestRetryRpcReturnGracefully(t *testing.T) {
 func TestClosePool(t *testing.T) {
 	t.Parallel()
 
-	pool := NewRpcWorkerPool()
+	pool := NewRpcWorkerPool(nil)
 	counter := &atomic.Int32{}
 	wg := &sync.WaitGroup{}
 
@@ -93,3 +97,63 @@ func TestCloseWorkerGroupStartStop(t *testing.T) {
 	}
 	wg.close()
 }
+
+func TestRefresh_FollowsChangesOfSelectedNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	url := driver.URL("wrong")
+	nodeA := driver.NewMockNode(ctrl)
+	nodeB := driver.NewMockNode(ctrl)
+	for _, node := range []*driver.MockNode{nodeA, nodeB} {
+		node.EXPECT().GetServiceUrl(gomock.Any()).Return(&url).AnyTimes()
+	}
+
+	selected := nodeA
+	pool := NewRpcWorkerPool(func(_ context.Context, nodes []driver.Node) ([]driver.Node, error) {
+		res := []driver.Node{}
+		for _, node := range nodes {
+			if node == selected {
+				res = append(res, node)
+			}
+		}
+		return res, nil
+	})
+	defer pool.Close()
+
+	check := func(want driver.Node) {
+		t.Helper()
+		if got := len(pool.workers); got != 1 {
+			t.Fatalf("unexpected number of nodes with workers, wanted 1, got %d", got)
+		}
+		if _, found := pool.workers[want]; !found {
+			t.Errorf("selected node has no workers")
+		}
+	}
+
+	pool.AfterNodeCreation(nodeA)
+	pool.AfterNodeCreation(nodeB)
+	check(nodeA)
+
+	selected = nodeB
+	pool.Refresh()
+	check(nodeB)
+}
+
+func TestRefresh_KeepsWorkersIfSelectionFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	url := driver.URL("wrong")
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetServiceUrl(gomock.Any()).Return(&url).AnyTimes()
+
+	var err error
+	pool := NewRpcWorkerPool(func(_ context.Context, nodes []driver.Node) ([]driver.Node, error) {
+		return nodes, err
+	})
+	defer pool.Close()
+
+	pool.AfterNodeCreation(node)
+	err = fmt.Errorf("injected error")
+	pool.Refresh()
+	if _, found := pool.workers[node]; !found {
+		t.Errorf("workers of node were stopped")
+	}
+}
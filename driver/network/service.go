@@ -17,6 +17,8 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -30,6 +32,7 @@ type ServiceDescription struct {
 	Name     string
 	Port     Port
 	Protocol string
+	Path     string // path of the service on its port, empty for the root
 }
 
 type ServiceGroup map[Port]*ServiceDescription
@@ -146,3 +149,60 @@ func Retry(numAttempts int, delay time.Duration, do func() error) error {
 	})
 	return err
 }
+
+// Backoff configures retries with exponentially growing delays between
+// attempts, starting with InitialDelay and doubling up to MaxDelay. Retries
+// are given up after Timeout has elapsed.
+type Backoff struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Timeout      time.Duration
+}
+
+// DefaultBackoff quickly detects services becoming available while giving up
+// after a time comparable to DefaultRetryAttempts with a 1s delay.
+var DefaultBackoff = Backoff{
+	InitialDelay: 50 * time.Millisecond,
+	MaxDelay:     2 * time.Second,
+	Timeout:      DefaultRetryAttempts * time.Second,
+}
+
+// RetryReturnWithBackoff executes the input function until it produces no
+// error, waiting for exponentially growing delays between attempts. It gives
+// up when the timeout of the backoff elapsed or the context got cancelled, in
+// which case the last error of the function is returned.
+func RetryReturnWithBackoff[Out any](ctx context.Context, backoff Backoff, do func() (Out, error)) (Out, error) {
+	if backoff.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, backoff.Timeout)
+		defer cancel()
+	}
+	delay := backoff.InitialDelay
+	for {
+		out, err := do()
+		if err == nil {
+			return out, nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return out, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		delay *= 2
+		if delay > backoff.MaxDelay {
+			delay = backoff.MaxDelay
+		}
+	}
+}
+
+// RetryWithBackoff executes the input function until it produces no error,
+// waiting for exponentially growing delays between attempts. See
+// RetryReturnWithBackoff for details.
+func RetryWithBackoff(ctx context.Context, backoff Backoff, do func() error) error {
+	_, err := RetryReturnWithBackoff(ctx, backoff, func() (*int, error) {
+		return nil, do()
+	})
+	return err
+}
@@ -17,6 +17,8 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"testing"
@@ -94,3 +96,57 @@ func TestRetry(t *testing.T) {
 		t.Errorf("Retry finished early: %d < %d", got, want)
 	}
 }
+
+func TestRetryWithBackoff_DelaysGrowExponentially(t *testing.T) {
+	t.Parallel()
+
+	var times []time.Time
+	backoff := Backoff{InitialDelay: 10 * time.Millisecond, MaxDelay: 40 * time.Millisecond, Timeout: time.Minute}
+	err := RetryWithBackoff(context.Background(), backoff, func() error {
+		times = append(times, time.Now())
+		if len(times) < 5 {
+			return fmt.Errorf("no time to end yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RetryWithBackoff should succeed eventually: %v", err)
+	}
+	wanted := []time.Duration{10, 20, 40, 40}
+	for i, want := range wanted {
+		if got := times[i+1].Sub(times[i]); got < want*time.Millisecond {
+			t.Errorf("delay %d too short, wanted at least %v, got %v", i, want*time.Millisecond, got)
+		}
+	}
+}
+
+func TestRetryWithBackoff_StopsAtTimeout(t *testing.T) {
+	t.Parallel()
+
+	injected := fmt.Errorf("injected")
+	backoff := Backoff{InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Timeout: 20 * time.Millisecond}
+	err := RetryWithBackoff(context.Background(), backoff, func() error {
+		return injected
+	})
+	if !errors.Is(err, injected) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRetryWithBackoff_StopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var count int
+	err := RetryWithBackoff(ctx, DefaultBackoff, func() error {
+		count++
+		cancel()
+		return fmt.Errorf("injected")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("function should not be retried after cancellation, was called %d times", count)
+	}
+}
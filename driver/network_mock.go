@@ -26,6 +26,7 @@
 package driver
 
 import (
+	context "context"
 	reflect "reflect"
 
 	rpc "github.com/Fantom-foundation/Norma/driver/rpc"
@@ -57,33 +58,33 @@ func (m *MockNetwork) EXPECT() *MockNetworkMockRecorder {
 }
 
 // CreateApplication mocks base method.
-func (m *MockNetwork) CreateApplication(config *ApplicationConfig) (Application, error) {
+func (m *MockNetwork) CreateApplication(ctx context.Context, config *ApplicationConfig) (Application, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateApplication", config)
+	ret := m.ctrl.Call(m, "CreateApplication", ctx, config)
 	ret0, _ := ret[0].(Application)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateApplication indicates an expected call of CreateApplication.
-func (mr *MockNetworkMockRecorder) CreateApplication(config any) *gomock.Call {
+func (mr *MockNetworkMockRecorder) CreateApplication(ctx, config any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplication", reflect.TypeOf((*MockNetwork)(nil).CreateApplication), config)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplication", reflect.TypeOf((*MockNetwork)(nil).CreateApplication), ctx, config)
 }
 
 // CreateNode mocks base method.
-func (m *MockNetwork) CreateNode(config *NodeConfig) (Node, error) {
+func (m *MockNetwork) CreateNode(ctx context.Context, config *NodeConfig) (Node, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNode", config)
+	ret := m.ctrl.Call(m, "CreateNode", ctx, config)
 	ret0, _ := ret[0].(Node)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNode indicates an expected call of CreateNode.
-func (mr *MockNetworkMockRecorder) CreateNode(config any) *gomock.Call {
+func (mr *MockNetworkMockRecorder) CreateNode(ctx, config any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNode", reflect.TypeOf((*MockNetwork)(nil).CreateNode), config)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNode", reflect.TypeOf((*MockNetwork)(nil).CreateNode), ctx, config)
 }
 
 // DialRandomRpc mocks base method.
@@ -129,6 +130,21 @@ func (mr *MockNetworkMockRecorder) GetActiveNodes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveNodes", reflect.TypeOf((*MockNetwork)(nil).GetActiveNodes))
 }
 
+// GetValidators mocks base method.
+func (m *MockNetwork) GetValidators(ctx context.Context) ([]ValidatorInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidators", ctx)
+	ret0, _ := ret[0].([]ValidatorInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidators indicates an expected call of GetValidators.
+func (mr *MockNetworkMockRecorder) GetValidators(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidators", reflect.TypeOf((*MockNetwork)(nil).GetValidators), ctx)
+}
+
 // KillNode mocks base method.
 func (m *MockNetwork) KillNode(arg0 Node) error {
 	m.ctrl.T.Helper()
@@ -156,17 +172,17 @@ func (mr *MockNetworkMockRecorder) RegisterListener(arg0 any) *gomock.Call {
 }
 
 // RemoveNode mocks base method.
-func (m *MockNetwork) RemoveNode(arg0 Node) error {
+func (m *MockNetwork) RemoveNode(ctx context.Context, node Node) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveNode", arg0)
+	ret := m.ctrl.Call(m, "RemoveNode", ctx, node)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveNode indicates an expected call of RemoveNode.
-func (mr *MockNetworkMockRecorder) RemoveNode(arg0 any) *gomock.Call {
+func (mr *MockNetworkMockRecorder) RemoveNode(ctx, node any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNode", reflect.TypeOf((*MockNetwork)(nil).RemoveNode), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNode", reflect.TypeOf((*MockNetwork)(nil).RemoveNode), ctx, node)
 }
 
 // SendTransaction mocks base method.
@@ -222,6 +238,20 @@ func (mr *MockNetworkMockRecorder) UnregisterListener(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterListener", reflect.TypeOf((*MockNetwork)(nil).UnregisterListener), arg0)
 }
 
+// UpdateNetworkRules mocks base method.
+func (m *MockNetwork) UpdateNetworkRules(ctx context.Context, rules NetworkRules) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNetworkRules", ctx, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNetworkRules indicates an expected call of UpdateNetworkRules.
+func (mr *MockNetworkMockRecorder) UpdateNetworkRules(ctx, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetworkRules", reflect.TypeOf((*MockNetwork)(nil).UpdateNetworkRules), ctx, rules)
+}
+
 // MockNetworkListener is a mock of NetworkListener interface.
 type MockNetworkListener struct {
 	ctrl     *gomock.Controller
@@ -17,7 +17,9 @@
 package driver
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
@@ -33,43 +35,78 @@ type Node interface {
 	// to label data and should be unique within a single scenario run.
 	GetLabel() string
 
+	// GetType returns the role of this node within the network.
+	GetType() NodeType
+
+	// GetValidatorId returns the ID of the validator operated by this node,
+	// or nil if the node is not running a validator.
+	GetValidatorId() *int
+
 	// Hostname returns the hostname of the host.
 	Hostname() string
 
 	// MetricsPort returns the port on which the node exposes its metrics.
 	MetricsPort() int
 
+	// GetStartTime returns the time the start of the node was initiated,
+	// including the bootstrapping of its state.
+	GetStartTime() time.Time
+
 	// IsRunning returns true if the node is still running, false if stopped.
 	IsRunning() bool
 
+	// GetResourceLimits returns the limits of host resources applied to the
+	// node's client, as reported by its host.
+	GetResourceLimits(ctx context.Context) (network.ResourceLimits, error)
+
+	// GetExitCode returns the exit code of the node's client process if it
+	// has terminated, or nil if it is still running. Unlike IsRunning, this
+	// reflects the actual state of the process, covering unexpected crashes.
+	GetExitCode(ctx context.Context) (*int, error)
+
 	// GetNodeID returns an enode identifying this node within the Norma network.
 	// An error shall be produced if no valid node ID could be obtained.
-	GetNodeID() (NodeID, error)
+	GetNodeID(ctx context.Context) (NodeID, error)
 
 	// GetServiceUrl returns the URL of a service running on the
 	// represented node. May be nil if no such service is offered.
 	GetServiceUrl(*network.ServiceDescription) *URL
 
 	// DialRpc establish an RPC connection with the node and returns the RPC client.
+	// Implementations may share the connection among callers, in which case
+	// closing the returned client has no effect on other callers.
 	DialRpc() (rpc.RpcClient, error)
 
 	// StreamLog provides a reader that is continuously providing the host log.
-	// It is up to the caller to close the stream.
-	StreamLog() (io.ReadCloser, error)
+	// It is up to the caller to close the stream, which also ends when the
+	// given context is done.
+	StreamLog(ctx context.Context) (io.ReadCloser, error)
 
 	// Stop shuts down this node gracefully, using its regular shutdown
 	// procedure (not killed). After stopping the service, no more interactions
 	// are expected to succeed.
-	Stop() error
+	Stop(ctx context.Context) error
 
 	// Kill shuts down this node disgracefully by using SigKill.
-	Kill() error
+	Kill(ctx context.Context) error
 
 	// Cleanup releases all underlying resources. After the cleanup no more
 	// operations on this node are expected to succeed.
-	Cleanup() error
+	Cleanup(ctx context.Context) error
 }
 
+// NodeType defines the role of a node within the network.
+type NodeType string
+
+const (
+	// ValidatorNode is a node participating in the consensus.
+	ValidatorNode NodeType = "validator"
+	// RpcNode is a node serving requests of users, e.g. to submit transactions.
+	RpcNode NodeType = "rpc"
+	// ObserverNode is a node following the chain without serving users.
+	ObserverNode NodeType = "observer"
+)
+
 // NodeID is a unique ID identifying each node. This identifier is used, for
 // instance, to connect nodes within the network. In Opera, this ID is known
 // as an 'enode' identifier.
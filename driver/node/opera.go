@@ -21,69 +21,122 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
+	"sync"
 	"time"
 
 	rpcdriver "github.com/Fantom-foundation/Norma/driver/rpc"
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/docker"
+	"github.com/Fantom-foundation/Norma/driver/genesis"
 	"github.com/Fantom-foundation/Norma/driver/network"
+	"github.com/Fantom-foundation/Norma/driver/parser"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
-var OperaRpcService = network.ServiceDescription{
-	Name:     "OperaRPC",
-	Port:     18545,
-	Protocol: "http",
-}
-
-var OperaWsService = network.ServiceDescription{
-	Name:     "OperaWs",
-	Port:     18546,
-	Protocol: "ws",
-}
-
-var OperaDebugService = network.ServiceDescription{
-	Name:     "OperaPprof",
-	Port:     6060,
-	Protocol: "http",
-}
-
-var operaServices = network.ServiceGroup{}
-
-func init() {
-	if err := operaServices.RegisterService(&OperaRpcService); err != nil {
-		panic(err)
-	}
-	if err := operaServices.RegisterService(&OperaWsService); err != nil {
-		panic(err)
-	}
-	if err := operaServices.RegisterService(&OperaDebugService); err != nil {
-		panic(err)
-	}
-}
-
 const operaDockerImageName = "sonic"
 
 // OperaNode implements the driver's Node interface by running a go-opera
 // client on a generic host.
 type OperaNode struct {
-	host      network.Host
-	container *docker.Container
-	label     string
+	host        network.Host
+	container   *docker.Container
+	label       string
+	nodeType    driver.NodeType
+	validatorId *int
+	started     time.Time
+	services    []*OperaService
+
+	// rpcClient is the RPC client shared by all users of the node, dialed
+	// on first use, and nodeId the node ID obtained through it. Both are
+	// dropped when the node is stopped or the connection fails, such that
+	// they are obtained again on next use.
+	rpcClient *rpc.Client
+	nodeId    driver.NodeID
+	mutex     sync.Mutex
 }
 
+// rpcReadyLogMarker is logged by the client once its RPC server is started.
+const rpcReadyLogMarker = "HTTP server started"
+
 type OperaNodeConfig struct {
 	// The label to be used to name this node. The label should not be empty.
 	Label string
+	// The role of the node in the network, derived from ValidatorId if empty.
+	Type driver.NodeType
 	// The ID of the validator, nil if the node should not be a validator.
 	ValidatorId *int
 	// The configuration of the network the configured node should be part of.
 	NetworkConfig *driver.NetworkConfig
 	// ValidatorPubkey is nil if not a validator, else used as pubkey for the validator.
 	ValidatorPubkey *string
+	// Resources limits the host resources available to the node.
+	Resources network.ResourceLimits
+	// Genesis is the genesis file shared by all nodes of the network. If
+	// nil, it is built from the network configuration.
+	Genesis []byte
+	// BootstrapFrom is a running node of the network whose current state is
+	// exported and imported by the new node before it starts. If nil, the
+	// node starts from the initial state and syncs the chain from its peers.
+	BootstrapFrom *OperaNode
+	// Services lists the names of optional services to be enabled on the
+	// node in addition to the services enabled on all nodes.
+	Services []string
+}
+
+// genesisPath is the location of the genesis file within the container.
+const genesisPath = "/genesis.json"
+
+const (
+	// initialStateGenesisPath is the location of the genesis file of the
+	// initial state within the container.
+	initialStateGenesisPath = "/initial_state.g"
+	// initialStateDatadirPath is the location of the datadir of the initial
+	// state within the container.
+	initialStateDatadirPath = "/initial_state"
+)
+
+// getMounts lists the host paths to be mounted into the container of a node
+// to provide the initial state of the network.
+func getMounts(config *OperaNodeConfig) ([]docker.Mount, error) {
+	mounts := []docker.Mount{}
+	state := config.NetworkConfig.InitialState
+	if state.Genesis != nil {
+		path, err := filepath.Abs(*state.Genesis)
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial state genesis; %v", err)
+		}
+		mounts = append(mounts, docker.Mount{Source: path, Target: initialStateGenesisPath, ReadOnly: true})
+	}
+	if state.Datadir != nil {
+		path, err := filepath.Abs(*state.Datadir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial state datadir; %v", err)
+		}
+		mounts = append(mounts, docker.Mount{Source: path, Target: initialStateDatadirPath, ReadOnly: true})
+	}
+	return mounts, nil
+}
+
+// getDatadir creates the host directory in which the datadir of a node is
+// kept after the run. It returns nil if datadirs are not to be kept.
+func getDatadir(config *OperaNodeConfig) (*string, error) {
+	dir := config.NetworkConfig.DatadirDirectory
+	if dir == "" {
+		return nil, nil
+	}
+	path, err := filepath.Abs(filepath.Join(dir, config.Label))
+	if err != nil {
+		return nil, fmt.Errorf("invalid datadir directory; %v", err)
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create datadir of node %s; %v", config.Label, err)
+	}
+	return &path, nil
 }
 
 // labelPattern restricts labels for nodes to non-empty alpha-numerical strings
@@ -91,66 +144,154 @@ type OperaNodeConfig struct {
 var labelPattern = regexp.MustCompile("[A-Za-z0-9_-]+")
 
 // StartOperaDockerNode creates a new OperaNode running in a Docker container.
-func StartOperaDockerNode(client *docker.Client, dn *docker.Network, config *OperaNodeConfig) (*OperaNode, error) {
+// The start is aborted and the container removed if the context is cancelled
+// before the node is online.
+func StartOperaDockerNode(ctx context.Context, client *docker.Client, dn *docker.Network, config *OperaNodeConfig) (*OperaNode, error) {
 	if !labelPattern.Match([]byte(config.Label)) {
 		return nil, fmt.Errorf("invalid label for node: '%v'", config.Label)
 	}
+	started := time.Now()
 
 	shutdownTimeout := 180 * time.Second
 
 	validatorId := "0"
-	if config.ValidatorId != nil {
+	var validator *int
+	if config.ValidatorId != nil && *config.ValidatorId > 0 {
 		validatorId = fmt.Sprintf("%d", *config.ValidatorId)
+		id := *config.ValidatorId
+		validator = &id
 	}
 
-	host, err := network.RetryReturn(network.DefaultRetryAttempts, 1*time.Second, func() (*docker.Container, error) {
-		ports, err := network.GetFreePorts(len(operaServices.Services()))
-		portForwarding := make(map[network.Port]network.Port, len(ports))
-		for i, service := range operaServices.Services() {
-			portForwarding[service.Port] = ports[i]
+	nodeType := config.Type
+	if nodeType == "" {
+		nodeType = driver.RpcNode
+		if validator != nil {
+			nodeType = driver.ValidatorNode
 		}
+	}
+
+	// Peers are only discovered automatically in a full mesh, otherwise
+	// connections are managed by the network according to its topology.
+	discovery := "true"
+	if topology := config.NetworkConfig.Topology.Type; topology != "" && topology != parser.FullMeshTopology {
+		discovery = "false"
+	}
+
+	// A snapshot of the running network replaces the initial state.
+	mounts := []docker.Mount{}
+	if config.BootstrapFrom == nil {
+		var err error
+		mounts, err = getMounts(config)
 		if err != nil {
 			return nil, err
 		}
-		return client.Start(&docker.ContainerConfig{
-			ImageName:       operaDockerImageName,
-			ShutdownTimeout: &shutdownTimeout,
-			PortForwarding:  portForwarding,
-			Environment: map[string]string{
-				"VALIDATOR_ID":     validatorId,
-				"VALIDATORS_COUNT": fmt.Sprintf("%d", config.NetworkConfig.NumberOfValidators),
-				"MAX_BLOCK_GAS":    fmt.Sprintf("%d", config.NetworkConfig.MaxBlockGas),
-				"MAX_EPOCH_GAS":    fmt.Sprintf("%d", config.NetworkConfig.MaxEpochGas),
-				"NETWORK_LATENCY":  fmt.Sprintf("%v", config.NetworkConfig.RoundTripTime/2),
-			},
-			Network: dn,
-		})
+	}
+	services, err := getServices(config.Services)
+	if err != nil {
+		return nil, err
+	}
+	datadir, err := getDatadir(config)
+	if err != nil {
+		return nil, err
+	}
+	environment := map[string]string{
+		"VALIDATOR_ID":     validatorId,
+		"VALIDATORS_COUNT": fmt.Sprintf("%d", config.NetworkConfig.NumberOfValidators),
+		"NETWORK_LATENCY":  fmt.Sprintf("%v", config.NetworkConfig.RoundTripTime/2),
+		"PEER_DISCOVERY":   discovery,
+		"NODE_TYPE":        string(nodeType),
+	}
+	for key, value := range getServiceEnvironment(services) {
+		environment[key] = value
+	}
+	if state := config.NetworkConfig.InitialState; state.Genesis != nil {
+		environment["INITIAL_STATE"] = "genesis"
+	} else if state.Datadir != nil {
+		environment["INITIAL_STATE"] = "datadir"
+	}
+
+	genesisFile := config.Genesis
+	if genesisFile == nil {
+		var err error
+		genesisFile, err = genesis.Build(config.NetworkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build genesis; %v", err)
+		}
+	}
+	files := map[string][]byte{genesisPath: genesisFile}
+
+	if config.BootstrapFrom != nil {
+		snapshot, err := os.CreateTemp("", "norma_snapshot_*.g")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot file; %v", err)
+		}
+		snapshot.Close()
+		// The snapshot is imported before the node is reported to be ready.
+		defer os.Remove(snapshot.Name())
+		if err := config.BootstrapFrom.exportState(ctx, snapshot.Name()); err != nil {
+			return nil, fmt.Errorf("failed to bootstrap from node %s; %v", config.BootstrapFrom.GetLabel(), err)
+		}
+		mounts = append(mounts, docker.Mount{Source: snapshot.Name(), Target: initialStateGenesisPath, ReadOnly: true})
+		environment["INITIAL_STATE"] = "genesis"
+	}
+
+	host, err := client.Start(ctx, &docker.ContainerConfig{
+		ImageName:       operaDockerImageName,
+		ShutdownTimeout: &shutdownTimeout,
+		ExportedPorts:   getPorts(services),
+		Environment:     environment,
+		Network:         dn,
+		Resources:       config.Resources,
+		Files:           files,
+		Mounts:          mounts,
+		MountDatadir:    datadir,
 	})
 	if err != nil {
 		return nil, err
 	}
 	node := &OperaNode{
-		host:      host,
-		container: host,
-		label:     config.Label,
+		host:        host,
+		container:   host,
+		label:       config.Label,
+		nodeType:    nodeType,
+		validatorId: validator,
+		started:     started,
+		services:    services,
 	}
 
-	// Wait until the OperaNode inside the Container is ready.
-	if err := network.Retry(network.DefaultRetryAttempts, 1*time.Second, func() error {
-		_, err := node.GetNodeID()
-		return err
-	}); err == nil {
+	// Wait until the OperaNode inside the Container is ready. The start of its
+	// RPC server is announced in the log, after which the node ID is obtained.
+	ctx, cancel := context.WithTimeout(ctx, network.DefaultBackoff.Timeout)
+	defer cancel()
+	err = host.WaitForLog(ctx, rpcReadyLogMarker)
+	if err == nil {
+		err = network.RetryWithBackoff(ctx, network.DefaultBackoff, func() error {
+			_, err := node.GetNodeID(ctx)
+			return err
+		})
+	}
+	if err == nil {
 		return node, nil
 	}
 
 	// The node did not show up in time, so we consider the start to have failed.
-	return nil, errors.Join(fmt.Errorf("failed to get node online"), node.host.Cleanup())
+	return nil, errors.Join(fmt.Errorf("failed to get node online; %v", err), node.Cleanup(context.WithoutCancel(ctx)))
 }
 
 func (n *OperaNode) GetLabel() string {
 	return n.label
 }
 
+func (n *OperaNode) GetType() driver.NodeType {
+	return n.nodeType
+}
+
+// GetValidatorId returns the ID of the validator run by this node, or nil
+// if the node is not a validator.
+func (n *OperaNode) GetValidatorId() *int {
+	return n.validatorId
+}
+
 // Hostname returns the hostname of the node.
 // The hostname is accessible only inside the Docker network.
 func (n *OperaNode) Hostname() string {
@@ -160,100 +301,211 @@ func (n *OperaNode) Hostname() string {
 // MetricsPort returns the port on which the node exports its metrics.
 // The port is accessible only inside the Docker network.
 func (n *OperaNode) MetricsPort() int {
-	return 6060
+	return int(OperaDebugService.Port)
+}
+
+// GetStartTime returns the time the start of the node was initiated.
+func (n *OperaNode) GetStartTime() time.Time {
+	return n.started
 }
 
 func (n *OperaNode) IsRunning() bool {
 	return n.host.IsRunning()
 }
 
+// GetExitCode returns the exit code of the client running in the node's
+// container, or nil if the client is still running.
+func (n *OperaNode) GetExitCode(ctx context.Context) (*int, error) {
+	return n.container.GetExitCode(ctx)
+}
+
+// GetResourceLimits returns the resource limits applied to the node's container.
+func (n *OperaNode) GetResourceLimits(ctx context.Context) (network.ResourceLimits, error) {
+	return n.container.GetResourceLimits(ctx)
+}
+
+// GetServiceUrl returns the URL of the given service of the node, or nil if
+// the service is not enabled on the node.
 func (n *OperaNode) GetServiceUrl(service *network.ServiceDescription) *driver.URL {
+	if !n.isEnabled(service) {
+		return nil
+	}
 	addr := n.host.GetAddressForService(service)
 	if addr == nil {
 		return nil
 	}
-	url := driver.URL(fmt.Sprintf("%s://%s", service.Protocol, *addr))
+	url := driver.URL(fmt.Sprintf("%s://%s%s", service.Protocol, *addr, service.Path))
 	return &url
 }
 
-func (n *OperaNode) GetNodeID() (driver.NodeID, error) {
-	url := n.GetServiceUrl(&OperaRpcService)
-	if url == nil {
-		return "", fmt.Errorf("node does not export an RPC server")
+// isEnabled checks whether the given service is enabled on the node. Services
+// not known to the registry are passed through to the host.
+func (n *OperaNode) isEnabled(service *network.ServiceDescription) bool {
+	if n.services == nil || !isRegistered(service) {
+		return true
 	}
-	rpcClient, err := rpc.DialContext(context.Background(), string(*url))
+	return slices.ContainsFunc(n.services, func(enabled *OperaService) bool {
+		return enabled.Description.Name == service.Name
+	})
+}
+
+// GetNodeID returns the enode of the node. It is obtained from the node once
+// and cached afterwards, until the node is stopped or its connection fails.
+func (n *OperaNode) GetNodeID(ctx context.Context) (driver.NodeID, error) {
+	n.mutex.Lock()
+	id := n.nodeId
+	n.mutex.Unlock()
+	if id != "" {
+		return id, nil
+	}
+	id, err := n.GetCurrentNodeID(ctx)
 	if err != nil {
 		return "", err
 	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.nodeId == "" {
+		n.nodeId = id
+	}
+	return n.nodeId, nil
+}
+
+// GetCurrentNodeID obtains the enode of the node from the node itself. It
+// differs from the ID returned by GetNodeID, which is cached, if the address
+// of the node has changed since, e.g. after a restart.
+func (n *OperaNode) GetCurrentNodeID(ctx context.Context) (driver.NodeID, error) {
 	var result struct {
 		Enode string
 	}
-	err = rpcClient.Call(&result, "admin_nodeInfo")
-	if err != nil {
+	if err := n.call(ctx, &result, "admin_nodeInfo"); err != nil {
 		return "", err
 	}
 	return driver.NodeID(result.Enode), nil
 }
 
-func (n *OperaNode) StreamLog() (io.ReadCloser, error) {
-	return n.host.StreamLog()
+// GetPeers returns the IDs of the nodes this node is currently connected to.
+func (n *OperaNode) GetPeers(ctx context.Context) ([]driver.NodeID, error) {
+	var result []struct {
+		Enode string
+	}
+	if err := n.call(ctx, &result, "admin_peers"); err != nil {
+		return nil, err
+	}
+	res := make([]driver.NodeID, 0, len(result))
+	for _, peer := range result {
+		res = append(res, driver.NodeID(peer.Enode))
+	}
+	return res, nil
 }
 
-func (n *OperaNode) Stop() error {
-	return n.host.Stop()
+func (n *OperaNode) StreamLog(ctx context.Context) (io.ReadCloser, error) {
+	return n.host.StreamLog(ctx)
 }
 
-func (n *OperaNode) Cleanup() error {
-	return n.host.Cleanup()
+// Stop releases the RPC client of the node and stops its container.
+func (n *OperaNode) Stop(ctx context.Context) error {
+	n.dropRpcClient(nil)
+	return n.host.Stop(ctx)
 }
 
+// Cleanup releases the RPC client of the node and removes its container.
+func (n *OperaNode) Cleanup(ctx context.Context) error {
+	n.dropRpcClient(nil)
+	return n.host.Cleanup(ctx)
+}
+
+// DialRpc returns an RPC client connected to the node. The client is shared
+// by all users of the node, closing it has no effect.
 func (n *OperaNode) DialRpc() (rpcdriver.RpcClient, error) {
+	rpcClient, err := n.getRpcClient()
+	if err != nil {
+		return nil, err
+	}
+	return sharedRpcClient{rpcdriver.WrapRpcClient(rpcClient)}, nil
+}
+
+// getRpcClient returns the RPC client of the node, dialing it on first use.
+func (n *OperaNode) getRpcClient() (*rpc.Client, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.rpcClient != nil {
+		return n.rpcClient, nil
+	}
 	url := n.GetServiceUrl(&OperaRpcService)
 	if url == nil {
 		return nil, fmt.Errorf("node %s does not export an RPC server", n.label)
 	}
-
-	rpcClient, err := network.RetryReturn(network.DefaultRetryAttempts, 1*time.Second, func() (*rpc.Client, error) {
-		return rpc.DialContext(context.Background(), string(*url))
-	})
+	rpcClient, err := rpc.DialContext(context.Background(), string(*url))
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial RPC for node %s; %v", n.label, err)
 	}
-	return rpcdriver.WrapRpcClient(rpcClient), nil
+	n.rpcClient = rpcClient
+	return rpcClient, nil
 }
 
-// AddPeer informs the client instance represented by the OperaNode about the
-// existence of another node, to which it may establish a connection.
-func (n *OperaNode) AddPeer(id driver.NodeID) error {
-	rpcClient, err := n.DialRpc()
+// dropRpcClient closes the cached RPC client and forgets the node ID obtained
+// through it, such that both are obtained again on next use. If the given
+// client is not nil, they are only dropped if it is still the cached client.
+func (n *OperaNode) dropRpcClient(rpcClient *rpc.Client) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.rpcClient == nil || (rpcClient != nil && rpcClient != n.rpcClient) {
+		return
+	}
+	n.rpcClient.Close()
+	n.rpcClient = nil
+	n.nodeId = ""
+}
+
+// call invokes the given RPC method on the node. If the node can not be
+// reached, the cached RPC client is dropped, since the address of the node
+// may have changed, e.g. after a restart. Errors reported by the node itself
+// retain the client.
+func (n *OperaNode) call(ctx context.Context, result any, method string, args ...any) error {
+	rpcClient, err := n.getRpcClient()
 	if err != nil {
 		return err
 	}
-	return network.Retry(network.DefaultRetryAttempts, 1*time.Second, func() error {
-		return rpcClient.Call(nil, "admin_addPeer", id)
+	err = rpcClient.CallContext(ctx, result, method, args...)
+	var rpcErr rpc.Error
+	if err != nil && !errors.As(err, &rpcErr) {
+		n.dropRpcClient(rpcClient)
+	}
+	return err
+}
+
+// sharedRpcClient is an RPC client shared among all users of a node. The
+// underlying connection is closed when the node is cleaned up.
+type sharedRpcClient struct {
+	*rpcdriver.RpcClientImpl
+}
+
+func (sharedRpcClient) Close() {}
+
+// AddPeer informs the client instance represented by the OperaNode about the
+// existence of another node, to which it may establish a connection.
+func (n *OperaNode) AddPeer(ctx context.Context, id driver.NodeID) error {
+	return network.RetryWithBackoff(ctx, network.DefaultBackoff, func() error {
+		return n.call(ctx, nil, "admin_addPeer", id)
 	})
 }
 
 // RemovePeer informs the client instance represented by the OperaNode
 // that the input node is no more available in the network.
-func (n *OperaNode) RemovePeer(id driver.NodeID) error {
-	rpcClient, err := n.DialRpc()
-	if err != nil {
-		return err
-	}
-	return network.Retry(network.DefaultRetryAttempts, 1*time.Second, func() error {
-		return rpcClient.Call(nil, "admin_removePeer", id)
+func (n *OperaNode) RemovePeer(ctx context.Context, id driver.NodeID) error {
+	return network.RetryWithBackoff(ctx, network.DefaultBackoff, func() error {
+		return n.call(ctx, nil, "admin_removePeer", id)
 	})
 }
 
 // Kill sends a SigKill singal to node.
-func (n *OperaNode) Kill() error {
-	return n.container.SendSignal(docker.SigKill)
+func (n *OperaNode) Kill(ctx context.Context) error {
+	return n.container.SendSignal(ctx, docker.SigKill)
 }
 
 // GetRoundTripTime returns the median network round-trip time to the given host.
-func (n *OperaNode) GetRoundTripTime(host string) (time.Duration, error) {
-	output, err := n.container.Exec([]string{"ping", "-c", "5", host})
+func (n *OperaNode) GetRoundTripTime(ctx context.Context, host string) (time.Duration, error) {
+	output, err := n.container.Exec(ctx, []string{"ping", "-c", "5", host})
 	if err != nil {
 		return 0, err
 	}
@@ -271,3 +523,34 @@ func (n *OperaNode) GetRoundTripTime(host string) (time.Duration, error) {
 	slices.Sort(durations)
 	return durations[len(durations)/2], nil
 }
+
+const (
+	// exportRequestPath marks a requested export of the state of a node. If
+	// present when the client stops, the start script exports the state into
+	// exportStatePath and restarts the client afterwards.
+	exportRequestPath = "/tmp/export.request"
+	// exportStatePath is the location of the exported state in the container.
+	exportStatePath = "/tmp/export.g"
+	// clientPidPath is the file the start script records the client's PID in.
+	clientPidPath = "/tmp/sonicd.pid"
+)
+
+// exportState exports the current state of the chain known to this node into
+// the given genesis file on the host. To get a consistent state, the client
+// is shut down during the export, which flushes its databases, and restarted
+// afterwards. The container keeps running, so the node retains its identity
+// and addresses.
+func (n *OperaNode) exportState(ctx context.Context, file string) error {
+	export := fmt.Sprintf(
+		"rm -f %[2]s && touch %[1]s && kill -INT $(cat %[3]s) && while [ -f %[1]s ]; do sleep 1; done && test -f %[2]s",
+		exportRequestPath, exportStatePath, clientPidPath,
+	)
+	if output, err := n.container.Exec(ctx, []string{"sh", "-c", export}); err != nil {
+		return fmt.Errorf("failed to export state; %v\n%s", err, output)
+	}
+	err := n.container.CopyFileToHost(ctx, exportStatePath, file)
+	if _, cleanupErr := n.container.Exec(ctx, []string{"rm", "-f", exportStatePath}); cleanupErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to remove exported state; %v", cleanupErr))
+	}
+	return err
+}
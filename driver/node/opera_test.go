@@ -18,15 +18,24 @@ package node
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/docker"
+	"github.com/Fantom-foundation/Norma/driver/network"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"go.uber.org/mock/gomock"
 )
 
 func TestImplements(t *testing.T) {
@@ -35,6 +44,217 @@ func TestImplements(t *testing.T) {
 
 }
 
+func TestGetMounts_InitialStateIsMounted(t *testing.T) {
+	genesis := "state.g"
+	mounts, err := getMounts(&OperaNodeConfig{
+		NetworkConfig: &driver.NetworkConfig{InitialState: parser.InitialState{Genesis: &genesis}},
+	})
+	if err != nil {
+		t.Fatalf("failed to get mounts: %v", err)
+	}
+	if len(mounts) != 1 {
+		t.Fatalf("unexpected mounts: %v", mounts)
+	}
+	if got := mounts[0]; !filepath.IsAbs(got.Source) || filepath.Base(got.Source) != genesis || got.Target != initialStateGenesisPath || !got.ReadOnly {
+		t.Errorf("unexpected mount: %+v", got)
+	}
+
+	mounts, err = getMounts(&OperaNodeConfig{NetworkConfig: &driver.NetworkConfig{}})
+	if err != nil || len(mounts) != 0 {
+		t.Errorf("no mounts expected without initial state, got %v, %v", mounts, err)
+	}
+}
+
+func TestOperaNode_RpcClientAndNodeIdAreCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"enode":"enode://abc"}}`)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	host := network.NewMockHost(ctrl)
+	address := network.AddressPort(strings.TrimPrefix(server.URL, "http://"))
+	host.EXPECT().GetAddressForService(&OperaRpcService).Return(&address)
+	host.EXPECT().Cleanup(gomock.Any())
+
+	node := &OperaNode{host: host, label: "A"}
+	for i := 0; i < 2; i++ {
+		id, err := node.GetNodeID(context.Background())
+		if err != nil {
+			t.Fatalf("failed to get node ID: %v", err)
+		}
+		if want := driver.NodeID("enode://abc"); id != want {
+			t.Errorf("unexpected node ID, wanted %s, got %s", want, id)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("node ID should be requested once, got %d requests", requests)
+	}
+
+	// Closing a client handed out to a user must not affect other users.
+	first, err := node.DialRpc()
+	if err != nil {
+		t.Fatalf("failed to dial RPC: %v", err)
+	}
+	first.Close()
+	second, err := node.DialRpc()
+	if err != nil {
+		t.Fatalf("failed to dial RPC: %v", err)
+	}
+	if err := second.Call(nil, "admin_nodeInfo"); err != nil {
+		t.Errorf("shared client should still be usable, got %v", err)
+	}
+
+	if err := node.Cleanup(context.Background()); err != nil {
+		t.Errorf("failed to clean up node: %v", err)
+	}
+}
+
+func TestOperaNode_PeersAndCurrentNodeIdAreQueried(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Id     int
+			Method string
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch request.Method {
+		case "admin_peers":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":[{"enode":"enode://b@1.2.3.4:5050"},{"enode":"enode://c@1.2.3.5:5050"}]}`, request.Id)
+		default:
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"enode":"enode://a@1.2.3.3:5050"}}`, request.Id)
+		}
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	host := network.NewMockHost(ctrl)
+	address := network.AddressPort(strings.TrimPrefix(server.URL, "http://"))
+	host.EXPECT().GetAddressForService(&OperaRpcService).Return(&address)
+	host.EXPECT().Cleanup(gomock.Any())
+
+	node := &OperaNode{host: host, label: "A", nodeId: "enode://a@172.17.0.2:5050"}
+	peers, err := node.GetPeers(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get peers: %v", err)
+	}
+	want := []driver.NodeID{"enode://b@1.2.3.4:5050", "enode://c@1.2.3.5:5050"}
+	if !slices.Equal(peers, want) {
+		t.Errorf("unexpected peers, wanted %v, got %v", want, peers)
+	}
+
+	// The current ID is obtained from the node, even if an ID is cached.
+	id, err := node.GetCurrentNodeID(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get current node ID: %v", err)
+	}
+	if want := driver.NodeID("enode://a@1.2.3.3:5050"); id != want {
+		t.Errorf("unexpected node ID, wanted %s, got %s", want, id)
+	}
+
+	if err := node.Cleanup(context.Background()); err != nil {
+		t.Errorf("failed to clean up node: %v", err)
+	}
+}
+
+func TestOperaNode_CachedRpcClientIsDroppedIfNodeIsUnreachable(t *testing.T) {
+	newServer := func(enode string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"enode":"%s"}}`, enode)
+		}))
+	}
+	before := newServer("enode://a@1.2.3.3:5050")
+	after := newServer("enode://a@1.2.3.4:5050")
+	defer after.Close()
+
+	ctrl := gomock.NewController(t)
+	host := network.NewMockHost(ctrl)
+	addressBefore := network.AddressPort(strings.TrimPrefix(before.URL, "http://"))
+	addressAfter := network.AddressPort(strings.TrimPrefix(after.URL, "http://"))
+	gomock.InOrder(
+		host.EXPECT().GetAddressForService(&OperaRpcService).Return(&addressBefore),
+		host.EXPECT().GetAddressForService(&OperaRpcService).Return(&addressAfter),
+	)
+	host.EXPECT().Stop(gomock.Any())
+
+	node := &OperaNode{host: host, label: "A"}
+	if _, err := node.GetNodeID(context.Background()); err != nil {
+		t.Fatalf("failed to get node ID: %v", err)
+	}
+
+	// The node moves to another address, e.g. after a restart.
+	before.Close()
+	if _, err := node.GetCurrentNodeID(context.Background()); err == nil {
+		t.Fatalf("node should not be reachable at its old address")
+	}
+	id, err := node.GetNodeID(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get node ID: %v", err)
+	}
+	if want := driver.NodeID("enode://a@1.2.3.4:5050"); id != want {
+		t.Errorf("unexpected node ID, wanted %s, got %s", want, id)
+	}
+
+	if err := node.Stop(context.Background()); err != nil {
+		t.Errorf("failed to stop node: %v", err)
+	}
+	if node.rpcClient != nil || node.nodeId != "" {
+		t.Errorf("RPC client and node ID should be dropped when the node is stopped")
+	}
+}
+
+func TestOperaNode_PeerQueriesAreAbortedWithTheirContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctrl := gomock.NewController(t)
+	host := network.NewMockHost(ctrl)
+	address := network.AddressPort(strings.TrimPrefix(server.URL, "http://"))
+	host.EXPECT().GetAddressForService(&OperaRpcService).Return(&address).AnyTimes()
+
+	node := &OperaNode{host: host, label: "A"}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := node.GetPeers(ctx); err == nil {
+		t.Errorf("query of unresponsive node should fail")
+	}
+	if ctx.Err() == nil {
+		t.Errorf("query should only be aborted by its context")
+	}
+}
+
+func TestGetDatadir_DatadirIsCreatedIfRequested(t *testing.T) {
+	dir := t.TempDir()
+	datadir, err := getDatadir(&OperaNodeConfig{
+		Label:         "A-0",
+		NetworkConfig: &driver.NetworkConfig{DatadirDirectory: dir},
+	})
+	if err != nil {
+		t.Fatalf("failed to get datadir: %v", err)
+	}
+	if datadir == nil || *datadir != filepath.Join(dir, "A-0") {
+		t.Fatalf("unexpected datadir: %v", datadir)
+	}
+	if info, err := os.Stat(*datadir); err != nil || !info.IsDir() {
+		t.Errorf("datadir was not created: %v", err)
+	}
+
+	datadir, err = getDatadir(&OperaNodeConfig{Label: "A-0", NetworkConfig: &driver.NetworkConfig{}})
+	if err != nil || datadir != nil {
+		t.Errorf("no datadir expected, got %v, %v", datadir, err)
+	}
+}
+
 func TestOperaNode_StartAndStop(t *testing.T) {
 	docker, err := docker.NewClient()
 	if err != nil {
@@ -43,21 +263,21 @@ func TestOperaNode_StartAndStop(t *testing.T) {
 	t.Cleanup(func() {
 		_ = docker.Close()
 	})
-	node, err := StartOperaDockerNode(docker, nil, &OperaNodeConfig{
+	node, err := StartOperaDockerNode(context.Background(), docker, nil, &OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 
 	if err != nil {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
-	if err = node.host.Stop(); err != nil {
+	if err = node.host.Stop(context.Background()); err != nil {
 		t.Errorf("failed to stop Opera node: %v", err)
 	}
 }
@@ -70,21 +290,21 @@ func TestOperaNode_RpcServiceIsReadyAfterStartup(t *testing.T) {
 	t.Cleanup(func() {
 		_ = docker.Close()
 	})
-	node, err := StartOperaDockerNode(docker, nil, &OperaNodeConfig{
+	node, err := StartOperaDockerNode(context.Background(), docker, nil, &OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 
 	if err != nil {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
-	if id, err := node.GetNodeID(); err != nil || len(id) == 0 {
+	if id, err := node.GetNodeID(context.Background()); err != nil || len(id) == 0 {
 		t.Errorf("failed to fetch NodeID from Opera node: '%v', err: %v", id, err)
 	}
 }
@@ -98,7 +318,7 @@ func TestOperaNode_StreamLog(t *testing.T) {
 		_ = docker.Close()
 	})
 
-	node, err := StartOperaDockerNode(docker, nil, &OperaNodeConfig{
+	node, err := StartOperaDockerNode(context.Background(), docker, nil, &OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
@@ -106,10 +326,10 @@ func TestOperaNode_StreamLog(t *testing.T) {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 
-	reader, err := node.StreamLog()
+	reader, err := node.StreamLog(context.Background())
 	if err != nil {
 		t.Fatalf("cannot read logs: %e", err)
 	}
@@ -151,7 +371,7 @@ func TestOperaNode_MetricsExposed(t *testing.T) {
 		_ = docker.Close()
 	})
 
-	node, err := StartOperaDockerNode(docker, nil, &OperaNodeConfig{
+	node, err := StartOperaDockerNode(context.Background(), docker, nil, &OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
@@ -159,7 +379,7 @@ func TestOperaNode_MetricsExposed(t *testing.T) {
 		t.Fatalf("failed to create an Opera node on Docker: %v", err)
 	}
 	t.Cleanup(func() {
-		_ = node.Cleanup()
+		_ = node.Cleanup(context.Background())
 	})
 
 	url := node.GetServiceUrl(&OperaDebugService)
@@ -196,7 +416,7 @@ func TestClient_Stop_Graceful(t *testing.T) {
 		}
 	}()
 
-	node, err := StartOperaDockerNode(client, nil, &OperaNodeConfig{
+	node, err := StartOperaDockerNode(context.Background(), client, nil, &OperaNodeConfig{
 		Label:         "test",
 		NetworkConfig: &driver.NetworkConfig{NumberOfValidators: 1},
 	})
@@ -204,12 +424,12 @@ func TestClient_Stop_Graceful(t *testing.T) {
 		t.Fatalf("failed to create client node: %v", err)
 	}
 	defer func() {
-		if err := node.Cleanup(); err != nil {
+		if err := node.Cleanup(context.Background()); err != nil {
 			t.Errorf("cannot cleanup: %v", err)
 		}
 	}()
 
-	reader, err := node.StreamLog()
+	reader, err := node.StreamLog(context.Background())
 	if err != nil {
 		t.Errorf("error: %v", err)
 	}
@@ -230,7 +450,7 @@ func TestClient_Stop_Graceful(t *testing.T) {
 		}
 	}()
 
-	if err := node.Stop(); err != nil {
+	if err := node.Stop(context.Background()); err != nil {
 		t.Errorf("cannot stop client node: %v", err)
 	}
 
@@ -0,0 +1,203 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Fantom-foundation/Norma/driver/network"
+)
+
+// OperaService is a service offered by the client of Opera nodes, e.g. an API
+// or an endpoint probed by monitoring tools. Services are made available in a
+// registry, such that probes can add the services they need without having to
+// modify the nodes.
+type OperaService struct {
+	// Description defines the port and protocol of the service.
+	Description *network.ServiceDescription
+	// Flags are the command line flags of the client enabling the service.
+	Flags []string
+	// APIs are the RPC namespaces to be enabled for the service, in addition
+	// to the ones of the node type.
+	APIs []string
+	// Optional services are only enabled on nodes requesting them, while
+	// all other services are enabled on every node.
+	Optional bool
+}
+
+var OperaRpcService = network.ServiceDescription{
+	Name:     "OperaRPC",
+	Port:     18545,
+	Protocol: "http",
+}
+
+var OperaWsService = network.ServiceDescription{
+	Name:     "OperaWs",
+	Port:     18546,
+	Protocol: "ws",
+}
+
+// OperaDebugService is the pprof server of the node, which also exports the
+// metrics of the node in the format of Prometheus.
+var OperaDebugService = network.ServiceDescription{
+	Name:     "OperaPprof",
+	Port:     6060,
+	Protocol: "http",
+}
+
+// OperaP2pService is the peer-to-peer endpoint of the node. Peers running on
+// other Docker hosts connect to it through its forwarded port.
+var OperaP2pService = network.ServiceDescription{
+	Name:     "OperaP2P",
+	Port:     5050,
+	Protocol: "tcp",
+}
+
+// OperaGraphQlService is the GraphQL API of the node, served by its HTTP
+// server under the /graphql path.
+var OperaGraphQlService = network.ServiceDescription{
+	Name:     "OperaGraphQL",
+	Port:     18545,
+	Protocol: "http",
+	Path:     "/graphql",
+}
+
+// OperaTracingService is the API for tracing transactions, e.g. through
+// debug_traceTransaction, served by the HTTP server of the node.
+var OperaTracingService = network.ServiceDescription{
+	Name:     "OperaTracing",
+	Port:     18545,
+	Protocol: "http",
+}
+
+// Names of the optional services, used to request them for nodes.
+const (
+	GraphQlServiceName = "graphql"
+	TracingServiceName = "tracing"
+)
+
+var (
+	// operaServices is the registry of services, indexed by their names.
+	operaServices      = map[string]*OperaService{}
+	operaServicesMutex sync.Mutex
+)
+
+func init() {
+	services := map[string]OperaService{
+		"rpc":              {Description: &OperaRpcService},
+		"ws":               {Description: &OperaWsService},
+		"pprof":            {Description: &OperaDebugService},
+		"p2p":              {Description: &OperaP2pService},
+		GraphQlServiceName: {Description: &OperaGraphQlService, Flags: []string{"--graphql"}, Optional: true},
+		TracingServiceName: {Description: &OperaTracingService, APIs: []string{"debug"}, Optional: true},
+	}
+	for name, service := range services {
+		if err := RegisterService(name, service); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterService makes a service available on Opera nodes under the given
+// name. Services are expected to be registered during initialization, before
+// any node is started.
+func RegisterService(name string, service OperaService) error {
+	if service.Description == nil {
+		return fmt.Errorf("service %s has no description", name)
+	}
+	operaServicesMutex.Lock()
+	defer operaServicesMutex.Unlock()
+	if _, exists := operaServices[name]; exists {
+		return fmt.Errorf("service %s is already registered", name)
+	}
+	for other, registered := range operaServices {
+		if registered.Description.Name == service.Description.Name {
+			return fmt.Errorf("service %s is already registered as %s", service.Description.Name, other)
+		}
+	}
+	operaServices[name] = &service
+	return nil
+}
+
+// getServices returns the services to be enabled on a node, which are all
+// services that are not optional and the requested optional ones.
+func getServices(requested []string) ([]*OperaService, error) {
+	operaServicesMutex.Lock()
+	defer operaServicesMutex.Unlock()
+	for _, name := range requested {
+		if _, found := operaServices[name]; !found {
+			return nil, fmt.Errorf("unknown service %s", name)
+		}
+	}
+	names := make([]string, 0, len(operaServices))
+	for name, service := range operaServices {
+		if !service.Optional || slices.Contains(requested, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	res := make([]*OperaService, 0, len(names))
+	for _, name := range names {
+		res = append(res, operaServices[name])
+	}
+	return res, nil
+}
+
+// getPorts returns the ports of the given services, each of them listed once.
+func getPorts(services []*OperaService) []network.Port {
+	res := []network.Port{}
+	for _, service := range services {
+		if !slices.Contains(res, service.Description.Port) {
+			res = append(res, service.Description.Port)
+		}
+	}
+	return res
+}
+
+// getServiceEnvironment returns the environment variables passing the flags
+// and APIs of the given services to the client.
+func getServiceEnvironment(services []*OperaService) map[string]string {
+	flags, apis := []string{}, []string{}
+	for _, service := range services {
+		flags = append(flags, service.Flags...)
+		for _, api := range service.APIs {
+			if !slices.Contains(apis, api) {
+				apis = append(apis, api)
+			}
+		}
+	}
+	return map[string]string{
+		"SERVICE_FLAGS": strings.Join(flags, " "),
+		"SERVICE_APIS":  strings.Join(apis, ","),
+	}
+}
+
+// isRegistered checks whether a service of the given name is registered.
+func isRegistered(service *network.ServiceDescription) bool {
+	operaServicesMutex.Lock()
+	defer operaServicesMutex.Unlock()
+	for _, registered := range operaServices {
+		if registered.Description.Name == service.Name {
+			return true
+		}
+	}
+	return false
+}
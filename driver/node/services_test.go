@@ -0,0 +1,161 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/network"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetServices_DefaultServicesAreEnabledOnAllNodes(t *testing.T) {
+	services, err := getServices(nil)
+	if err != nil {
+		t.Fatalf("failed to get services: %v", err)
+	}
+	names := getDescriptionNames(services)
+	for _, want := range []string{OperaRpcService.Name, OperaWsService.Name, OperaDebugService.Name, OperaP2pService.Name} {
+		if !slices.Contains(names, want) {
+			t.Errorf("default service %s is not enabled, got %v", want, names)
+		}
+	}
+	for _, optional := range []string{OperaGraphQlService.Name, OperaTracingService.Name} {
+		if slices.Contains(names, optional) {
+			t.Errorf("optional service %s should not be enabled by default", optional)
+		}
+	}
+}
+
+func TestGetServices_OptionalServicesAreEnabledOnRequest(t *testing.T) {
+	services, err := getServices([]string{GraphQlServiceName})
+	if err != nil {
+		t.Fatalf("failed to get services: %v", err)
+	}
+	names := getDescriptionNames(services)
+	if !slices.Contains(names, OperaGraphQlService.Name) {
+		t.Errorf("requested service is not enabled, got %v", names)
+	}
+	if slices.Contains(names, OperaTracingService.Name) {
+		t.Errorf("service which was not requested is enabled, got %v", names)
+	}
+}
+
+func TestGetServices_UnknownServicesAreRejected(t *testing.T) {
+	if _, err := getServices([]string{"unknown"}); err == nil {
+		t.Errorf("unknown service should be rejected")
+	}
+}
+
+func TestRegisterService_ServicesCanBeAddedWithoutModifyingNodes(t *testing.T) {
+	probe := network.ServiceDescription{Name: "TestProbe", Port: 7070, Protocol: "http"}
+	if err := RegisterService("probe", OperaService{Description: &probe, Flags: []string{"--probe"}, APIs: []string{"probe"}, Optional: true}); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	t.Cleanup(func() {
+		operaServicesMutex.Lock()
+		delete(operaServices, "probe")
+		operaServicesMutex.Unlock()
+	})
+
+	services, err := getServices([]string{"probe"})
+	if err != nil {
+		t.Fatalf("failed to get services: %v", err)
+	}
+	if ports := getPorts(services); !slices.Contains(ports, probe.Port) {
+		t.Errorf("port of the service is not exported, got %v", ports)
+	}
+	environment := getServiceEnvironment(services)
+	if got, want := environment["SERVICE_FLAGS"], "--probe"; got != want {
+		t.Errorf("unexpected flags, wanted %q, got %q", want, got)
+	}
+	if got, want := environment["SERVICE_APIS"], "probe"; got != want {
+		t.Errorf("unexpected APIs, wanted %q, got %q", want, got)
+	}
+}
+
+func TestRegisterService_DuplicatesAreRejected(t *testing.T) {
+	if err := RegisterService("rpc", OperaService{Description: &network.ServiceDescription{Name: "Other"}}); err == nil {
+		t.Errorf("duplicate name should be rejected")
+	}
+	if err := RegisterService("other", OperaService{Description: &OperaRpcService}); err == nil {
+		t.Errorf("duplicate service should be rejected")
+	}
+	if err := RegisterService("other", OperaService{}); err == nil {
+		t.Errorf("service without description should be rejected")
+	}
+}
+
+func TestGetPorts_SharedPortsAreListedOnce(t *testing.T) {
+	services, err := getServices([]string{GraphQlServiceName, TracingServiceName})
+	if err != nil {
+		t.Fatalf("failed to get services: %v", err)
+	}
+	ports := getPorts(services)
+	for _, port := range ports {
+		if count := len(slices.DeleteFunc(slices.Clone(ports), func(p network.Port) bool { return p != port })); count != 1 {
+			t.Errorf("port %d is listed %d times", port, count)
+		}
+	}
+	if len(ports) != 4 {
+		t.Errorf("unexpected ports, got %v", ports)
+	}
+}
+
+func TestOperaNode_GetServiceUrl_DisabledServicesHaveNoUrl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	host := network.NewMockHost(ctrl)
+	address := network.AddressPort("localhost:32768")
+	host.EXPECT().GetAddressForService(&OperaRpcService).Return(&address)
+
+	services, err := getServices(nil)
+	if err != nil {
+		t.Fatalf("failed to get services: %v", err)
+	}
+	node := &OperaNode{host: host, label: "A", services: services}
+	if url := node.GetServiceUrl(&OperaRpcService); url == nil || *url != "http://localhost:32768" {
+		t.Errorf("unexpected URL of enabled service, got %v", url)
+	}
+	if url := node.GetServiceUrl(&OperaGraphQlService); url != nil {
+		t.Errorf("disabled service should have no URL, got %v", *url)
+	}
+}
+
+func TestOperaNode_GetServiceUrl_UrlIncludesPathOfService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	host := network.NewMockHost(ctrl)
+	address := network.AddressPort("localhost:32768")
+	host.EXPECT().GetAddressForService(&OperaGraphQlService).Return(&address)
+
+	services, err := getServices([]string{GraphQlServiceName})
+	if err != nil {
+		t.Fatalf("failed to get services: %v", err)
+	}
+	node := &OperaNode{host: host, label: "A", services: services}
+	if url := node.GetServiceUrl(&OperaGraphQlService); url == nil || *url != "http://localhost:32768/graphql" {
+		t.Errorf("unexpected URL of GraphQL service, got %v", url)
+	}
+}
+
+func getDescriptionNames(services []*OperaService) []string {
+	res := []string{}
+	for _, service := range services {
+		res = append(res, service.Description.Name)
+	}
+	return res
+}
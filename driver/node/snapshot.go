@@ -0,0 +1,71 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/docker"
+)
+
+// ExportGenesis exports the state of the node owning the given datadir into
+// a genesis file, which can be used as the initial state of later runs. The
+// datadir is expected to be kept from a finished run, see the DatadirDirectory
+// of the network configuration. The export is run by the client's tools in a
+// dedicated container.
+func ExportGenesis(ctx context.Context, client *docker.Client, datadir, file string) error {
+	datadir, err := filepath.Abs(datadir)
+	if err != nil {
+		return fmt.Errorf("invalid datadir; %v", err)
+	}
+	if info, err := os.Stat(datadir); err != nil || !info.IsDir() {
+		return fmt.Errorf("datadir %s does not exist", datadir)
+	}
+	file, err = filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("invalid genesis file; %v", err)
+	}
+	outputDir, name := filepath.Split(file)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory; %v", err)
+	}
+
+	const snapshotDir = "/snapshot"
+	shutdownTimeout := 10 * time.Second
+	container, err := client.Start(ctx, &docker.ContainerConfig{
+		ImageName:       operaDockerImageName,
+		ShutdownTimeout: &shutdownTimeout,
+		Entrypoint:      []string{"./sonictool", "--datadir", "/datadir", "genesis", "export", filepath.Join(snapshotDir, name)},
+		MountDatadir:    &datadir,
+		Mounts:          []docker.Mount{{Source: outputDir, Target: snapshotDir}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start export; %v", err)
+	}
+
+	code, err := container.Wait(ctx)
+	if err == nil && code != 0 {
+		err = fmt.Errorf("export failed with exit code %d, see the log in %s", code, outputDir)
+		err = errors.Join(err, container.SaveLogTo(ctx, outputDir))
+	}
+	return errors.Join(err, container.Cleanup(context.WithoutCancel(ctx)))
+}
@@ -26,8 +26,10 @@
 package driver
 
 import (
+	context "context"
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	network "github.com/Fantom-foundation/Norma/driver/network"
 	rpc "github.com/Fantom-foundation/Norma/driver/rpc"
@@ -58,17 +60,17 @@ func (m *MockNode) EXPECT() *MockNodeMockRecorder {
 }
 
 // Cleanup mocks base method.
-func (m *MockNode) Cleanup() error {
+func (m *MockNode) Cleanup(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Cleanup")
+	ret := m.ctrl.Call(m, "Cleanup", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Cleanup indicates an expected call of Cleanup.
-func (mr *MockNodeMockRecorder) Cleanup() *gomock.Call {
+func (mr *MockNodeMockRecorder) Cleanup(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cleanup", reflect.TypeOf((*MockNode)(nil).Cleanup))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cleanup", reflect.TypeOf((*MockNode)(nil).Cleanup), ctx)
 }
 
 // DialRpc mocks base method.
@@ -86,6 +88,21 @@ func (mr *MockNodeMockRecorder) DialRpc() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DialRpc", reflect.TypeOf((*MockNode)(nil).DialRpc))
 }
 
+// GetExitCode mocks base method.
+func (m *MockNode) GetExitCode(ctx context.Context) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExitCode", ctx)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExitCode indicates an expected call of GetExitCode.
+func (mr *MockNodeMockRecorder) GetExitCode(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExitCode", reflect.TypeOf((*MockNode)(nil).GetExitCode), ctx)
+}
+
 // GetLabel mocks base method.
 func (m *MockNode) GetLabel() string {
 	m.ctrl.T.Helper()
@@ -101,18 +118,33 @@ func (mr *MockNodeMockRecorder) GetLabel() *gomock.Call {
 }
 
 // GetNodeID mocks base method.
-func (m *MockNode) GetNodeID() (NodeID, error) {
+func (m *MockNode) GetNodeID(ctx context.Context) (NodeID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNodeID")
+	ret := m.ctrl.Call(m, "GetNodeID", ctx)
 	ret0, _ := ret[0].(NodeID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNodeID indicates an expected call of GetNodeID.
-func (mr *MockNodeMockRecorder) GetNodeID() *gomock.Call {
+func (mr *MockNodeMockRecorder) GetNodeID(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeID", reflect.TypeOf((*MockNode)(nil).GetNodeID))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeID", reflect.TypeOf((*MockNode)(nil).GetNodeID), ctx)
+}
+
+// GetResourceLimits mocks base method.
+func (m *MockNode) GetResourceLimits(ctx context.Context) (network.ResourceLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceLimits", ctx)
+	ret0, _ := ret[0].(network.ResourceLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceLimits indicates an expected call of GetResourceLimits.
+func (mr *MockNodeMockRecorder) GetResourceLimits(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceLimits", reflect.TypeOf((*MockNode)(nil).GetResourceLimits), ctx)
 }
 
 // GetServiceUrl mocks base method.
@@ -129,6 +161,48 @@ func (mr *MockNodeMockRecorder) GetServiceUrl(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceUrl", reflect.TypeOf((*MockNode)(nil).GetServiceUrl), arg0)
 }
 
+// GetStartTime mocks base method.
+func (m *MockNode) GetStartTime() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStartTime")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetStartTime indicates an expected call of GetStartTime.
+func (mr *MockNodeMockRecorder) GetStartTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartTime", reflect.TypeOf((*MockNode)(nil).GetStartTime))
+}
+
+// GetType mocks base method.
+func (m *MockNode) GetType() NodeType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetType")
+	ret0, _ := ret[0].(NodeType)
+	return ret0
+}
+
+// GetType indicates an expected call of GetType.
+func (mr *MockNodeMockRecorder) GetType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetType", reflect.TypeOf((*MockNode)(nil).GetType))
+}
+
+// GetValidatorId mocks base method.
+func (m *MockNode) GetValidatorId() *int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorId")
+	ret0, _ := ret[0].(*int)
+	return ret0
+}
+
+// GetValidatorId indicates an expected call of GetValidatorId.
+func (mr *MockNodeMockRecorder) GetValidatorId() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorId", reflect.TypeOf((*MockNode)(nil).GetValidatorId))
+}
+
 // Hostname mocks base method.
 func (m *MockNode) Hostname() string {
 	m.ctrl.T.Helper()
@@ -158,17 +232,17 @@ func (mr *MockNodeMockRecorder) IsRunning() *gomock.Call {
 }
 
 // Kill mocks base method.
-func (m *MockNode) Kill() error {
+func (m *MockNode) Kill(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Kill")
+	ret := m.ctrl.Call(m, "Kill", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Kill indicates an expected call of Kill.
-func (mr *MockNodeMockRecorder) Kill() *gomock.Call {
+func (mr *MockNodeMockRecorder) Kill(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockNode)(nil).Kill))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockNode)(nil).Kill), ctx)
 }
 
 // MetricsPort mocks base method.
@@ -186,30 +260,30 @@ func (mr *MockNodeMockRecorder) MetricsPort() *gomock.Call {
 }
 
 // Stop mocks base method.
-func (m *MockNode) Stop() error {
+func (m *MockNode) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Stop")
+	ret := m.ctrl.Call(m, "Stop", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Stop indicates an expected call of Stop.
-func (mr *MockNodeMockRecorder) Stop() *gomock.Call {
+func (mr *MockNodeMockRecorder) Stop(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockNode)(nil).Stop))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockNode)(nil).Stop), ctx)
 }
 
 // StreamLog mocks base method.
-func (m *MockNode) StreamLog() (io.ReadCloser, error) {
+func (m *MockNode) StreamLog(ctx context.Context) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StreamLog")
+	ret := m.ctrl.Call(m, "StreamLog", ctx)
 	ret0, _ := ret[0].(io.ReadCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StreamLog indicates an expected call of StreamLog.
-func (mr *MockNodeMockRecorder) StreamLog() *gomock.Call {
+func (mr *MockNodeMockRecorder) StreamLog(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLog", reflect.TypeOf((*MockNode)(nil).StreamLog))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLog", reflect.TypeOf((*MockNode)(nil).StreamLog), ctx)
 }
@@ -29,14 +29,34 @@ var purgeCommand = cli.Command{
 	Action: purge,
 	Name:   "purge",
 	Usage:  "purges all resources created by norma",
+	Flags: []cli.Flag{
+		&dockerEndpoints,
+	},
 }
 
-func purge(_ *cli.Context) error {
-	fmt.Printf("Purging all resources...\n")
-	err := docker.Purge()
+func purge(ctx *cli.Context) error {
+	endpoints, err := parseDockerEndpoints(ctx.StringSlice(dockerEndpoints.Name))
 	if err != nil {
 		return err
 	}
+	if len(endpoints) == 0 {
+		fmt.Printf("Purging all resources...\n")
+		if err := docker.Purge(ctx.Context); err != nil {
+			return err
+		}
+	}
+	for _, endpoint := range endpoints {
+		fmt.Printf("Purging all resources on %s...\n", endpoint.Label)
+		client, err := docker.NewClientForHost(endpoint.Host, endpoint.Address, endpoint.BindAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create docker client for endpoint %s; %v", endpoint.Label, err)
+		}
+		err = client.Purge(ctx.Context)
+		client.Close()
+		if err != nil {
+			return err
+		}
+	}
 	fmt.Printf("Done.\n")
 	return nil
 }
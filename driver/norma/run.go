@@ -17,11 +17,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/analysis/report"
@@ -49,6 +52,10 @@ var runCommand = cli.Command{
 		&skipChecks,
 		&skipReportRendering,
 		&outputDirectory,
+		&stallTimeout,
+		&failOnIncident,
+		&keepDatadirs,
+		&dockerEndpoints,
 	},
 }
 
@@ -81,8 +88,49 @@ var (
 		Name:  "skip-report-rendering",
 		Usage: "disables the rendering of the final summary report",
 	}
+	stallTimeout = cli.DurationFlag{
+		Name:  "stall-timeout",
+		Usage: "time a node may go without a new block before it is reported as stalled, 0 disables the check.",
+		Value: 60 * time.Second,
+	}
+	keepDatadirs = cli.BoolFlag{
+		Name:  "keep-datadirs",
+		Usage: "if set, the datadirs of all nodes are kept in the output directory, e.g. to export their state using `norma snapshot`.",
+	}
+	failOnIncident = cli.BoolFlag{
+		Name:  "fail-fast",
+		Usage: "if set, the run is aborted as soon as a node is detected to have crashed or stalled.",
+	}
+	dockerEndpoints = cli.StringSliceFlag{
+		Name: "docker-endpoint",
+		Usage: "adds a Docker host running a part of the nodes, as <label>=<host URL>[,<address>[,<bind address>]], e.g. remote=tcp://10.0.0.2:2375. " +
+			"Nodes are placed by the endpoint label of the scenario or round-robin. The address reaching ports forwarded by the host " +
+			"defaults to the host of the URL and has to be set for local hosts used together with remote ones. Forwarded ports are " +
+			"bound to the interface with the bind address, which defaults to the address and has to be set if it is not an IP.",
+	}
 )
 
+// parseDockerEndpoints parses the endpoints given through the docker-endpoint
+// flag in the format <label>=<host URL>[,<address>[,<bind address>]].
+func parseDockerEndpoints(specs []string) ([]driver.DockerEndpoint, error) {
+	res := make([]driver.DockerEndpoint, 0, len(specs))
+	for _, spec := range specs {
+		label, host, found := strings.Cut(spec, "=")
+		if !found || label == "" || host == "" {
+			return nil, fmt.Errorf("invalid docker endpoint %q, expected <label>=<host URL>[,<address>[,<bind address>]]", spec)
+		}
+		host, address, _ := strings.Cut(host, ",")
+		address, bindAddress, _ := strings.Cut(address, ",")
+		res = append(res, driver.DockerEndpoint{
+			Label:       label,
+			Host:        host,
+			Address:     address,
+			BindAddress: bindAddress,
+		})
+	}
+	return res, nil
+}
+
 func run(ctx *cli.Context) (err error) {
 	args := ctx.Args()
 	if args.Len() < 1 {
@@ -93,9 +141,22 @@ func run(ctx *cli.Context) (err error) {
 	keepPrometheusRunning := ctx.Bool(keepPrometheusRunning.Name)
 	skipChecks := ctx.Bool(skipChecks.Name)
 	skipReportRendering := ctx.Bool(skipReportRendering.Name)
+	keepDatadirs := ctx.Bool(keepDatadirs.Name)
+	watchdogConfig := watchdogConfig{
+		stallTimeout: ctx.Duration(stallTimeout.Name),
+		failFast:     ctx.Bool(failOnIncident.Name),
+	}
+	endpoints, err := parseDockerEndpoints(ctx.StringSlice(dockerEndpoints.Name))
+	if err != nil {
+		return err
+	}
 
 	path := args.First()
 
+	// A Ctrl+C aborts the network operations in progress and the scenario run.
+	runCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt)
+	defer stop()
+
 	// Check if the path is a directory
 	fileInfo, err := os.Stat(path)
 	if err != nil {
@@ -111,7 +172,7 @@ func run(ctx *cli.Context) (err error) {
 			if !d.IsDir() && (filepath.Ext(d.Name()) == ".yaml" || filepath.Ext(d.Name()) == ".yml") {
 				// Call runScenario for each YAML file
 				label := fmt.Sprintf("eval_%d", time.Now().Unix())
-				if err := runScenario(p, outputDir, label, keepPrometheusRunning, skipChecks, skipReportRendering); err != nil {
+				if err := runScenario(runCtx, p, outputDir, label, keepPrometheusRunning, skipChecks, skipReportRendering, keepDatadirs, watchdogConfig, endpoints); err != nil {
 					return fmt.Errorf("failed to run: %s: %w", p, err)
 				}
 			}
@@ -124,11 +185,18 @@ func run(ctx *cli.Context) (err error) {
 			label = fmt.Sprintf("eval_%d", time.Now().Unix())
 		}
 
-		return runScenario(path, outputDir, label, keepPrometheusRunning, skipChecks, skipReportRendering)
+		return runScenario(runCtx, path, outputDir, label, keepPrometheusRunning, skipChecks, skipReportRendering, keepDatadirs, watchdogConfig, endpoints)
 	}
 }
 
-func runScenario(path, outputDir, label string, keepPrometheusRunning, skipChecks, skipReportRendering bool) error {
+// watchdogConfig summarizes the command line options controlling the
+// detection of crashed and stalled nodes.
+type watchdogConfig struct {
+	stallTimeout time.Duration
+	failFast     bool
+}
+
+func runScenario(ctx context.Context, path, outputDir, label string, keepPrometheusRunning, skipChecks, skipReportRendering, keepDatadirs bool, watchdogConfig watchdogConfig, endpoints []driver.DockerEndpoint) error {
 
 	// if not configured, default to /tmp/norma_data_<label>_<timestamp> else /configured/path/norma_data_<l>_<t>
 	outputDir, err := os.MkdirTemp(outputDir, fmt.Sprintf("norma_data_%s_", label))
@@ -174,12 +242,38 @@ func runScenario(path, outputDir, label string, keepPrometheusRunning, skipCheck
 	fmt.Printf("    Network max block gas: %d\n", scenario.GetMaxBlockGas())
 	fmt.Printf("    Network max epoch gas: %d\n", scenario.GetMaxEpochGas())
 	fmt.Printf("    Network RoundTripTime: %v\n", scenario.GetRoundTripTime())
+	fmt.Printf("    Network topology: %v\n", scenario.GetTopology().Type)
+	for _, endpoint := range endpoints {
+		fmt.Printf("    Docker endpoint %s: %s\n", endpoint.Label, endpoint.Host)
+	}
+	if genesis := scenario.GetGenesis(); len(genesis.Accounts) > 0 {
+		fmt.Printf("    Genesis accounts: %d\n", len(genesis.Accounts))
+	}
 
-	net, err := local.NewLocalNetwork(&driver.NetworkConfig{
+	datadirDirectory := ""
+	if keepDatadirs {
+		datadirDirectory = filepath.Join(outputDir, "datadirs")
+		fmt.Printf("    Node datadirs are kept in: %s\n", datadirDirectory)
+	}
+	if state := scenario.InitialState; state != nil {
+		if state.Genesis != nil {
+			fmt.Printf("    Initial state genesis: %s\n", *state.Genesis)
+		} else if state.Datadir != nil {
+			fmt.Printf("    Initial state datadir: %s\n", *state.Datadir)
+		}
+	}
+
+	net, err := local.NewLocalNetwork(ctx, &driver.NetworkConfig{
 		NumberOfValidators: scenario.GetNumValidators(),
 		MaxBlockGas:        scenario.GetMaxBlockGas(),
 		MaxEpochGas:        scenario.GetMaxEpochGas(),
 		RoundTripTime:      scenario.GetRoundTripTime(),
+		Topology:           scenario.GetTopology(),
+		Routing:            scenario.GetRouting(),
+		Genesis:            scenario.GetGenesis(),
+		InitialState:       scenario.GetInitialState(),
+		DatadirDirectory:   datadirDirectory,
+		Endpoints:          endpoints,
 	})
 	if err != nil {
 		return err
@@ -227,28 +321,62 @@ func runScenario(path, outputDir, label string, keepPrometheusRunning, skipCheck
 
 	// Run prometheus.
 	fmt.Printf("Starting Prometheus ...\n")
-	prom, err := prometheusmon.Start(net, net.GetDockerNetwork())
+	prom, err := prometheusmon.Start(ctx, net, net.GetDockerNetwork())
 	if err != nil {
 		fmt.Printf("error starting Prometheus:\n%v", err)
 	}
 	defer func() {
 		if !keepPrometheusRunning && prom != nil {
 			fmt.Printf("Shutting down Prometheus ...\n")
-			if err := prom.Shutdown(); err != nil {
+			if err := prom.Shutdown(context.WithoutCancel(ctx)); err != nil {
 				fmt.Printf("error during Prometheus shutdown:\n%v", err)
 			}
 		}
 	}()
 
+	// Watch for crashed and stalled nodes.
+	watchdog := monitoring.NewWatchdog(net, monitor.NodeLogProvider(), monitoring.WatchdogConfig{
+		StallTimeout: watchdogConfig.stallTimeout,
+	})
+	defer func() {
+		watchdog.Shutdown()
+		if events := watchdog.GetEvents(); len(events) > 0 {
+			fmt.Printf("Watchdog detected %d incident(s):\n", len(events))
+			for _, event := range events {
+				fmt.Printf("    %v\n", event)
+			}
+		}
+	}()
+	var incidents <-chan error
+	if watchdogConfig.failFast {
+		incidents = watchdog.Incidents()
+	}
+
 	// Run scenario.
 	fmt.Printf("Running '%s' ...\n", path)
 	logger := startProgressLogger(monitor, net)
 	defer logger.shutdown()
-	err = executor.Run(clock, net, &scenario, skipChecks)
+	err = executor.Run(ctx, clock, net, &scenario, skipChecks, incidents)
 	if err != nil {
 		return err
 	}
 	fmt.Printf("Execution completed successfully!\n")
 
+	if validators, err := net.GetValidators(ctx); err != nil {
+		fmt.Printf("failed to obtain validators:\n%v\n", err)
+	} else {
+		fmt.Printf("Validators at the end of the run:\n")
+		for _, validator := range validators {
+			status := "inactive"
+			if validator.Active {
+				status = "active"
+			}
+			fmt.Printf("    %d: %s, nodes %v\n", validator.ID, status, validator.Nodes)
+		}
+	}
+	if datadirDirectory != "" {
+		fmt.Printf("To export the state of a node, run `norma snapshot %s/<node> <genesis-file>`\n", datadirDirectory)
+	}
+
 	return nil
 }
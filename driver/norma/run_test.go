@@ -0,0 +1,51 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+func TestParseDockerEndpoints_ValidEndpointsAreParsed(t *testing.T) {
+	got, err := parseDockerEndpoints([]string{
+		"local=unix:///var/run/docker.sock,10.0.0.1",
+		"remote=tcp://10.0.0.2:2375",
+		"named=tcp://docker-1.example:2375,docker-1.example,10.0.0.3",
+	})
+	if err != nil {
+		t.Fatalf("failed to parse endpoints: %v", err)
+	}
+	want := []driver.DockerEndpoint{
+		{Label: "local", Host: "unix:///var/run/docker.sock", Address: "10.0.0.1"},
+		{Label: "remote", Host: "tcp://10.0.0.2:2375"},
+		{Label: "named", Host: "tcp://docker-1.example:2375", Address: "docker-1.example", BindAddress: "10.0.0.3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected endpoints, wanted %v, got %v", want, got)
+	}
+}
+
+func TestParseDockerEndpoints_InvalidEndpointsAreReported(t *testing.T) {
+	for _, spec := range []string{"", "remote", "=tcp://10.0.0.2:2375", "remote="} {
+		if _, err := parseDockerEndpoints([]string{spec}); err == nil {
+			t.Errorf("invalid endpoint %q should be reported", spec)
+		}
+	}
+}
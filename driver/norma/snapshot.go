@@ -0,0 +1,56 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/Fantom-foundation/Norma/driver/docker"
+	"github.com/Fantom-foundation/Norma/driver/node"
+	"github.com/urfave/cli/v2"
+)
+
+// Run with `go run ./driver/norma snapshot <datadir> <genesis-file>`
+
+var snapshotCommand = cli.Command{
+	Action:    snapshot,
+	Name:      "snapshot",
+	Usage:     "exports the state of a node kept from a finished run (see run --keep-datadirs) into a genesis file",
+	ArgsUsage: "<datadir> <genesis-file>",
+}
+
+func snapshot(ctx *cli.Context) error {
+	args := ctx.Args()
+	if args.Len() != 2 {
+		return fmt.Errorf("requires the datadir of a node and the genesis file to create as arguments")
+	}
+	datadir, file := args.Get(0), args.Get(1)
+
+	client, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("failed to create docker client; %v", err)
+	}
+	defer client.Close()
+
+	fmt.Printf("Exporting state of %s ...\n", datadir)
+	if err := node.ExportGenesis(ctx.Context, client, datadir, file); err != nil {
+		return err
+	}
+	fmt.Printf("Snapshot was exported to %s\n", file)
+	fmt.Printf("Use it as `initial_state: {genesis: %s}` in scenarios\n", file)
+	return nil
+}
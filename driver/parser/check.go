@@ -19,10 +19,13 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
 	"github.com/Fantom-foundation/Norma/load/app"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const namePatternStr = "^[A-Za-z0-9-]+$"
@@ -44,6 +47,36 @@ func (s *Scenario) Check() error {
 	if s.RoundTripTime != nil && *s.RoundTripTime < 0 {
 		errs = append(errs, fmt.Errorf("round trip time must be >= 0, is %v", *s.RoundTripTime))
 	}
+	if s.Topology != nil {
+		if err := s.Topology.Check(s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Genesis != nil {
+		if err := s.Genesis.Check(s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.InitialState != nil {
+		if err := s.InitialState.Check(s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Routing != nil {
+		if err := s.Routing.Check(s); err != nil {
+			errs = append(errs, fmt.Errorf("invalid routing; %w", err))
+		}
+		for _, t := range s.Routing.Types {
+			if t == "observer" {
+				errs = append(errs, fmt.Errorf("invalid routing; observers do not accept transactions"))
+			}
+		}
+	}
+	if s.Checks != nil {
+		if err := s.Checks.Check(s); err != nil {
+			errs = append(errs, fmt.Errorf("invalid checks; %w", err))
+		}
+	}
 
 	names := map[string]bool{}
 	for _, node := range s.Nodes {
@@ -78,6 +111,17 @@ func (s *Scenario) Check() error {
 			names[cheat.Name] = true
 		}
 	}
+	names = map[string]bool{}
+	for _, update := range s.RuleUpdates {
+		if err := update.Check(s); err != nil {
+			errs = append(errs, err)
+		}
+		if _, exists := names[update.Name]; exists {
+			errs = append(errs, fmt.Errorf("rule update names must be unique, %s encountered multiple times", update.Name))
+		} else {
+			names[update.Name] = true
+		}
+	}
 
 	return errors.Join(errs...)
 }
@@ -92,7 +136,7 @@ func (n *Node) Check(scenario *Scenario) error {
 		errs = append(errs, fmt.Errorf("number of instances must be >= 0, is %d", *n.Instances))
 	}
 	if n.Client.Type == "" {
-		n.Client.Type = "observer"
+		n.Client.Type = "rpc"
 	}
 
 	if err := checkTimeInterval(n.Start, n.End, scenario.Duration); err != nil {
@@ -103,6 +147,43 @@ func (n *Node) Check(scenario *Scenario) error {
 		errs = append(errs, err)
 	}
 
+	if n.Resources != nil {
+		if err := n.Resources.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if bootstrap := n.GetBootstrap(); bootstrap != FullSyncBootstrap && bootstrap != SnapshotSyncBootstrap {
+		errs = append(errs, fmt.Errorf("bootstrap of node must be %s or %s, was set to %s", FullSyncBootstrap, SnapshotSyncBootstrap, bootstrap))
+	}
+
+	if n.Endpoint != nil && strings.TrimSpace(*n.Endpoint) == "" {
+		errs = append(errs, fmt.Errorf("endpoint of node must not be empty"))
+	}
+
+	for _, service := range n.Services {
+		if strings.TrimSpace(service) == "" {
+			errs = append(errs, fmt.Errorf("services of node must not be empty"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the resource limits of a node.
+func (r *Resources) Check() error {
+	errs := []error{}
+	if r.Cpus != nil && *r.Cpus <= 0 {
+		errs = append(errs, fmt.Errorf("number of CPUs must be > 0, is %v", *r.Cpus))
+	}
+	if memory, err := r.GetMemory(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid memory limit; %v", err))
+	} else if r.Memory != nil && memory <= 0 {
+		errs = append(errs, fmt.Errorf("memory limit must be > 0, is %v", *r.Memory))
+	}
+	if r.Pids != nil && *r.Pids <= 0 {
+		errs = append(errs, fmt.Errorf("pids limit must be > 0, is %d", *r.Pids))
+	}
 	return errors.Join(errs...)
 }
 
@@ -122,6 +203,183 @@ func isTypeValid(t string) error {
 	return fmt.Errorf("type of node must be observer, rpc or validator, was set to %s", t)
 }
 
+// Check tests semantic constraints on the genesis configuration of a scenario.
+func (g *Genesis) Check(scenario *Scenario) error {
+	errs := []error{}
+	if got, limit := len(g.Validators), scenario.GetNumValidators(); got > limit {
+		errs = append(errs, fmt.Errorf("genesis lists %d validators, but the network has only %d", got, limit))
+	}
+	for i, validator := range g.Validators {
+		if validator.GetStake() == 0 {
+			errs = append(errs, fmt.Errorf("stake of genesis validator %d must be > 0", i+1))
+		}
+	}
+
+	addresses := map[common.Address]bool{}
+	for _, account := range g.Accounts {
+		if !common.IsHexAddress(account.Address) {
+			errs = append(errs, fmt.Errorf("invalid genesis account address: %v", account.Address))
+			continue
+		}
+		address := common.HexToAddress(account.Address)
+		if addresses[address] {
+			errs = append(errs, fmt.Errorf("genesis account %v is listed multiple times", account.Address))
+		}
+		addresses[address] = true
+		if account.Code != nil && account.CodeFile != nil {
+			errs = append(errs, fmt.Errorf("genesis account %v must not define both code and code_file", account.Address))
+		}
+		if account.Code != nil {
+			if _, err := hexutil.Decode(*account.Code); err != nil {
+				errs = append(errs, fmt.Errorf("invalid code of genesis account %v; %v", account.Address, err))
+			}
+		}
+		for key, value := range account.Storage {
+			for _, word := range []string{key, value} {
+				if data, err := hexutil.Decode(word); err != nil || len(data) > common.HashLength {
+					errs = append(errs, fmt.Errorf("invalid storage of genesis account %v: %v", account.Address, word))
+				}
+			}
+		}
+	}
+
+	upgrades := map[string]bool{}
+	for _, name := range g.Upgrades {
+		switch name {
+		case "berlin", "london", "llr", "sonic":
+		default:
+			errs = append(errs, fmt.Errorf("unknown network upgrade: %v", name))
+		}
+		if upgrades[name] {
+			errs = append(errs, fmt.Errorf("network upgrade %v is listed multiple times", name))
+		}
+		upgrades[name] = true
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the initial state of a scenario.
+func (i *InitialState) Check(scenario *Scenario) error {
+	errs := []error{}
+	if scenario.Genesis != nil {
+		errs = append(errs, fmt.Errorf("initial state and genesis must not be defined both"))
+	}
+	if (i.Genesis == nil) == (i.Datadir == nil) {
+		errs = append(errs, fmt.Errorf("initial state must specify exactly one of genesis and datadir"))
+	}
+	if i.Genesis != nil {
+		if info, err := os.Stat(*i.Genesis); err != nil {
+			errs = append(errs, fmt.Errorf("invalid initial state genesis; %v", err))
+		} else if info.IsDir() {
+			errs = append(errs, fmt.Errorf("initial state genesis %s must be a file", *i.Genesis))
+		}
+	}
+	if i.Datadir != nil {
+		if info, err := os.Stat(*i.Datadir); err != nil {
+			errs = append(errs, fmt.Errorf("invalid initial state datadir; %v", err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("initial state datadir %s must be a directory", *i.Datadir))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on a selection of nodes. At least one
+// node of the scenario has to be of a selected type.
+func (n *NodeSelection) Check(scenario *Scenario) error {
+	errs := []error{}
+	covered := false
+	for _, t := range n.Types {
+		if err := isTypeValid(t); err != nil {
+			errs = append(errs, err)
+		}
+		// Genesis validators are always present.
+		if t == "validator" {
+			covered = true
+		}
+		for _, node := range scenario.Nodes {
+			if node.Client.Type == t || (node.Client.Type == "" && t == "rpc") {
+				covered = true
+			}
+		}
+	}
+	if len(n.Types) > 0 && !covered {
+		errs = append(errs, fmt.Errorf("no node of type %v in scenario", strings.Join(n.Types, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the peer topology of a scenario.
+func (t *Topology) Check(scenario *Scenario) error {
+	errs := []error{}
+
+	switch t.Type {
+	case "", FullMeshTopology, RingTopology, RandomTopology, StarTopology, ExplicitTopology:
+	default:
+		errs = append(errs, fmt.Errorf("unknown topology type: %v", t.Type))
+	}
+
+	if t.Degree != nil && t.Type != RandomTopology {
+		errs = append(errs, fmt.Errorf("degree can only be set for random topologies"))
+	}
+	if t.Degree != nil && *t.Degree < 1 {
+		errs = append(errs, fmt.Errorf("topology degree must be >= 1, is %d", *t.Degree))
+	}
+
+	if len(t.Hubs) > 0 && t.Type != StarTopology {
+		errs = append(errs, fmt.Errorf("hubs can only be set for star topologies"))
+	}
+	for _, hub := range t.Hubs {
+		if !scenario.isKnownNodeReference(hub) {
+			errs = append(errs, fmt.Errorf("unknown node in topology hubs: %v", hub))
+		}
+	}
+
+	if len(t.Peers) > 0 && t.Type != ExplicitTopology {
+		errs = append(errs, fmt.Errorf("peers can only be set for explicit topologies"))
+	}
+	if len(t.Peers) == 0 && t.Type == ExplicitTopology {
+		errs = append(errs, fmt.Errorf("explicit topology must list peers"))
+	}
+	for node, peers := range t.Peers {
+		if !scenario.isKnownNodeReference(node) {
+			errs = append(errs, fmt.Errorf("unknown node in topology peers: %v", node))
+		}
+		for _, peer := range peers {
+			if !scenario.isKnownNodeReference(peer) {
+				errs = append(errs, fmt.Errorf("unknown node in topology peers of %v: %v", node, peer))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isKnownNodeReference checks whether the given reference names a node group,
+// an instance of a node group, or a genesis validator of the scenario.
+func (s *Scenario) isKnownNodeReference(ref string) bool {
+	for i := 1; i <= s.GetNumValidators(); i++ {
+		if ref == fmt.Sprintf("_validator-%d", i) {
+			return true
+		}
+	}
+	for _, node := range s.Nodes {
+		if ref == node.Name {
+			return true
+		}
+		instances := DefaultInstance
+		if node.Instances != nil {
+			instances = *node.Instances
+		}
+		for i := 0; i < instances; i++ {
+			if ref == fmt.Sprintf("%s-%d", node.Name, i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Check tests semantic constraints on the application configuration of a scenario.
 func (a *Application) Check(scenario *Scenario) error {
 	errs := []error{}
@@ -130,12 +388,29 @@ func (a *Application) Check(scenario *Scenario) error {
 		errs = append(errs, fmt.Errorf("application name must match %v, got %v", namePatternStr, a.Name))
 	}
 
-	if a.Type == "" {
+	if len(a.Mix) > 0 {
+		if a.Type != "" && strings.ToLower(a.Type) != app.MixApplicationType {
+			errs = append(errs, fmt.Errorf("application with mix must be of type %s, got %v", app.MixApplicationType, a.Type))
+		}
+		if err := checkMix(a.Mix); err != nil {
+			errs = append(errs, err)
+		}
+	} else if a.Type == "" {
 		errs = append(errs, fmt.Errorf("application type must be specified"))
+	} else if strings.ToLower(a.Type) == app.MixApplicationType {
+		errs = append(errs, fmt.Errorf("application of type %s must define a mix", app.MixApplicationType))
 	} else if !app.IsSupportedApplicationType(a.Type) {
 		errs = append(errs, fmt.Errorf("unknown application type: %v", a.Type))
 	}
 
+	if err := a.checkConfigs(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if _, err := app.ParseTxType(a.TxType); err != nil {
+		errs = append(errs, err)
+	}
+
 	if a.Instances != nil && *a.Instances < 0 {
 		errs = append(errs, fmt.Errorf("number of instances must be >= 0, is %d", *a.Instances))
 	}
@@ -155,6 +430,71 @@ func (a *Application) Check(scenario *Scenario) error {
 	return errors.Join(errs...)
 }
 
+// requiredConfigs describes the options application types can not be created
+// without.
+var requiredConfigs = map[string]string{
+	app.ContractApplicationType: "a contract",
+	app.ReplayApplicationType:   "a file of recorded transactions",
+}
+
+// checkConfigs tests that options of application types are only defined for
+// applications using the type, that required options are defined, and that
+// applications can be created as configured.
+func (a *Application) checkConfigs() error {
+	errs := []error{}
+	defined := map[string]bool{}
+	for _, config := range a.GetConfigs() {
+		defined[config.Type()] = true
+		if !a.usesType(config.Type()) {
+			errs = append(errs, fmt.Errorf("%s may only be defined for applications of type %s", config.Type(), config.Type()))
+			continue
+		}
+		if err := config.Check(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s; %w", config.Type(), err))
+		}
+	}
+	for applicationType, description := range requiredConfigs {
+		if a.usesType(applicationType) && !defined[applicationType] {
+			errs = append(errs, fmt.Errorf("application of type %s must define %s", applicationType, description))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// usesType tests whether the application is of the given type, or mixes it
+// as one of its components.
+func (a *Application) usesType(applicationType string) bool {
+	if strings.ToLower(a.Type) == applicationType {
+		return true
+	}
+	for _, component := range a.Mix {
+		if strings.ToLower(component.Type) == applicationType {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMix tests semantic constraints on the components of a mix application.
+func checkMix(mix []MixComponent) error {
+	errs := []error{}
+	seen := map[string]bool{}
+	for _, component := range mix {
+		componentType := strings.ToLower(component.Type)
+		if componentType == "" || componentType == app.MixApplicationType || !app.IsSupportedApplicationType(componentType) {
+			errs = append(errs, fmt.Errorf("invalid type of mix component: %v", component.Type))
+		}
+		if seen[componentType] {
+			errs = append(errs, fmt.Errorf("type of mix component used more than once: %v", component.Type))
+		}
+		seen[componentType] = true
+		if component.Weight <= 0 {
+			errs = append(errs, fmt.Errorf("weight of mix component %v must be > 0, is %v", component.Type, component.Weight))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Check tests semantic constraints on the cheat configuration of a scenario.
 func (c *Cheat) Check(scenario *Scenario) error {
 	errs := []error{}
@@ -170,6 +510,32 @@ func (c *Cheat) Check(scenario *Scenario) error {
 	return errors.Join(errs...)
 }
 
+// Check tests semantic constraints on the configuration of a rules update.
+func (u *RulesUpdate) Check(scenario *Scenario) error {
+	errs := []error{}
+
+	if !namePattern.Match([]byte(u.Name)) {
+		errs = append(errs, fmt.Errorf("rule update name must match %v, got %v", namePatternStr, u.Name))
+	}
+
+	if (u.Time == nil) == (u.Epoch == nil) {
+		errs = append(errs, fmt.Errorf("rule update %s must specify exactly one of time and epoch", u.Name))
+	}
+	if u.Time != nil {
+		if err := checkTimeInterval(u.Time, nil, scenario.Duration); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if u.Epoch != nil && *u.Epoch == 0 {
+		errs = append(errs, fmt.Errorf("rule update %s must be triggered by an epoch > 0", u.Name))
+	}
+	if len(u.Rules) == 0 {
+		errs = append(errs, fmt.Errorf("rule update %s must modify at least one rule", u.Name))
+	}
+
+	return errors.Join(errs...)
+}
+
 // Check tests semantic constraints on the traffic shape configuration of a source.
 func (r *Rate) Check(scenario *Scenario) error {
 	count := 0
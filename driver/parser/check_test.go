@@ -18,9 +18,13 @@ package parser
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/Fantom-foundation/Norma/load/contracts/abi"
 )
 
 func TestTimeRange_UnconstraintInputIsAccepted(t *testing.T) {
@@ -263,6 +267,217 @@ func TestApplication_InvalidApplicationTypeIsDetected(t *testing.T) {
 	}
 }
 
+func TestApplication_InvalidTxTypeIsDetected(t *testing.T) {
+	scenario := Scenario{}
+	for _, txType := range []string{"", "legacy", "access_list", "dynamic_fee"} {
+		app := Application{Type: "counter", TxType: txType}
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "transaction type") {
+			t.Errorf("tx type %q should be accepted, got %v", txType, err)
+		}
+	}
+	app := Application{Type: "counter", TxType: "blob"}
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unknown transaction type") {
+		t.Errorf("invalid tx type was not detected")
+	}
+}
+
+func TestApplication_MixIsChecked(t *testing.T) {
+	scenario := Scenario{}
+	valid := []Application{
+		{Mix: []MixComponent{{Type: "transfer", Weight: 7}, {Type: "erc20", Weight: 3}}},
+		{Type: "mix", Mix: []MixComponent{{Type: "counter", Weight: 1}}},
+	}
+	for _, app := range valid {
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "mix") {
+			t.Errorf("mix %v should be accepted, got %v", app.Mix, err)
+		}
+	}
+
+	invalid := []struct {
+		issue string
+		app   Application
+	}{
+		{"must be of type mix", Application{Type: "counter", Mix: []MixComponent{{Type: "transfer", Weight: 1}}}},
+		{"must define a mix", Application{Type: "mix"}},
+		{"invalid type of mix component", Application{Mix: []MixComponent{{Type: "mix", Weight: 1}}}},
+		{"invalid type of mix component", Application{Mix: []MixComponent{{Type: "unknown", Weight: 1}}}},
+		{"used more than once", Application{Mix: []MixComponent{{Type: "transfer", Weight: 1}, {Type: "Transfer", Weight: 1}}}},
+		{"weight of mix component", Application{Mix: []MixComponent{{Type: "transfer", Weight: 0}}}},
+	}
+	for _, test := range invalid {
+		if err := test.app.Check(&scenario); err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("issue %q was not detected, got %v", test.issue, err)
+		}
+	}
+}
+
+func TestApplication_ContractIsChecked(t *testing.T) {
+	dir := t.TempDir()
+	contract := &Contract{
+		Bytecode: filepath.Join(dir, "Counter.bin"),
+		Abi:      filepath.Join(dir, "Counter.abi"),
+		Function: "incrementCounter",
+		GasLimit: 28036,
+	}
+	if err := os.WriteFile(contract.Bytecode, []byte(abi.CounterBin), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(contract.Abi, []byte(abi.CounterMetaData.ABI), 0600); err != nil {
+		t.Fatal(err)
+	}
+	scenario := Scenario{}
+
+	valid := []Application{
+		{Type: "contract", Contract: contract},
+		{Mix: []MixComponent{{Type: "contract", Weight: 1}}, Contract: contract},
+	}
+	for _, app := range valid {
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "contract") {
+			t.Errorf("contract should be accepted, got %v", err)
+		}
+	}
+
+	unknownFunction := *contract
+	unknownFunction.Function = "unknown"
+	invalid := []struct {
+		issue string
+		app   Application
+	}{
+		{"must define a contract", Application{Type: "contract"}},
+		{"may only be defined for applications of type contract", Application{Type: "counter", Contract: contract}},
+		{"function unknown not found", Application{Type: "contract", Contract: &unknownFunction}},
+	}
+	for _, test := range invalid {
+		if err := test.app.Check(&scenario); err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("issue %q was not detected, got %v", test.issue, err)
+		}
+	}
+}
+
+func TestApplication_ReplayIsChecked(t *testing.T) {
+	replay := filepath.Join(t.TempDir(), "transactions.jsonl")
+	if err := os.WriteFile(replay, []byte(`{"to":"0x0000000000000000000000000000000000000001","gas":"0x5208"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(t.TempDir(), "empty.jsonl")
+	if err := os.WriteFile(empty, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	scenario := Scenario{}
+
+	valid := []Application{
+		{Type: "replay", Replay: replay},
+		{Mix: []MixComponent{{Type: "replay", Weight: 1}}, Replay: replay},
+	}
+	for _, app := range valid {
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "replay") {
+			t.Errorf("replay should be accepted, got %v", err)
+		}
+	}
+
+	invalid := []struct {
+		issue string
+		app   Application
+	}{
+		{"must define a file of recorded transactions", Application{Type: "replay"}},
+		{"may only be defined for applications of type replay", Application{Type: "counter", Replay: replay}},
+		{"no transactions to replay", Application{Type: "replay", Replay: empty}},
+	}
+	for _, test := range invalid {
+		if err := test.app.Check(&scenario); err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("issue %q was not detected, got %v", test.issue, err)
+		}
+	}
+}
+
+func TestApplication_DeployIsChecked(t *testing.T) {
+	scenario := Scenario{}
+	valid := []Application{
+		{Type: "deploy"},
+		{Type: "deploy", Deploy: &Deploy{CodeSize: 24576, Factory: true}},
+		{Mix: []MixComponent{{Type: "deploy", Weight: 1}}, Deploy: &Deploy{CodeSize: 100}},
+	}
+	for _, app := range valid {
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "deploy") {
+			t.Errorf("deploy should be accepted, got %v", err)
+		}
+	}
+
+	invalid := []struct {
+		issue string
+		app   Application
+	}{
+		{"may only be defined for applications of type deploy", Application{Type: "counter", Deploy: &Deploy{}}},
+		{"code size must be between", Application{Type: "deploy", Deploy: &Deploy{CodeSize: 24577}}},
+	}
+	for _, test := range invalid {
+		if err := test.app.Check(&scenario); err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("issue %q was not detected, got %v", test.issue, err)
+		}
+	}
+}
+
+func TestApplication_ReadIsChecked(t *testing.T) {
+	scenario := Scenario{}
+	reads, writes := 0, 5
+	valid := []Application{
+		{Type: "read"},
+		{Type: "read", Read: &Read{Pattern: "Scan", Reads: &reads, Writes: &writes}},
+		{Mix: []MixComponent{{Type: "read", Weight: 1}}, Read: &Read{Pattern: "accounts"}},
+	}
+	for _, app := range valid {
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "read") {
+			t.Errorf("read should be accepted, got %v", err)
+		}
+	}
+
+	none := 0
+	invalid := []struct {
+		issue string
+		app   Application
+	}{
+		{"may only be defined for applications of type read", Application{Type: "counter", Read: &Read{}}},
+		{"unknown read pattern", Application{Type: "read", Read: &Read{Pattern: "random"}}},
+		{"at least one slot", Application{Type: "read", Read: &Read{Writes: &none}}},
+	}
+	for _, test := range invalid {
+		if err := test.app.Check(&scenario); err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("issue %q was not detected, got %v", test.issue, err)
+		}
+	}
+}
+
+func TestApplication_UniswapIsChecked(t *testing.T) {
+	scenario := Scenario{}
+	tokens, pathLength, share := 8, 2, 0.9
+	valid := []Application{
+		{Type: "uniswap"},
+		{Type: "uniswap", Uniswap: &Uniswap{Tokens: &tokens, Topology: "Star", PathLength: &pathLength, Distribution: "exponential", HotPairShare: &share}},
+		{Mix: []MixComponent{{Type: "uniswap", Weight: 1}}, Uniswap: &Uniswap{Topology: "full"}},
+	}
+	for _, app := range valid {
+		if err := app.Check(&scenario); err != nil && strings.Contains(err.Error(), "uniswap") {
+			t.Errorf("uniswap should be accepted, got %v", err)
+		}
+	}
+
+	tooLong, tooHot := 3, 2.0
+	invalid := []struct {
+		issue string
+		app   Application
+	}{
+		{"may only be defined for applications of type uniswap", Application{Type: "counter", Uniswap: &Uniswap{}}},
+		{"unknown topology", Application{Type: "uniswap", Uniswap: &Uniswap{Topology: "ring"}}},
+		{"path length must be between 0 and 2", Application{Type: "uniswap", Uniswap: &Uniswap{Topology: "star", PathLength: &tooLong}}},
+		{"hot pair share must be between", Application{Type: "uniswap", Uniswap: &Uniswap{HotPairShare: &tooHot}}},
+	}
+	for _, test := range invalid {
+		if err := test.app.Check(&scenario); err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("issue %q was not detected, got %v", test.issue, err)
+		}
+	}
+}
+
 func TestApplication_NegativeInstanceCounterIsNotAllowed(t *testing.T) {
 	scenario := Scenario{}
 	app := Application{Name: "test", Type: "counter", Instances: new(int), Rate: Rate{Constant: new(float32)}}
@@ -469,3 +684,391 @@ func TestScenario_CheatIssuesAreDetected(t *testing.T) {
 		t.Errorf("cheat issue was not detected")
 	}
 }
+
+func TestTopology_ValidTopologiesAreAccepted(t *testing.T) {
+	degree := 2
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		Nodes:    []Node{{Name: "A", Client: ClientType{Type: "rpc"}}},
+	}
+	topologies := []Topology{
+		{},
+		{Type: FullMeshTopology},
+		{Type: RingTopology},
+		{Type: RandomTopology, Degree: &degree},
+		{Type: StarTopology},
+		{Type: StarTopology, Hubs: []string{"_validator-1"}},
+		{Type: ExplicitTopology, Peers: map[string][]string{"A": {"_validator-1"}}},
+	}
+	for _, topology := range topologies {
+		if err := topology.Check(&scenario); err != nil {
+			t.Errorf("topology %v should be valid, got %v", topology, err)
+		}
+	}
+}
+
+func TestTopology_UnknownTypeIsDetected(t *testing.T) {
+	topology := Topology{Type: "tree"}
+	if err := topology.Check(&Scenario{}); err == nil || !strings.Contains(err.Error(), "unknown topology type") {
+		t.Errorf("unknown topology type was not detected")
+	}
+}
+
+func TestTopology_InvalidDegreeIsDetected(t *testing.T) {
+	degree := 0
+	topology := Topology{Type: RandomTopology, Degree: &degree}
+	if err := topology.Check(&Scenario{}); err == nil || !strings.Contains(err.Error(), "topology degree must be >= 1") {
+		t.Errorf("invalid degree was not detected")
+	}
+}
+
+func TestTopology_OptionsOfOtherTypesAreDetected(t *testing.T) {
+	degree := 2
+	topology := Topology{
+		Type:   RingTopology,
+		Degree: &degree,
+		Hubs:   []string{"_validator-1"},
+		Peers:  map[string][]string{"_validator-1": {}},
+	}
+	err := topology.Check(&Scenario{})
+	if err == nil {
+		t.Fatalf("options of other topology types were not detected")
+	}
+	for _, msg := range []string{"degree can only be set", "hubs can only be set", "peers can only be set"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("missing error message %q, got %v", msg, err)
+		}
+	}
+}
+
+func TestTopology_UnknownNodeReferencesAreDetected(t *testing.T) {
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		Nodes:    []Node{{Name: "A"}},
+		Topology: &Topology{
+			Type:  ExplicitTopology,
+			Peers: map[string][]string{"A-0": {"A-1", "_validator-2"}},
+		},
+	}
+	err := scenario.Check()
+	if err == nil {
+		t.Fatalf("unknown node references were not detected")
+	}
+	for _, ref := range []string{"A-1", "_validator-2"} {
+		if !strings.Contains(err.Error(), "unknown node in topology peers of A-0: "+ref) {
+			t.Errorf("unknown reference %s was not detected, got %v", ref, err)
+		}
+	}
+}
+
+func TestScenario_GetTopologyUsesRpcNodesAsDefaultHubs(t *testing.T) {
+	scenario := Scenario{
+		Nodes: []Node{
+			{Name: "A", Client: ClientType{Type: "observer"}},
+			{Name: "B", Client: ClientType{Type: "rpc"}},
+		},
+		Topology: &Topology{Type: StarTopology},
+	}
+	topology := scenario.GetTopology()
+	if len(topology.Hubs) != 1 || topology.Hubs[0] != "B" {
+		t.Errorf("unexpected default hubs: %v", topology.Hubs)
+	}
+	if scenario.Topology.Hubs != nil {
+		t.Errorf("default hubs should not modify the scenario")
+	}
+}
+
+func TestScenario_NodesWithoutClientTypeAreRpcNodes(t *testing.T) {
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		Nodes:    []Node{{Name: "A"}},
+		Topology: &Topology{Type: StarTopology},
+	}
+	if topology := scenario.GetTopology(); len(topology.Hubs) != 1 || topology.Hubs[0] != "A" {
+		t.Errorf("unexpected default hubs: %v", topology.Hubs)
+	}
+	routing := NodeSelection{Types: []string{"rpc"}}
+	if err := routing.Check(&scenario); err != nil {
+		t.Errorf("nodes without client type should be covered by rpc selections, got %v", err)
+	}
+}
+
+func TestNodeSelection_ValidSelectionsAreAccepted(t *testing.T) {
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		Nodes:    []Node{{Name: "A"}, {Name: "B", Client: ClientType{Type: "observer"}}},
+	}
+	selections := []NodeSelection{
+		{},
+		{Types: []string{"rpc"}},
+		{Types: []string{"observer"}},
+		{Types: []string{"validator"}, ActiveValidators: true},
+		{ActiveValidators: true},
+	}
+	for _, selection := range selections {
+		if err := selection.Check(&scenario); err != nil {
+			t.Errorf("selection %v should be valid, got %v", selection, err)
+		}
+	}
+}
+
+func TestNodeSelection_InvalidTypeIsDetected(t *testing.T) {
+	selection := NodeSelection{Types: []string{"archive"}}
+	if err := selection.Check(&Scenario{}); err == nil || !strings.Contains(err.Error(), "type of node must be") {
+		t.Errorf("invalid node type was not detected")
+	}
+}
+
+func TestNodeSelection_TypesWithoutNodesAreDetected(t *testing.T) {
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		Nodes:    []Node{{Name: "A", Client: ClientType{Type: "observer"}}},
+		Routing:  &NodeSelection{Types: []string{"rpc"}},
+	}
+	if err := scenario.Check(); err == nil || !strings.Contains(err.Error(), "invalid routing; no node of type rpc") {
+		t.Errorf("routing to missing node type was not detected, got %v", err)
+	}
+}
+
+func TestNodeSelection_RoutingToObserversIsDetected(t *testing.T) {
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		Nodes:    []Node{{Name: "A", Client: ClientType{Type: "observer"}}},
+		Routing:  &NodeSelection{Types: []string{"observer"}},
+	}
+	if err := scenario.Check(); err == nil || !strings.Contains(err.Error(), "observers do not accept transactions") {
+		t.Errorf("routing to observers was not detected, got %v", err)
+	}
+}
+
+func TestResources_ValidLimitsAreAccepted(t *testing.T) {
+	cpus := float32(1.5)
+	memory := "512m"
+	pids := int64(100)
+	resources := Resources{Cpus: &cpus, Memory: &memory, Pids: &pids}
+	if err := resources.Check(); err != nil {
+		t.Errorf("resources should be valid, got %v", err)
+	}
+	if got, err := resources.GetMemory(); err != nil || got != 512*1024*1024 {
+		t.Errorf("unexpected memory limit: %d, %v", got, err)
+	}
+}
+
+func TestResources_InvalidLimitsAreDetected(t *testing.T) {
+	cpus := float32(0)
+	memory := "lots"
+	pids := int64(-1)
+	resources := Resources{Cpus: &cpus, Memory: &memory, Pids: &pids}
+	err := resources.Check()
+	if err == nil {
+		t.Fatalf("invalid resources were not detected")
+	}
+	for _, msg := range []string{"number of CPUs must be > 0", "invalid memory limit", "pids limit must be > 0"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("missing error message %q, got %v", msg, err)
+		}
+	}
+}
+
+func TestNode_BootstrapMethodsAreChecked(t *testing.T) {
+	tests := map[string]bool{
+		FullSyncBootstrap:     true,
+		SnapshotSyncBootstrap: true,
+		"":                    false,
+		"fast":                false,
+	}
+	for bootstrap, valid := range tests {
+		bootstrap := bootstrap
+		scenario := Scenario{
+			Name:     "Test",
+			Duration: 60,
+			Nodes:    []Node{{Name: "A", Bootstrap: &bootstrap}},
+		}
+		err := scenario.Check()
+		if valid && err != nil {
+			t.Errorf("bootstrap %q should be accepted, got %v", bootstrap, err)
+		}
+		if !valid && (err == nil || !strings.Contains(err.Error(), "bootstrap of node must be")) {
+			t.Errorf("bootstrap %q should be rejected, got %v", bootstrap, err)
+		}
+	}
+}
+
+func TestNode_EndpointMustNotBeEmpty(t *testing.T) {
+	tests := map[string]bool{
+		"remote-1": true,
+		"":         false,
+		" ":        false,
+	}
+	for endpoint, valid := range tests {
+		endpoint := endpoint
+		scenario := Scenario{
+			Name:     "Test",
+			Duration: 60,
+			Nodes:    []Node{{Name: "A", Endpoint: &endpoint}},
+		}
+		err := scenario.Check()
+		if valid && err != nil {
+			t.Errorf("endpoint %q should be accepted, got %v", endpoint, err)
+		}
+		if !valid && (err == nil || !strings.Contains(err.Error(), "endpoint of node must not be empty")) {
+			t.Errorf("endpoint %q should be rejected, got %v", endpoint, err)
+		}
+	}
+}
+
+func TestNode_ServicesMustNotBeEmpty(t *testing.T) {
+	tests := map[string]bool{
+		"graphql": true,
+		"":        false,
+		" ":       false,
+	}
+	for service, valid := range tests {
+		scenario := Scenario{
+			Name:     "Test",
+			Duration: 60,
+			Nodes:    []Node{{Name: "A", Services: []string{service}}},
+		}
+		err := scenario.Check()
+		if valid && err != nil {
+			t.Errorf("service %q should be accepted, got %v", service, err)
+		}
+		if !valid && (err == nil || !strings.Contains(err.Error(), "services of node must not be empty")) {
+			t.Errorf("service %q should be rejected, got %v", service, err)
+		}
+	}
+}
+
+func TestGenesis_ValidConfigurationIsAccepted(t *testing.T) {
+	stake := uint64(1_000)
+	code := "0x6080"
+	validators := 2
+	scenario := Scenario{Name: "Test", Duration: 60, NumValidators: &validators}
+	genesis := Genesis{
+		Validators: []GenesisValidator{{Stake: &stake}, {}},
+		Accounts: []GenesisAccount{
+			{Address: "0x0000000000000000000000000000000000000042", Balance: 10},
+			{Address: "0x0000000000000000000000000000000000000043", Code: &code, Storage: map[string]string{"0x01": "0x02"}},
+		},
+		Upgrades: []string{"berlin", "london", "sonic"},
+	}
+	if err := genesis.Check(&scenario); err != nil {
+		t.Errorf("genesis should be valid, got %v", err)
+	}
+}
+
+func TestGenesis_InvalidConfigurationIsDetected(t *testing.T) {
+	stake := uint64(0)
+	code := "not hex"
+	validators := 2
+	scenario := Scenario{Name: "Test", Duration: 60, NumValidators: &validators}
+	genesis := Genesis{
+		Validators: []GenesisValidator{{Stake: &stake}, {}, {}},
+		Accounts: []GenesisAccount{
+			{Address: "0x42"},
+			{Address: "0x0000000000000000000000000000000000000043", Code: &code, CodeFile: &code},
+			{Address: "0x0000000000000000000000000000000000000043", Storage: map[string]string{"0x01": "xyz"}},
+		},
+		Upgrades: []string{"shanghai", "sonic", "sonic"},
+	}
+	err := genesis.Check(&scenario)
+	if err == nil {
+		t.Fatalf("invalid genesis was not detected")
+	}
+	for _, msg := range []string{
+		"genesis lists 3 validators, but the network has only 2",
+		"stake of genesis validator 1 must be > 0",
+		"invalid genesis account address: 0x42",
+		"must not define both code and code_file",
+		"invalid code of genesis account",
+		"is listed multiple times",
+		"invalid storage of genesis account",
+		"unknown network upgrade: shanghai",
+		"network upgrade sonic is listed multiple times",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("missing error message %q, got %v", msg, err)
+		}
+	}
+}
+
+func TestRulesUpdate_ValidUpdatesAreAccepted(t *testing.T) {
+	scenario := Scenario{Name: "Test", Duration: 60}
+	time := float32(30)
+	epoch := uint64(5)
+	rules := map[string]any{"Blocks": map[string]any{"MaxBlockGas": 1_000_000}}
+	for _, update := range []RulesUpdate{
+		{Name: "byTime", Time: &time, Rules: rules},
+		{Name: "byEpoch", Epoch: &epoch, Rules: rules},
+	} {
+		if err := update.Check(&scenario); err != nil {
+			t.Errorf("update %s should be valid, got %v", update.Name, err)
+		}
+	}
+}
+
+func TestRulesUpdate_InvalidUpdatesAreDetected(t *testing.T) {
+	scenario := Scenario{Name: "Test", Duration: 60}
+	time := float32(70)
+	epoch := uint64(0)
+	rules := map[string]any{"Blocks": map[string]any{"MaxBlockGas": 1_000_000}}
+	tests := map[string]RulesUpdate{
+		"must specify exactly one of time and epoch": {Name: "A", Rules: rules},
+		"start time must be <= scenario duration":    {Name: "A", Time: &time, Rules: rules},
+		"must be triggered by an epoch > 0":          {Name: "A", Epoch: &epoch, Rules: rules},
+		"must modify at least one rule":              {Name: "A", Epoch: new(uint64)},
+		"rule update name must match":                {Name: "", Time: new(float32), Rules: rules},
+	}
+	for msg, update := range tests {
+		if err := update.Check(&scenario); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected error %q, got %v", msg, err)
+		}
+	}
+}
+
+func TestInitialState_ValidStatesAreAccepted(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "state.g")
+	if err := os.WriteFile(file, []byte{}, 0600); err != nil {
+		t.Fatalf("failed to create genesis file: %v", err)
+	}
+	scenario := Scenario{Name: "Test", Duration: 60}
+	for _, state := range []InitialState{{Genesis: &file}, {Datadir: &dir}} {
+		if err := state.Check(&scenario); err != nil {
+			t.Errorf("initial state should be valid, got %v", err)
+		}
+	}
+}
+
+func TestInitialState_InvalidStatesAreDetected(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "state.g")
+	if err := os.WriteFile(file, []byte{}, 0600); err != nil {
+		t.Fatalf("failed to create genesis file: %v", err)
+	}
+	missing := filepath.Join(dir, "missing")
+	tests := map[string]InitialState{
+		"must specify exactly one of genesis and datadir": {Genesis: &file, Datadir: &dir},
+		"invalid initial state genesis":                   {Genesis: &missing},
+		"must be a file":                                  {Genesis: &dir},
+		"must be a directory":                             {Datadir: &file},
+	}
+	scenario := Scenario{Name: "Test", Duration: 60}
+	for msg, state := range tests {
+		if err := state.Check(&scenario); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected error %q, got %v", msg, err)
+		}
+	}
+
+	scenario.Genesis = &Genesis{}
+	state := InitialState{Datadir: &dir}
+	if err := state.Check(&scenario); err == nil || !strings.Contains(err.Error(), "must not be defined both") {
+		t.Errorf("conflict with genesis was not detected, got %v", err)
+	}
+}
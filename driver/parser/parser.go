@@ -20,16 +20,21 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/Fantom-foundation/Norma/load/app"
+	"github.com/docker/go-units"
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	DefaultInstance = 1
-	// MaxBlockGas, MaxEpochGas defaults borrowed from example-genesis.json
+	// MaxBlockGas, MaxEpochGas defaults of the norma private network rules
 	DefaultMaxBlockGas = 20_500_000_000
 	DefaultMaxEpochGas = 1_500_000_000_000
+	// DefaultValidatorStake is the stake of genesis validators in FTM.
+	DefaultValidatorStake = 5_000_000
 )
 
 // Scenario is the root element of a scenario description. It defines basic
@@ -40,9 +45,15 @@ type Scenario struct {
 	NumValidators    *int           `yaml:"num_validators,omitempty"`  // nil == 1
 	RoundTripTime    *time.Duration `yaml:"round_trip_time,omitempty"` // nil == 0
 	GenesisGasLimits GasLimits      `yaml:"genesis_gas_limit,omitempty"`
+	Genesis          *Genesis       `yaml:",omitempty"`              // nil == default genesis
+	InitialState     *InitialState  `yaml:"initial_state,omitempty"` // nil == start from genesis
+	Topology         *Topology      `yaml:",omitempty"`              // nil == full mesh
+	Routing          *NodeSelection `yaml:",omitempty"`              // nil == all nodes
+	Checks           *NodeSelection `yaml:",omitempty"`              // nil == all nodes
 	Nodes            []Node         `yaml:",omitempty"`
 	Applications     []Application  `yaml:",omitempty"`
 	Cheats           []Cheat        `yaml:",omitempty"`
+	RuleUpdates      []RulesUpdate  `yaml:"rule_updates,omitempty"`
 }
 
 // GasLimits is a configuration group for gas limit rules
@@ -51,6 +62,106 @@ type GasLimits struct {
 	MaxEpochGas *uint64 `yaml:"max_epoch_gas,omitempty"`
 }
 
+// Genesis defines the initial state of the network. A single genesis is
+// built from it for each network and shared by all of its nodes. Genesis
+// validators, their number being defined by num_validators, are always
+// included with a default stake unless listed otherwise.
+type Genesis struct {
+	Validators []GenesisValidator `yaml:",omitempty"` // stakes of genesis validators, by ascending ID
+	Accounts   []GenesisAccount   `yaml:",omitempty"` // pre-funded accounts and pre-deployed contracts
+	Rules      NetworkRules       `yaml:",omitempty"`
+	Upgrades   []string           `yaml:",omitempty"` // enabled network upgrades, nil = client defaults
+}
+
+// InitialState defines a prepared state the nodes of the network are started
+// from instead of the genesis built by Norma. The state is either given as
+// a genesis file, e.g. exported by `norma snapshot`, or as the datadir of a
+// node, which is copied into the datadir of each node. The validators of the
+// state have to be the genesis validators of Norma networks.
+type InitialState struct {
+	Genesis *string `yaml:",omitempty"` // path to a genesis file
+	Datadir *string `yaml:",omitempty"` // path to a datadir
+}
+
+// GenesisValidator defines properties of a single genesis validator.
+type GenesisValidator struct {
+	Stake *uint64 `yaml:",omitempty"` // in FTM, nil = DefaultValidatorStake
+}
+
+// GetStake returns the stake of the validator in FTM.
+func (v *GenesisValidator) GetStake() uint64 {
+	if v.Stake != nil {
+		return *v.Stake
+	}
+	return DefaultValidatorStake
+}
+
+// GenesisAccount is an account existing from the start of the network. If
+// code is provided, a contract is deployed at the account's address. The
+// code is either given as hex string or as a path to a file containing it.
+type GenesisAccount struct {
+	Name     string            `yaml:",omitempty"`
+	Address  string            // hex address of the account
+	Balance  uint64            `yaml:",omitempty"`          // in FTM
+	Code     *string           `yaml:",omitempty"`          // hex encoded runtime code
+	CodeFile *string           `yaml:"code_file,omitempty"` // file with hex encoded runtime code
+	Storage  map[string]string `yaml:",omitempty"`          // hex encoded slot => value
+}
+
+// NetworkRules are the gas related rules of the network. The block and
+// epoch gas limits are defined by the genesis_gas_limit section.
+type NetworkRules struct {
+	MaxEventGas         *uint64 `yaml:"max_event_gas,omitempty"`
+	ShortGasAllocPerSec *uint64 `yaml:"short_gas_alloc_per_sec,omitempty"`
+	LongGasAllocPerSec  *uint64 `yaml:"long_gas_alloc_per_sec,omitempty"`
+}
+
+const (
+	DefaultMaxEventGas         = 10_028_000_000
+	DefaultShortGasAllocPerSec = 5_600_000_000_000
+	DefaultLongGasAllocPerSec  = 2_800_000_000_000
+)
+
+// GetGenesis returns the genesis configuration of the scenario.
+func (s *Scenario) GetGenesis() Genesis {
+	if s.Genesis == nil {
+		return Genesis{}
+	}
+	return *s.Genesis
+}
+
+// GetInitialState returns the initial state of the scenario.
+func (s *Scenario) GetInitialState() InitialState {
+	if s.InitialState == nil {
+		return InitialState{}
+	}
+	return *s.InitialState
+}
+
+// GetMaxEventGas returns the maximum gas of a single event.
+func (r *NetworkRules) GetMaxEventGas() uint64 {
+	if r.MaxEventGas != nil {
+		return *r.MaxEventGas
+	}
+	return DefaultMaxEventGas
+}
+
+// GetShortGasAllocPerSec returns the short-term gas allocation per second.
+func (r *NetworkRules) GetShortGasAllocPerSec() uint64 {
+	if r.ShortGasAllocPerSec != nil {
+		return *r.ShortGasAllocPerSec
+	}
+	return DefaultShortGasAllocPerSec
+}
+
+// GetLongGasAllocPerSec returns the long-term gas allocation per second.
+func (r *NetworkRules) GetLongGasAllocPerSec() uint64 {
+	if r.LongGasAllocPerSec != nil {
+		return *r.LongGasAllocPerSec
+	}
+	return DefaultLongGasAllocPerSec
+}
+
 // GetMaxBlockGas returns MaxBlockGas
 func (s *Scenario) GetMaxBlockGas() uint64 {
 	if s.GenesisGasLimits.MaxBlockGas != nil {
@@ -83,6 +194,27 @@ func (s *Scenario) GetRoundTripTime() time.Duration {
 	return 0
 }
 
+// GetTopology returns the peer topology of the network. If the scenario
+// does not define a topology, a full mesh is returned. For star topologies
+// without explicitly listed hubs, all RPC nodes are used as hubs.
+func (s *Scenario) GetTopology() Topology {
+	if s.Topology == nil {
+		return Topology{Type: FullMeshTopology}
+	}
+	res := *s.Topology
+	if res.Type == "" {
+		res.Type = FullMeshTopology
+	}
+	if res.Type == StarTopology && len(res.Hubs) == 0 {
+		for _, node := range s.Nodes {
+			if node.Client.Type == "rpc" || node.Client.Type == "" {
+				res.Hubs = append(res.Hubs, node.Name)
+			}
+		}
+	}
+	return res
+}
+
 // Node is a configuration for a group of nodes with similar properties.
 // Each node has a name, a set of features (e.g. 'validator', 'archve'),
 // and a start and end time. Furthermore, nodes may be instantiated multiple
@@ -95,6 +227,53 @@ type Node struct {
 	End       *float32   `yaml:",omitempty"` // nil is interpreted as end-of-scenario
 	Client    ClientType `yaml:",omitempty"`
 	Mount     *string    `yaml:",omitempty"`
+	Resources *Resources `yaml:",omitempty"` // nil is interpreted as unlimited
+	Bootstrap *string    `yaml:",omitempty"` // nil is interpreted as full
+	Endpoint  *string    `yaml:",omitempty"` // nil is interpreted as round-robin placement
+	Services  []string   `yaml:",omitempty"` // optional services, e.g. graphql or tracing
+}
+
+// Bootstrap methods of nodes joining the network. Using full sync, a node
+// starts from the genesis and replays the chain received from its peers.
+// Using snapshot sync, the current state of the chain is exported from a
+// running node of the network and imported by the new node before it starts.
+// The exporting node is shut down during the export and restarted after.
+const (
+	FullSyncBootstrap     = "full"
+	SnapshotSyncBootstrap = "snapshot"
+)
+
+// GetBootstrap returns the method used to bootstrap the state of the node.
+func (n *Node) GetBootstrap() string {
+	if n.Bootstrap == nil {
+		return FullSyncBootstrap
+	}
+	return *n.Bootstrap
+}
+
+// GetEndpoint returns the label of the Docker endpoint the node is placed on,
+// or an empty string if nodes are distributed among all endpoints.
+func (n *Node) GetEndpoint() string {
+	if n.Endpoint == nil {
+		return ""
+	}
+	return *n.Endpoint
+}
+
+// Resources limits the host resources available to each instance of a node.
+// Limits which are not set are not restricted.
+type Resources struct {
+	Cpus   *float32 `yaml:",omitempty"` // number of CPUs, fractions are allowed
+	Memory *string  `yaml:",omitempty"` // amount of memory, e.g. 512m or 4g
+	Pids   *int64   `yaml:",omitempty"` // maximum number of processes
+}
+
+// GetMemory returns the memory limit in bytes, or 0 if no limit is set.
+func (r *Resources) GetMemory() (int64, error) {
+	if r.Memory == nil {
+		return 0, nil
+	}
+	return units.RAMInBytes(*r.Memory)
 }
 
 // IsValidator returns true if the node is defined as validator in Features
@@ -132,7 +311,70 @@ func (n *Node) IsCheater() bool {
 // Type can be used to configure the launching command of the client
 type ClientType struct {
 	ImageName string `yaml:",omitempty"` // nil is interpreted as main
-	Type      string `yaml:",omitempty"` // nil is interpreted as observer
+	Type      string `yaml:",omitempty"` // nil is interpreted as rpc
+}
+
+// Topology defines which nodes of the network are peered with each other.
+// The following types are supported:
+//   - mesh     ... every node is connected to every other node (default)
+//   - ring     ... nodes are connected in a ring in the order they join
+//   - random   ... every node is connected to up to Degree random other nodes
+//   - star     ... nodes are only connected to the Hubs, hubs to everyone
+//   - explicit ... nodes are connected as listed in Peers
+//
+// Nodes are referenced by the name of their node group, which covers all
+// instances of the group, or by the label of an individual instance (e.g.
+// A-0 or _validator-1). For any topology but the full mesh, the discovery
+// of further peers by the clients is disabled.
+type Topology struct {
+	Type   string              `yaml:",omitempty"` // empty is interpreted as mesh
+	Degree *int                `yaml:",omitempty"` // random only, nil = 3
+	Hubs   []string            `yaml:",omitempty"` // star only, nil = all RPC nodes
+	Peers  map[string][]string `yaml:",omitempty"` // explicit only, adjacency lists
+}
+
+const (
+	FullMeshTopology = "mesh"
+	RingTopology     = "ring"
+	RandomTopology   = "random"
+	StarTopology     = "star"
+	ExplicitTopology = "explicit"
+
+	DefaultTopologyDegree = 3
+)
+
+// GetDegree returns the number of peers each node is connected to in a
+// random topology.
+func (t *Topology) GetDegree() int {
+	if t.Degree != nil {
+		return *t.Degree
+	}
+	return DefaultTopologyDegree
+}
+
+// NodeSelection restricts an operation, e.g. the routing of user
+// transactions or the network consistency checks, to a subset of the nodes
+// of the network. Nodes need to satisfy all listed criteria to be selected,
+// an empty selection covers all nodes.
+type NodeSelection struct {
+	Types            []string `yaml:",omitempty"`                  // node types, nil = any type
+	ActiveValidators bool     `yaml:"active_validators,omitempty"` // only validators of the current epoch
+}
+
+// GetRouting returns the selection of nodes user transactions are sent to.
+func (s *Scenario) GetRouting() NodeSelection {
+	if s.Routing == nil {
+		return NodeSelection{}
+	}
+	return *s.Routing
+}
+
+// GetChecks returns the selection of nodes covered by the network checks.
+func (s *Scenario) GetChecks() NodeSelection {
+	if s.Checks == nil {
+		return NodeSelection{}
+	}
+	return *s.Checks
 }
 
 // Application is a load generator in the simulated network. Each application defines
@@ -140,14 +382,175 @@ type ClientType struct {
 // shape (see Rate below), and a number of instances.
 type Application struct {
 	Name      string
-	Type      string   `yaml:",omitempty"` // empty is interpreted as the default app type
-	Instances *int     `yaml:",omitempty"` // nil is interpreted as 1
-	Users     *int     `yaml:",omitempty"` // nil is interpreted as 1
-	Start     *float32 `yaml:",omitempty"` // nil is interpreted as 0
-	End       *float32 `yaml:",omitempty"` // nil is interpreted as end-of-scenario
+	Type      string         `yaml:",omitempty"`        // empty is interpreted as the default app type
+	Instances *int           `yaml:",omitempty"`        // nil is interpreted as 1
+	Users     *int           `yaml:",omitempty"`        // nil is interpreted as 1
+	Start     *float32       `yaml:",omitempty"`        // nil is interpreted as 0
+	End       *float32       `yaml:",omitempty"`        // nil is interpreted as end-of-scenario
+	TxType    string         `yaml:"tx_type,omitempty"` // empty is interpreted as legacy transactions
+	Mix       []MixComponent `yaml:",omitempty"`        // nil is interpreted as a single application type
+	Contract  *Contract      `yaml:",omitempty"`        // the contract called by applications of type contract
+	Replay    string         `yaml:",omitempty"`        // path to a file of transactions replayed by applications of type replay
+	Deploy    *Deploy        `yaml:",omitempty"`        // nil is interpreted as the default deployments of applications of type deploy
+	Read      *Read          `yaml:",omitempty"`        // nil is interpreted as the default transactions of applications of type read
+	Uniswap   *Uniswap       `yaml:",omitempty"`        // nil is interpreted as the default swaps of applications of type uniswap
 	Rate      Rate
 }
 
+// GetConfigs returns the options of the application types used by the
+// application, where options not defined by the application are omitted.
+func (a *Application) GetConfigs() []app.Config {
+	res := []app.Config{}
+	if a.Contract != nil {
+		res = append(res, *a.Contract.GetConfig())
+	}
+	if a.Replay != "" {
+		res = append(res, app.ReplayConfig{Path: a.Replay})
+	}
+	if a.Deploy != nil {
+		res = append(res, *a.Deploy.GetConfig())
+	}
+	if a.Read != nil {
+		res = append(res, *a.Read.GetConfig())
+	}
+	if a.Uniswap != nil {
+		res = append(res, *a.Uniswap.GetConfig())
+	}
+	return res
+}
+
+// Contract defines a user-supplied contract deployed by an application of type
+// contract, and the calls sent to it. Arguments are given by generators, which
+// are either the sender of a call, random integers in an inclusive range, e.g.
+// random(1,100), or constants of the type of the argument.
+type Contract struct {
+	Bytecode        string   // path to a file holding the hex-encoded bytecode
+	Abi             string   // path to a file holding the ABI in JSON format
+	ConstructorArgs []string `yaml:"constructor_args,omitempty"`
+	Function        string   // the function called by the users
+	Args            []string `yaml:",omitempty"` // generators of the arguments of each call
+	GasLimit        uint64   `yaml:"gas_limit"`
+	Counter         *string  `yaml:",omitempty"` // view function returning the received transactions, nil to count nonces of users
+}
+
+// GetConfig converts the contract into the configuration of a contract
+// application.
+func (c *Contract) GetConfig() *app.ContractConfig {
+	counter := ""
+	if c.Counter != nil {
+		counter = *c.Counter
+	}
+	return &app.ContractConfig{
+		Bytecode:        c.Bytecode,
+		Abi:             c.Abi,
+		ConstructorArgs: c.ConstructorArgs,
+		Function:        c.Function,
+		Args:            c.Args,
+		GasLimit:        c.GasLimit,
+		Counter:         counter,
+	}
+}
+
+// Deploy defines the contracts deployed by an application of type deploy.
+type Deploy struct {
+	CodeSize int  `yaml:"code_size,omitempty"` // 0 is interpreted as the default code size
+	Factory  bool `yaml:",omitempty"`          // deploy through a CREATE2 factory instead of contract creation transactions
+}
+
+// GetConfig converts the deployments into the configuration of a deploy
+// application.
+func (d *Deploy) GetConfig() *app.DeployConfig {
+	return &app.DeployConfig{
+		CodeSize: d.CodeSize,
+		Factory:  d.Factory,
+	}
+}
+
+// Read defines the read-dominated transactions of an application of type read.
+// Transactions read random slots written earlier (slots), consecutive slots
+// written earlier (scan), or random accounts funded earlier (accounts).
+type Read struct {
+	Pattern  string `yaml:",omitempty"` // empty is interpreted as the slots pattern
+	Reads    *int   `yaml:",omitempty"` // nil is interpreted as the default number of reads
+	Writes   *int   `yaml:",omitempty"` // nil is interpreted as the default number of writes
+	Accounts *int   `yaml:",omitempty"` // nil is interpreted as the default number of accounts funded per user
+}
+
+// GetConfig converts the reads into the configuration of a read application,
+// where unset options take the defaults of read applications.
+func (r *Read) GetConfig() *app.ReadConfig {
+	config := app.DefaultReadConfig
+	if r.Pattern != "" {
+		config.Pattern = app.ReadPattern(strings.ToLower(r.Pattern))
+	}
+	if r.Reads != nil {
+		config.Reads = *r.Reads
+	}
+	if r.Writes != nil {
+		config.Writes = *r.Writes
+	}
+	if r.Accounts != nil {
+		config.Accounts = *r.Accounts
+	}
+	return &config
+}
+
+// Uniswap defines the tokens and pairs of an application of type uniswap, and
+// the swaps sent by its users. Pairs form a chain of tokens (chain), connect
+// the first token with each other one (star), or connect all tokens (full).
+// Amounts are constant, or drawn from a uniform or exponential distribution
+// with the configured amount as mean.
+type Uniswap struct {
+	Tokens       *int     `yaml:",omitempty"`               // nil is interpreted as the default number of tokens
+	Topology     string   `yaml:",omitempty"`               // empty is interpreted as the chain topology
+	PathLength   *int     `yaml:"path_length,omitempty"`    // nil is interpreted as the longest path of the topology
+	Amount       *int64   `yaml:",omitempty"`               // nil is interpreted as the default amount
+	Distribution string   `yaml:",omitempty"`               // empty is interpreted as constant amounts
+	HotPairShare *float64 `yaml:"hot_pair_share,omitempty"` // nil is interpreted as no additional swaps passing the hot pair
+}
+
+// GetConfig converts the swaps into the configuration of a Uniswap
+// application, where unset options take the defaults of Uniswap applications.
+func (u *Uniswap) GetConfig() *app.UniswapConfig {
+	config := app.DefaultUniswapConfig
+	if u.Tokens != nil {
+		config.Tokens = *u.Tokens
+	}
+	if u.Topology != "" {
+		config.Topology = app.UniswapTopology(strings.ToLower(u.Topology))
+	}
+	if u.PathLength != nil {
+		config.PathLength = *u.PathLength
+	}
+	if u.Amount != nil {
+		config.Amount = *u.Amount
+	}
+	if u.Distribution != "" {
+		config.Distribution = app.UniswapAmountDistribution(strings.ToLower(u.Distribution))
+	}
+	if u.HotPairShare != nil {
+		config.HotPairShare = *u.HotPairShare
+	}
+	return &config
+}
+
+// MixComponent is an application type contributing a share of the
+// transactions of an application mixing several types, proportional to its
+// weight.
+type MixComponent struct {
+	Type   string
+	Weight float32
+}
+
+// GetType returns the type of the application, which is the mix type if the
+// application mixes several types.
+func (a *Application) GetType() string {
+	if len(a.Mix) > 0 {
+		return app.MixApplicationType
+	}
+	return a.Type
+}
+
 // Rate defines the shape of traffic to be generated. There are three types
 // currently supported:
 //   - constant ... traffic is created at a constant rate
@@ -191,6 +594,18 @@ type Cheat struct {
 	Start *float32
 }
 
+// RulesUpdate is an update of the network rules submitted during the run,
+// either at a given time or as soon as the network reaches a given epoch.
+// Rules only list the modified fields using the names of the client's rule
+// definitions, e.g. {Blocks: {MaxBlockGas: 1000000}}. Updates take effect
+// with the epoch following their submission.
+type RulesUpdate struct {
+	Name  string
+	Time  *float32 `yaml:",omitempty"` // time of the update in seconds
+	Epoch *uint64  `yaml:",omitempty"` // epoch triggering the update
+	Rules map[string]any
+}
+
 // Parse parses a YAML based scenario description from the given reader.
 // The parsing will fail if there are syntactic issues in the YAML file
 // or if there are unknown keys. However, no semantic checks on the resulting
@@ -17,8 +17,11 @@
 package parser
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/Fantom-foundation/Norma/load/app"
 )
 
 func TestParseEmpty(t *testing.T) {
@@ -154,3 +157,369 @@ func TestParseExampleWithCheats(t *testing.T) {
 		t.Fatalf("parsing of input failed: %v", err)
 	}
 }
+
+var withTopology = smallExample + `
+
+topology:
+  type: explicit
+  peers:
+    A: [_validator-1, _validator-2]
+    _validator-3: [A-0]
+`
+
+func TestParseExampleWithTopology(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withTopology))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	if got, want := scenario.GetTopology().Type, ExplicitTopology; got != want {
+		t.Errorf("unexpected topology type, wanted %v, got %v", want, got)
+	}
+	if got := len(scenario.GetTopology().Peers); got != 2 {
+		t.Errorf("unexpected number of adjacency lists: %d", got)
+	}
+}
+
+var withNodeSelections = smallExample + `
+
+routing:
+  types: [rpc, validator]
+
+checks:
+  active_validators: true
+`
+
+func TestParseExampleWithNodeSelections(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withNodeSelections))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	if got := scenario.GetRouting().Types; len(got) != 2 || got[0] != "rpc" || got[1] != "validator" {
+		t.Errorf("unexpected routing types: %v", got)
+	}
+	if got := scenario.GetRouting().ActiveValidators; got {
+		t.Errorf("routing should not be restricted to active validators")
+	}
+	if got := scenario.GetChecks().ActiveValidators; !got {
+		t.Errorf("checks should be restricted to active validators")
+	}
+}
+
+var withResources = `
+name: Resources
+duration: 60
+nodes:
+  - name: A
+    resources:
+      cpus: 2
+      memory: 4g
+      pids: 1024
+`
+
+func TestParseExampleWithResources(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withResources))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	resources := scenario.Nodes[0].Resources
+	if resources == nil || resources.Cpus == nil || *resources.Cpus != 2 || resources.Pids == nil || *resources.Pids != 1024 {
+		t.Fatalf("unexpected resources: %v", resources)
+	}
+	if got, err := resources.GetMemory(); err != nil || got != 4<<30 {
+		t.Errorf("unexpected memory limit: %d, %v", got, err)
+	}
+}
+
+var withBootstrap = `
+name: Bootstrap
+duration: 60
+nodes:
+  - name: A
+  - name: B
+    start: 30
+    bootstrap: snapshot
+`
+
+func TestParseExampleWithBootstrap(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withBootstrap))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	if got, want := scenario.Nodes[0].GetBootstrap(), FullSyncBootstrap; got != want {
+		t.Errorf("unexpected default bootstrap, wanted %s, got %s", want, got)
+	}
+	if got, want := scenario.Nodes[1].GetBootstrap(), SnapshotSyncBootstrap; got != want {
+		t.Errorf("unexpected bootstrap, wanted %s, got %s", want, got)
+	}
+}
+
+var withTxType = `
+name: TxType
+duration: 60
+applications:
+  - name: fees
+    type: transfer
+    tx_type: dynamic_fee
+    rate:
+      constant: 10
+`
+
+func TestParseExampleWithTxType(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withTxType))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	if got, want := scenario.Applications[0].TxType, "dynamic_fee"; got != want {
+		t.Errorf("unexpected tx type, wanted %s, got %s", want, got)
+	}
+}
+
+var withMix = `
+name: Mix
+duration: 60
+applications:
+  - name: mainnet
+    mix:
+      - type: transfer
+        weight: 70
+      - type: erc20
+        weight: 20
+      - type: uniswap
+        weight: 10
+    rate:
+      constant: 10
+`
+
+func TestParseExampleWithMix(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withMix))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	if err := scenario.Check(); err != nil {
+		t.Fatalf("scenario should be valid, got %v", err)
+	}
+	application := scenario.Applications[0]
+	want := []MixComponent{{Type: "transfer", Weight: 70}, {Type: "erc20", Weight: 20}, {Type: "uniswap", Weight: 10}}
+	if !slices.Equal(application.Mix, want) {
+		t.Errorf("unexpected mix, wanted %v, got %v", want, application.Mix)
+	}
+	if got, want := application.GetType(), "mix"; got != want {
+		t.Errorf("unexpected type, wanted %s, got %s", want, got)
+	}
+}
+
+var withContract = `
+name: Contract
+duration: 60
+applications:
+  - name: custom
+    type: contract
+    contract:
+      bytecode: build/MyContract.bin
+      abi: build/MyContract.abi
+      constructor_args: ["1000"]
+      function: transfer
+      args: [sender, "random(1,100)"]
+      gas_limit: 60000
+      counter: getCount
+    rate:
+      constant: 10
+`
+
+func TestParseExampleWithContract(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withContract))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	contract := scenario.Applications[0].Contract
+	if contract == nil {
+		t.Fatalf("contract was not parsed")
+	}
+	config := contract.GetConfig()
+	if config.Bytecode != "build/MyContract.bin" || config.Abi != "build/MyContract.abi" || config.Function != "transfer" || config.GasLimit != 60000 || config.Counter != "getCount" {
+		t.Errorf("unexpected contract configuration, got %+v", config)
+	}
+	if !slices.Equal(config.ConstructorArgs, []string{"1000"}) || !slices.Equal(config.Args, []string{"sender", "random(1,100)"}) {
+		t.Errorf("unexpected arguments, got %v and %v", config.ConstructorArgs, config.Args)
+	}
+}
+
+var withDeploy = `
+name: Deploy
+duration: 60
+applications:
+  - name: deployments
+    type: deploy
+    deploy:
+      code_size: 4096
+      factory: true
+    rate:
+      constant: 10
+`
+
+func TestParseExampleWithDeploy(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withDeploy))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	deploy := scenario.Applications[0].Deploy
+	if deploy == nil {
+		t.Fatalf("deploy was not parsed")
+	}
+	if config := deploy.GetConfig(); config.CodeSize != 4096 || !config.Factory {
+		t.Errorf("unexpected deploy configuration, got %+v", config)
+	}
+}
+
+var withRead = `
+name: Read
+duration: 60
+applications:
+  - name: reads
+    type: read
+    read:
+      pattern: scan
+      writes: 0
+    rate:
+      constant: 10
+`
+
+func TestParseExampleWithRead(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withRead))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	read := scenario.Applications[0].Read
+	if read == nil {
+		t.Fatalf("read was not parsed")
+	}
+	want := app.DefaultReadConfig
+	want.Pattern = app.ScanReadPattern
+	want.Writes = 0
+	if got := read.GetConfig(); *got != want {
+		t.Errorf("unexpected read configuration, wanted %+v, got %+v", want, *got)
+	}
+}
+
+var withUniswap = `
+name: Uniswap
+duration: 60
+applications:
+  - name: swaps
+    type: uniswap
+    uniswap:
+      tokens: 6
+      topology: star
+      path_length: 1
+      distribution: uniform
+      hot_pair_share: 0.8
+    rate:
+      constant: 10
+`
+
+func TestParseExampleWithUniswap(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withUniswap))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	uniswap := scenario.Applications[0].Uniswap
+	if uniswap == nil {
+		t.Fatalf("uniswap was not parsed")
+	}
+	want := app.DefaultUniswapConfig
+	want.Tokens = 6
+	want.Topology = app.StarUniswapTopology
+	want.PathLength = 1
+	want.Distribution = app.UniformUniswapAmounts
+	want.HotPairShare = 0.8
+	if got := uniswap.GetConfig(); *got != want {
+		t.Errorf("unexpected uniswap configuration, wanted %+v, got %+v", want, *got)
+	}
+}
+
+var withGenesis = `
+name: Genesis
+duration: 60
+num_validators: 2
+genesis:
+  validators:
+    - stake: 1000000
+    - {}
+  accounts:
+    - name: treasury
+      address: "0x0000000000000000000000000000000000000042"
+      balance: 1000
+    - address: "0x0000000000000000000000000000000000000043"
+      code: "0x6080"
+      storage:
+        "0x00": "0x01"
+  rules:
+    max_event_gas: 1000000
+  upgrades: [berlin, london, llr, sonic]
+`
+
+func TestParseExampleWithGenesis(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withGenesis))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	genesis := scenario.GetGenesis()
+	if got, want := len(genesis.Validators), 2; got != want {
+		t.Fatalf("unexpected number of validators, wanted %d, got %d", want, got)
+	}
+	if got, want := genesis.Validators[0].GetStake(), uint64(1_000_000); got != want {
+		t.Errorf("unexpected stake, wanted %d, got %d", want, got)
+	}
+	if got, want := genesis.Validators[1].GetStake(), uint64(DefaultValidatorStake); got != want {
+		t.Errorf("unexpected default stake, wanted %d, got %d", want, got)
+	}
+	if got, want := len(genesis.Accounts), 2; got != want {
+		t.Fatalf("unexpected number of accounts, wanted %d, got %d", want, got)
+	}
+	if got, want := genesis.Rules.GetMaxEventGas(), uint64(1_000_000); got != want {
+		t.Errorf("unexpected max event gas, wanted %d, got %d", want, got)
+	}
+	if got, want := genesis.Rules.GetLongGasAllocPerSec(), uint64(DefaultLongGasAllocPerSec); got != want {
+		t.Errorf("unexpected long gas allocation, wanted %d, got %d", want, got)
+	}
+	if got, want := len(genesis.Upgrades), 4; got != want {
+		t.Errorf("unexpected number of upgrades, wanted %d, got %d", want, got)
+	}
+}
+
+var withRuleUpdates = smallExample + `
+
+rule_updates:
+  - name: gas
+    time: 20
+    rules:
+      Blocks:
+        MaxBlockGas: 1000000
+  - name: upgrade
+    epoch: 10
+    rules:
+      Upgrades:
+        Allegro: true
+`
+
+func TestParseExampleWithRuleUpdates(t *testing.T) {
+	scenario, err := ParseBytes([]byte(withRuleUpdates))
+	if err != nil {
+		t.Fatalf("parsing of input failed: %v", err)
+	}
+	if got, want := len(scenario.RuleUpdates), 2; got != want {
+		t.Fatalf("unexpected number of rule updates, wanted %d, got %d", want, got)
+	}
+	gas := scenario.RuleUpdates[0]
+	if gas.Time == nil || *gas.Time != 20 || gas.Epoch != nil {
+		t.Errorf("unexpected trigger of first update: %v, %v", gas.Time, gas.Epoch)
+	}
+	blocks, ok := gas.Rules["Blocks"].(map[string]any)
+	if !ok || blocks["MaxBlockGas"] != 1000000 {
+		t.Errorf("unexpected rules of first update: %v", gas.Rules)
+	}
+	upgrade := scenario.RuleUpdates[1]
+	if upgrade.Epoch == nil || *upgrade.Epoch != 10 || upgrade.Time != nil {
+		t.Errorf("unexpected trigger of second update: %v, %v", upgrade.Time, upgrade.Epoch)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+// SelectNodes filters the given nodes of the network according to the given
+// selection. Only if the selection is restricted to active validators, the
+// validator status is obtained from the network.
+func SelectNodes(ctx context.Context, net Network, nodes []Node, selection parser.NodeSelection) ([]Node, error) {
+	var active map[int]bool
+	if selection.ActiveValidators {
+		validators, err := net.GetValidators(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get validators; %v", err)
+		}
+		active = map[int]bool{}
+		for _, validator := range validators {
+			if validator.Active {
+				active[validator.ID] = true
+			}
+		}
+	}
+
+	res := make([]Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !hasSelectedType(node, selection.Types) {
+			continue
+		}
+		if active != nil {
+			id := node.GetValidatorId()
+			if id == nil || !active[*id] {
+				continue
+			}
+		}
+		res = append(res, node)
+	}
+	return res, nil
+}
+
+func hasSelectedType(node Node, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if NodeType(t) == node.GetType() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package driver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSelectNodes_EmptySelectionCoversAllNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := NewMockNetwork(ctrl)
+	nodes := []Node{NewMockNode(ctrl), NewMockNode(ctrl)}
+
+	selected, err := SelectNodes(context.Background(), net, nodes, parser.NodeSelection{})
+	if err != nil {
+		t.Fatalf("failed to select nodes: %v", err)
+	}
+	if len(selected) != len(nodes) {
+		t.Errorf("unexpected number of selected nodes, wanted %d, got %d", len(nodes), len(selected))
+	}
+}
+
+func TestSelectNodes_FiltersByType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := NewMockNetwork(ctrl)
+	validator := NewMockNode(ctrl)
+	rpc := NewMockNode(ctrl)
+	observer := NewMockNode(ctrl)
+	validator.EXPECT().GetType().AnyTimes().Return(ValidatorNode)
+	rpc.EXPECT().GetType().AnyTimes().Return(RpcNode)
+	observer.EXPECT().GetType().AnyTimes().Return(ObserverNode)
+
+	selected, err := SelectNodes(context.Background(), net, []Node{validator, rpc, observer}, parser.NodeSelection{
+		Types: []string{"rpc", "observer"},
+	})
+	if err != nil {
+		t.Fatalf("failed to select nodes: %v", err)
+	}
+	if len(selected) != 2 || selected[0] != rpc || selected[1] != observer {
+		t.Errorf("unexpected selection: %v", selected)
+	}
+}
+
+func TestSelectNodes_FiltersActiveValidators(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := NewMockNetwork(ctrl)
+	active := NewMockNode(ctrl)
+	inactive := NewMockNode(ctrl)
+	observer := NewMockNode(ctrl)
+	one, two := 1, 2
+	active.EXPECT().GetValidatorId().AnyTimes().Return(&one)
+	inactive.EXPECT().GetValidatorId().AnyTimes().Return(&two)
+	observer.EXPECT().GetValidatorId().AnyTimes().Return(nil)
+
+	net.EXPECT().GetValidators(gomock.Any()).Return([]ValidatorInfo{
+		{ID: 1, Nodes: []string{"A"}, Active: true},
+		{ID: 2, Nodes: []string{"B"}, Active: false},
+	}, nil)
+
+	selected, err := SelectNodes(context.Background(), net, []Node{active, inactive, observer}, parser.NodeSelection{
+		ActiveValidators: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to select nodes: %v", err)
+	}
+	if len(selected) != 1 || selected[0] != active {
+		t.Errorf("unexpected selection: %v", selected)
+	}
+}
+
+func TestSelectNodes_ReportsValidatorQueryFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := NewMockNetwork(ctrl)
+	net.EXPECT().GetValidators(gomock.Any()).Return(nil, fmt.Errorf("injected"))
+
+	if _, err := SelectNodes(context.Background(), net, nil, parser.NodeSelection{ActiveValidators: true}); err == nil {
+		t.Errorf("failure to obtain validators should be reported")
+	}
+}
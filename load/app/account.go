@@ -36,21 +36,24 @@ type AccountFactory struct {
 	chainID         *big.Int
 	numAccounts     int64
 	feederId, appId uint32
+	txType          TxType
 }
 
 // NewAccountFactory creates a new AccountFactory, generating accounts for given feeder and app.
 // Re-creating a factory using the same feederId and appId will produce the same sequence of accounts.
-func NewAccountFactory(chainID *big.Int, feederId, appId uint32) (*AccountFactory, error) {
+// The created accounts send transactions of the given type.
+func NewAccountFactory(chainID *big.Int, feederId, appId uint32, txType TxType) (*AccountFactory, error) {
 	return &AccountFactory{
 		chainID:     chainID,
 		numAccounts: 0,
 		feederId:    feederId,
 		appId:       appId,
+		txType:      txType,
 	}, nil
 }
 
 // CreateAccount generates the next account in the sequence generated by the AccountFactory.
-func (f *AccountFactory) CreateAccount(rpcClient rpc.RpcClient) (*Account, error) {
+func (f *AccountFactory) CreateAccount(ctx context.Context, rpcClient rpc.RpcClient) (*Account, error) {
 	id := atomic.AddInt64(&f.numAccounts, 1)
 	d := make([]byte, 32)
 	binary.BigEndian.PutUint64(d[:24], uint64(id))
@@ -62,7 +65,7 @@ func (f *AccountFactory) CreateAccount(rpcClient rpc.RpcClient) (*Account, error
 	}
 	address := crypto.PubkeyToAddress(privateKey.PublicKey)
 
-	nonce, err := rpcClient.NonceAt(context.Background(), address, nil) // nonce at latest block
+	nonce, err := rpcClient.NonceAt(ctx, address, nil) // nonce at latest block
 	if err != nil {
 		return nil, fmt.Errorf("failed to get address nonce; %v", err)
 	}
@@ -72,6 +75,7 @@ func (f *AccountFactory) CreateAccount(rpcClient rpc.RpcClient) (*Account, error
 		address:    address,
 		chainID:    f.chainID,
 		nonce:      nonce,
+		txType:     f.txType,
 	}, nil
 }
 
@@ -85,6 +89,7 @@ type Account struct {
 	chainID    *big.Int
 	nonce      uint64
 	publicKey  []byte
+	txType     TxType // the type of transactions sent, legacy if empty
 }
 
 // NewAccount creates an Account instance from the provided private key
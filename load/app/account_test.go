@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/mock/gomock"
@@ -18,13 +19,13 @@ func TestAccount_CreateAccount_AccountsUniq(t *testing.T) {
 
 	accounts := make(map[common.Address]struct{}, loops)
 	for i := 0; i < loops; i++ {
-		gen, err := NewAccountFactory(chainId, 0, uint32(i))
+		gen, err := NewAccountFactory(chainId, 0, uint32(i), LegacyTxType)
 		if err != nil {
 			t.Fatalf("cannot create account factory: %v", err)
 		}
 
 		for j := 0; j < loops; j++ {
-			account, err := gen.CreateAccount(rpcClient)
+			account, err := gen.CreateAccount(context.Background(), rpcClient)
 			if err != nil {
 				t.Fatalf("cannot create account: %v", err)
 			}
@@ -17,6 +17,7 @@
 package app
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/Fantom-foundation/Norma/driver/rpc"
@@ -28,11 +29,11 @@ import (
 type Application interface {
 	// CreateUsers creates a number of users for this application that
 	// can generate transactions for this application.
-	CreateUsers(context AppContext, numUsers int) ([]User, error)
+	CreateUsers(ctx context.Context, context AppContext, numUsers int) ([]User, error)
 
 	// GetReceivedTransactions returns the total number of transactions
 	// received by this application up to the current point in time.
-	GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error)
+	GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error)
 }
 
 // User produces a stream of transactions to Generate traffic on the chain.
@@ -41,3 +42,36 @@ type User interface {
 	GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error)
 	GetSentTransactions() uint64
 }
+
+// AppConfig defines the options of an application in addition to its type.
+type AppConfig struct {
+	// TxType is the kind of transactions sent by the users of the application,
+	// legacy transactions if empty.
+	TxType TxType
+	// Mix lists the application types the transactions of a mix application
+	// are drawn from.
+	Mix []MixComponent
+	// Configs holds the options of the application types used by the
+	// application, where types without an entry take their defaults.
+	Configs []Config
+}
+
+// Config defines the options specific to one application type.
+type Config interface {
+	// Type returns the application type configured by the options.
+	Type() string
+	// Check tests whether applications can be created as configured.
+	Check() error
+}
+
+// getConfig returns the options of type T among the given configurations,
+// and whether they are defined.
+func getConfig[T Config](config AppConfig) (T, bool) {
+	for _, cur := range config.Configs {
+		if res, ok := cur.(T); ok {
+			return res, true
+		}
+	}
+	var res T
+	return res, false
+}
@@ -26,6 +26,7 @@
 package app
 
 import (
+	context "context"
 	big "math/big"
 	reflect "reflect"
 
@@ -58,33 +59,33 @@ func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
 }
 
 // CreateUsers mocks base method.
-func (m *MockApplication) CreateUsers(context AppContext, numUsers int) ([]User, error) {
+func (m *MockApplication) CreateUsers(ctx context.Context, context AppContext, numUsers int) ([]User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUsers", context, numUsers)
+	ret := m.ctrl.Call(m, "CreateUsers", ctx, context, numUsers)
 	ret0, _ := ret[0].([]User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUsers indicates an expected call of CreateUsers.
-func (mr *MockApplicationMockRecorder) CreateUsers(context, numUsers any) *gomock.Call {
+func (mr *MockApplicationMockRecorder) CreateUsers(ctx, context, numUsers any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUsers", reflect.TypeOf((*MockApplication)(nil).CreateUsers), context, numUsers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUsers", reflect.TypeOf((*MockApplication)(nil).CreateUsers), ctx, context, numUsers)
 }
 
 // GetReceivedTransactions mocks base method.
-func (m *MockApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+func (m *MockApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReceivedTransactions", rpcClient)
+	ret := m.ctrl.Call(m, "GetReceivedTransactions", ctx, rpcClient)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetReceivedTransactions indicates an expected call of GetReceivedTransactions.
-func (mr *MockApplicationMockRecorder) GetReceivedTransactions(rpcClient any) *gomock.Call {
+func (mr *MockApplicationMockRecorder) GetReceivedTransactions(ctx, rpcClient any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactions), rpcClient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactions), ctx, rpcClient)
 }
 
 // MockUser is a mock of User interface.
@@ -19,6 +19,9 @@ package app_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +29,7 @@ import (
 	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/network/local"
 	"github.com/Fantom-foundation/Norma/load/app"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -34,7 +38,7 @@ const FakeNetworkID = 0xfa3
 
 func TestGenerators(t *testing.T) {
 	// run local network of one node
-	net, err := local.NewLocalNetwork(&driver.NetworkConfig{NumberOfValidators: 1})
+	net, err := local.NewLocalNetwork(context.Background(), &driver.NetworkConfig{NumberOfValidators: 1})
 	if err != nil {
 		t.Fatalf("failed to create new local network: %v", err)
 	}
@@ -45,43 +49,145 @@ func TestGenerators(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	context, err := app.NewContext(net, primaryAccount)
+	appContext, err := app.NewContext(context.Background(), net, primaryAccount)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	t.Run("Counter", func(t *testing.T) {
-		counterApp, err := app.NewCounterApplication(context, 0, 0)
+		counterApp, err := app.NewCounterApplication(context.Background(), appContext, 0, 0, app.AppConfig{})
 		if err != nil {
 			t.Fatal(err)
 		}
-		testGenerator(t, counterApp, context)
+		testGenerator(t, counterApp, appContext)
 	})
 	t.Run("ERC20", func(t *testing.T) {
-		erc20app, err := app.NewERC20Application(context, 0, 0)
+		erc20app, err := app.NewERC20Application(context.Background(), appContext, 0, 0, app.AppConfig{})
 		if err != nil {
 			t.Fatal(err)
 		}
-		testGenerator(t, erc20app, context)
+		testGenerator(t, erc20app, appContext)
 	})
 	t.Run("Store", func(t *testing.T) {
-		storeApp, err := app.NewStoreApplication(context, 0, 0)
+		storeApp, err := app.NewStoreApplication(context.Background(), appContext, 0, 0, app.AppConfig{})
 		if err != nil {
 			t.Fatal(err)
 		}
-		testGenerator(t, storeApp, context)
+		testGenerator(t, storeApp, appContext)
 	})
 	t.Run("Uniswap", func(t *testing.T) {
-		uniswapApp, err := app.NewUniswapApplication(context, 0, 0)
+		uniswapApp, err := app.NewUniswapApplication(context.Background(), appContext, 0, 0, app.AppConfig{})
 		if err != nil {
 			t.Fatal(err)
 		}
-		testGenerator(t, uniswapApp, context)
+		testGenerator(t, uniswapApp, appContext)
 	})
+	for _, topology := range []app.UniswapTopology{app.StarUniswapTopology, app.FullUniswapTopology} {
+		t.Run(fmt.Sprintf("Uniswap_%s", topology), func(t *testing.T) {
+			config := app.DefaultUniswapConfig
+			config.Topology = topology
+			config.Tokens = 5
+			config.Distribution = app.ExponentialUniswapAmounts
+			config.HotPairShare = 0.5
+			uniswapApp, err := app.NewUniswapApplication(context.Background(), appContext, 0, 0, app.AppConfig{Configs: []app.Config{config}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			testGenerator(t, uniswapApp, appContext)
+		})
+	}
+	t.Run("Transfer", func(t *testing.T) {
+		transferApp, err := app.NewTransferApplication(context.Background(), appContext, 0, 0, app.AppConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, transferApp, appContext)
+	})
+	t.Run("Contract", func(t *testing.T) {
+		dir := t.TempDir()
+		config := app.ContractConfig{
+			Bytecode: filepath.Join(dir, "Counter.bin"),
+			Abi:      filepath.Join(dir, "Counter.abi"),
+			Function: "incrementCounter",
+			GasLimit: 28036,
+			Counter:  "getCount",
+		}
+		if err := os.WriteFile(config.Bytecode, []byte(contract.CounterBin), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(config.Abi, []byte(contract.CounterMetaData.ABI), 0600); err != nil {
+			t.Fatal(err)
+		}
+		contractApp, err := app.NewContractApplication(context.Background(), appContext, 0, 0, app.AppConfig{Configs: []app.Config{config}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, contractApp, appContext)
+	})
+	t.Run("Replay", func(t *testing.T) {
+		// The recorded Counter contract is created first and called by the replayed transactions.
+		recorded := "0x1111111111111111111111111111111111111111"
+		lines := []string{
+			fmt.Sprintf(`{"to":null,"input":"%s","gas":"0x100000","contractAddress":"%s"}`, contract.CounterBin, recorded),
+			fmt.Sprintf(`{"to":"%s","input":"0x5b34b966","gas":"0x6d84"}`, recorded),
+		}
+		replay := filepath.Join(t.TempDir(), "transactions.jsonl")
+		if err := os.WriteFile(replay, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+			t.Fatal(err)
+		}
+		replayApp, err := app.NewReplayApplication(context.Background(), appContext, 0, 0, app.AppConfig{Configs: []app.Config{app.ReplayConfig{Path: replay}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, replayApp, appContext)
+	})
+	t.Run("NFT", func(t *testing.T) {
+		nftApp, err := app.NewNftApplication(context.Background(), appContext, 0, 0, app.AppConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, nftApp, appContext)
+	})
+	t.Run("Wallet", func(t *testing.T) {
+		walletApp, err := app.NewWalletApplication(context.Background(), appContext, 0, 0, app.AppConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, walletApp, appContext)
+	})
+	for _, factory := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Deploy_factory_%t", factory), func(t *testing.T) {
+			deployApp, err := app.NewDeployApplication(context.Background(), appContext, 0, 0, app.AppConfig{Configs: []app.Config{app.DeployConfig{Factory: factory}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			testGenerator(t, deployApp, appContext)
+		})
+	}
+	for _, pattern := range []app.ReadPattern{app.SlotsReadPattern, app.ScanReadPattern, app.AccountsReadPattern} {
+		t.Run(fmt.Sprintf("Read_%s", pattern), func(t *testing.T) {
+			config := app.DefaultReadConfig
+			config.Pattern = pattern
+			readApp, err := app.NewReadApplication(context.Background(), appContext, 0, 0, app.AppConfig{Configs: []app.Config{config}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			testGenerator(t, readApp, appContext)
+		})
+	}
+	for _, txType := range []app.TxType{app.AccessListTxType, app.DynamicFeeTxType} {
+		t.Run(fmt.Sprintf("Counter_%s", txType), func(t *testing.T) {
+			counterApp, err := app.NewCounterApplication(context.Background(), appContext, 0, 0, app.AppConfig{TxType: txType})
+			if err != nil {
+				t.Fatal(err)
+			}
+			testGenerator(t, counterApp, appContext)
+		})
+	}
 }
 
 func testGenerator(t *testing.T, app app.Application, ctxt app.AppContext) {
-	users, err := app.CreateUsers(ctxt, 1)
+	users, err := app.CreateUsers(context.Background(), ctxt, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -110,7 +216,7 @@ func testGenerator(t *testing.T, app app.Application, ctxt app.AppContext) {
 
 	// wait for the transactions to be processed
 	for _, tx := range transactions {
-		receipt, err := ctxt.GetReceipt(tx.Hash())
+		receipt, err := ctxt.GetReceipt(context.Background(), tx.Hash())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -124,7 +230,7 @@ func testGenerator(t *testing.T, app app.Application, ctxt app.AppContext) {
 	}
 
 	err = network.Retry(network.DefaultRetryAttempts, 1*time.Second, func() error {
-		received, err := app.GetReceivedTransactions(rpcClient)
+		received, err := app.GetReceivedTransactions(context.Background(), rpcClient)
 		if err != nil {
 			return fmt.Errorf("unable to get amount of received txs; %v", err)
 		}
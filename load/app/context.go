@@ -41,10 +41,10 @@ import (
 type AppContext interface {
 	GetClient() rpc.RpcClient
 	GetTreasure() *Account
-	GetTransactOptions(account *Account) (*bind.TransactOpts, error)
-	GetReceipt(txHash common.Hash) (*types.Receipt, error)
-	Run(operation func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error)
-	FundAccounts(accounts []common.Address, value *big.Int) error
+	GetTransactOptions(ctx context.Context, account *Account) (*bind.TransactOpts, error)
+	GetReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	Run(ctx context.Context, operation func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error)
+	FundAccounts(ctx context.Context, accounts []common.Address, value *big.Int) error
 	Close()
 }
 
@@ -52,7 +52,7 @@ type RpcClientFactory interface {
 	DialRandomRpc() (rpc.RpcClient, error)
 }
 
-func NewContext(factory RpcClientFactory, treasury *Account) (*appContext, error) {
+func NewContext(ctx context.Context, factory RpcClientFactory, treasury *Account) (*appContext, error) {
 	rpcClient, err := factory.DialRandomRpc()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to network: %w", err)
@@ -66,7 +66,7 @@ func NewContext(factory RpcClientFactory, treasury *Account) (*appContext, error
 	}
 
 	// Install a helper contract on the network to perform operations.
-	helper, receipt, err := DeployContract(res, contract.DeployHelper)
+	helper, receipt, err := DeployContract(ctx, res, contract.DeployHelper)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy helper contract: %w", err)
 	}
@@ -101,21 +101,20 @@ func (c *appContext) GetTreasure() *Account {
 // the next free nonce of the given account, and a hard-coded gas limit of 1e6.
 // The main purpose of this function is to provide a convenient way to collect all
 // the necessary information required to create a transaction in one place.
-func (c *appContext) GetTransactOptions(account *Account) (*bind.TransactOpts, error) {
+func (c *appContext) GetTransactOptions(ctx context.Context, account *Account) (*bind.TransactOpts, error) {
 	client := c.rpcClient
 
-	ctxt := context.Background()
-	chainId, err := client.ChainID(ctxt)
+	chainId, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
-	gasPrice, err := client.SuggestGasPrice(ctxt)
+	gasPrice, err := client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price suggestion: %w", err)
 	}
 
-	nonce, err := client.NonceAt(ctxt, account.address, nil)
+	nonce, err := client.NonceAt(ctx, account.address, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
@@ -126,21 +125,23 @@ func (c *appContext) GetTransactOptions(account *Account) (*bind.TransactOpts, e
 	}
 	txOpts.GasPrice = new(big.Int).Mul(gasPrice, big.NewInt(2))
 	txOpts.Nonce = big.NewInt(int64(nonce))
+	txOpts.Context = ctx
 	return txOpts, nil
 }
 
 // GetReceipt waits for the receipt of the given transaction hash to be available.
-// The function times out after 10 seconds.
-func (c *appContext) GetReceipt(txHash common.Hash) (*types.Receipt, error) {
-	return GetReceipt(txHash, c.rpcClient)
+// See GetReceipt for the applied timeout.
+func (c *appContext) GetReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return GetReceipt(ctx, txHash, c.rpcClient)
 }
 
 // Apply sends a transaction to the network using the network's validator account
 // and waits for the transaction to be processed. The resulting receipt is returned.
 func (c *appContext) Run(
+	ctx context.Context,
 	operation func(*bind.TransactOpts) (*types.Transaction, error),
 ) (*types.Receipt, error) {
-	txOpts, err := c.GetTransactOptions(c.treasury)
+	txOpts, err := c.GetTransactOptions(ctx, c.treasury)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction options: %w", err)
 	}
@@ -148,12 +149,12 @@ func (c *appContext) Run(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
-	return c.GetReceipt(transaction.Hash())
+	return c.GetReceipt(ctx, transaction.Hash())
 }
 
 // FundAccounts transfers the given amount of funds from the treasure to each of the
 // given accounts.
-func (c *appContext) FundAccounts(accounts []common.Address, value *big.Int) error {
+func (c *appContext) FundAccounts(ctx context.Context, accounts []common.Address, value *big.Int) error {
 	// Group funding requests in batches to avoid making individual transactions
 	// too big for a single block.
 	const batchSize = 128
@@ -163,7 +164,7 @@ func (c *appContext) FundAccounts(accounts []common.Address, value *big.Int) err
 	}
 
 	// Send one transaction per batch of accounts.
-	opts, err := c.GetTransactOptions(c.GetTreasure())
+	opts, err := c.GetTransactOptions(ctx, c.GetTreasure())
 	if err != nil {
 		return fmt.Errorf("failed to get transaction options: %w", err)
 	}
@@ -180,7 +181,7 @@ func (c *appContext) FundAccounts(accounts []common.Address, value *big.Int) err
 
 	// Wait for all the transactions to be completed.
 	for _, tx := range txs {
-		receipt, err := c.GetReceipt(tx.Hash())
+		receipt, err := c.GetReceipt(ctx, tx.Hash())
 		if err != nil {
 			return fmt.Errorf("failed to get receipt: %w", err)
 		}
@@ -194,10 +195,10 @@ func (c *appContext) FundAccounts(accounts []common.Address, value *big.Int) err
 // DeployContract is a utility function handling the deployment of a contract on the network.
 // The contract is deployed with by the network's treasure account. The function returns the
 // deployed contract instance and the transaction receipt.
-func DeployContract[T any](c AppContext, deploy contractDeployer[T]) (*T, *types.Receipt, error) {
+func DeployContract[T any](ctx context.Context, c AppContext, deploy contractDeployer[T]) (*T, *types.Receipt, error) {
 	client := c.GetClient()
 
-	transactOptions, err := c.GetTransactOptions(c.GetTreasure())
+	transactOptions, err := c.GetTransactOptions(ctx, c.GetTreasure())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get transaction options: %w", err)
 	}
@@ -207,24 +208,37 @@ func DeployContract[T any](c AppContext, deploy contractDeployer[T]) (*T, *types
 		return nil, nil, fmt.Errorf("failed to deploy contract: %w", err)
 	}
 
-	receipt, err := c.GetReceipt(transaction.Hash())
+	receipt, err := c.GetReceipt(ctx, transaction.Hash())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get receipt: %w", err)
 	}
 	return contract, receipt, nil
 }
 
+// DefaultReceiptTimeout is the time waited for a receipt if the context of
+// the request defines no deadline.
+const DefaultReceiptTimeout = 12 * time.Second
+
 // GetReceipt waits for the receipt of the given transaction hash to be available.
-// The function times out after 10 seconds.
-func GetReceipt(txHash common.Hash, rpcClient rpc.RpcClient) (*types.Receipt, error) {
+// The wait ends with the deadline of the given context, or after
+// DefaultReceiptTimeout if the context has none.
+func GetReceipt(ctx context.Context, txHash common.Hash, rpcClient rpc.RpcClient) (*types.Receipt, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultReceiptTimeout)
+		defer cancel()
+	}
 	// Wait for the response with some exponential backoff.
 	const maxDelay = 100 * time.Millisecond
-	begin := time.Now()
 	delay := time.Millisecond
-	for time.Since(begin) < 12*time.Second {
-		receipt, err := rpcClient.TransactionReceipt(context.Background(), txHash)
+	for {
+		receipt, err := rpcClient.TransactionReceipt(ctx, txHash)
 		if errors.Is(err, ethereum.NotFound) {
-			time.Sleep(delay)
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("failed to get transaction receipt: %w", ctx.Err())
+			case <-time.After(delay):
+			}
 			delay = 2 * delay
 			if delay > maxDelay {
 				delay = maxDelay
@@ -236,7 +250,6 @@ func GetReceipt(txHash common.Hash, rpcClient rpc.RpcClient) (*types.Receipt, er
 		}
 		return receipt, nil
 	}
-	return nil, fmt.Errorf("failed to get transaction receipt: timeout")
 }
 
 // contractDeployer is the type of the deployment functions generated by abigen.
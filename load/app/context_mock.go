@@ -26,6 +26,7 @@
 package app
 
 import (
+	context "context"
 	big "math/big"
 	reflect "reflect"
 
@@ -72,17 +73,17 @@ func (mr *MockAppContextMockRecorder) Close() *gomock.Call {
 }
 
 // FundAccounts mocks base method.
-func (m *MockAppContext) FundAccounts(accounts []common.Address, value *big.Int) error {
+func (m *MockAppContext) FundAccounts(ctx context.Context, accounts []common.Address, value *big.Int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FundAccounts", accounts, value)
+	ret := m.ctrl.Call(m, "FundAccounts", ctx, accounts, value)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FundAccounts indicates an expected call of FundAccounts.
-func (mr *MockAppContextMockRecorder) FundAccounts(accounts, value any) *gomock.Call {
+func (mr *MockAppContextMockRecorder) FundAccounts(ctx, accounts, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FundAccounts", reflect.TypeOf((*MockAppContext)(nil).FundAccounts), accounts, value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FundAccounts", reflect.TypeOf((*MockAppContext)(nil).FundAccounts), ctx, accounts, value)
 }
 
 // GetClient mocks base method.
@@ -100,33 +101,33 @@ func (mr *MockAppContextMockRecorder) GetClient() *gomock.Call {
 }
 
 // GetReceipt mocks base method.
-func (m *MockAppContext) GetReceipt(txHash common.Hash) (*types.Receipt, error) {
+func (m *MockAppContext) GetReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReceipt", txHash)
+	ret := m.ctrl.Call(m, "GetReceipt", ctx, txHash)
 	ret0, _ := ret[0].(*types.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetReceipt indicates an expected call of GetReceipt.
-func (mr *MockAppContextMockRecorder) GetReceipt(txHash any) *gomock.Call {
+func (mr *MockAppContextMockRecorder) GetReceipt(ctx, txHash any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockAppContext)(nil).GetReceipt), txHash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockAppContext)(nil).GetReceipt), ctx, txHash)
 }
 
 // GetTransactOptions mocks base method.
-func (m *MockAppContext) GetTransactOptions(account *Account) (*bind.TransactOpts, error) {
+func (m *MockAppContext) GetTransactOptions(ctx context.Context, account *Account) (*bind.TransactOpts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactOptions", account)
+	ret := m.ctrl.Call(m, "GetTransactOptions", ctx, account)
 	ret0, _ := ret[0].(*bind.TransactOpts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTransactOptions indicates an expected call of GetTransactOptions.
-func (mr *MockAppContextMockRecorder) GetTransactOptions(account any) *gomock.Call {
+func (mr *MockAppContextMockRecorder) GetTransactOptions(ctx, account any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactOptions", reflect.TypeOf((*MockAppContext)(nil).GetTransactOptions), account)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactOptions", reflect.TypeOf((*MockAppContext)(nil).GetTransactOptions), ctx, account)
 }
 
 // GetTreasure mocks base method.
@@ -144,18 +145,18 @@ func (mr *MockAppContextMockRecorder) GetTreasure() *gomock.Call {
 }
 
 // Run mocks base method.
-func (m *MockAppContext) Run(operation func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
+func (m *MockAppContext) Run(ctx context.Context, operation func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Run", operation)
+	ret := m.ctrl.Call(m, "Run", ctx, operation)
 	ret0, _ := ret[0].(*types.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Run indicates an expected call of Run.
-func (mr *MockAppContextMockRecorder) Run(operation any) *gomock.Call {
+func (mr *MockAppContextMockRecorder) Run(ctx, operation any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAppContext)(nil).Run), operation)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAppContext)(nil).Run), ctx, operation)
 }
 
 // MockRpcClientFactory is a mock of RpcClientFactory interface.
@@ -0,0 +1,291 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ContractApplicationType is the type of applications calling a
+// user-supplied contract.
+const ContractApplicationType = "contract"
+
+// ContractConfig defines a user-supplied contract deployed by a contract
+// application, and the calls sent to it by the users of the application.
+type ContractConfig struct {
+	// Bytecode is the path of a file holding the hex-encoded bytecode of the
+	// contract, as produced by solc --bin.
+	Bytecode string
+	// Abi is the path of a file holding the ABI of the contract in JSON
+	// format, as produced by solc --abi.
+	Abi string
+	// ConstructorArgs are the arguments passed to the constructor.
+	ConstructorArgs []string
+	// Function is the name of the function called by the users.
+	Function string
+	// Args are generators for the arguments of each call, see
+	// parseArgGenerator for the supported generators.
+	Args []string
+	// GasLimit is the gas limit of each call.
+	GasLimit uint64
+	// Counter is the name of a view function returning the number of received
+	// transactions. If empty, transactions are counted by the nonces of the
+	// users.
+	Counter string
+}
+
+// contractSpec is a contract configuration with loaded files and parsed
+// argument generators.
+type contractSpec struct {
+	abi             *abi.ABI
+	bytecode        []byte
+	constructorArgs []argGenerator
+	function        string
+	args            []argGenerator
+	gasLimit        uint64
+	counter         string
+}
+
+// Type returns the type of applications configured by contracts.
+func (config ContractConfig) Type() string {
+	return ContractApplicationType
+}
+
+// Check tests whether the contract can be loaded and its arguments match the
+// ABI of the contract.
+func (config ContractConfig) Check() error {
+	_, err := loadContract(config)
+	return err
+}
+
+// loadContract reads the bytecode and ABI of a contract and parses the
+// argument generators of its constructor and the called function.
+func loadContract(config ContractConfig) (*contractSpec, error) {
+	code, err := os.ReadFile(config.Bytecode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bytecode; %w", err)
+	}
+	bytecode, err := hexutil.Decode("0x" + strings.TrimPrefix(strings.TrimSpace(string(code)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode in %s; %w", config.Bytecode, err)
+	}
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("bytecode in %s is empty", config.Bytecode)
+	}
+
+	file, err := os.Open(config.Abi)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ABI; %w", err)
+	}
+	defer file.Close()
+	parsedAbi, err := abi.JSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI in %s; %w", config.Abi, err)
+	}
+
+	constructorArgs, err := parseArgGenerators(parsedAbi.Constructor.Inputs, config.ConstructorArgs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constructor arguments; %w", err)
+	}
+
+	method, found := parsedAbi.Methods[config.Function]
+	if !found {
+		return nil, fmt.Errorf("function %s not found in ABI", config.Function)
+	}
+	args, err := parseArgGenerators(method.Inputs, config.Args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments of function %s; %w", config.Function, err)
+	}
+
+	if config.GasLimit == 0 {
+		return nil, fmt.Errorf("gas limit must be positive")
+	}
+
+	if config.Counter != "" {
+		counter, found := parsedAbi.Methods[config.Counter]
+		if !found {
+			return nil, fmt.Errorf("counter function %s not found in ABI", config.Counter)
+		}
+		if len(counter.Inputs) != 0 || len(counter.Outputs) != 1 || !isIntegerType(counter.Outputs[0].Type) {
+			return nil, fmt.Errorf("counter function %s must take no arguments and return an integer", config.Counter)
+		}
+	}
+
+	return &contractSpec{
+		abi:             &parsedAbi,
+		bytecode:        bytecode,
+		constructorArgs: constructorArgs,
+		function:        config.Function,
+		args:            args,
+		gasLimit:        config.GasLimit,
+		counter:         config.Counter,
+	}, nil
+}
+
+// NewContractApplication deploys a user-supplied contract to the chain. The
+// users of the application call a configured function of the contract with
+// generated arguments, such that contracts can be benchmarked without
+// writing a dedicated application.
+func NewContractApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	contractConfig, found := getConfig[ContractConfig](config)
+	if !found {
+		return nil, fmt.Errorf("contract application requires a contract configuration")
+	}
+	spec, err := loadContract(contractConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client := ctxt.GetClient()
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	// The treasure deploys the contract, so it is the sender of constructor arguments.
+	deployer := ctxt.GetTreasure().address
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	constructorArgs := make([]any, 0, len(spec.constructorArgs))
+	for _, generate := range spec.constructorArgs {
+		constructorArgs = append(constructorArgs, generate(deployer, random))
+	}
+	deploy := func(opts *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *bind.BoundContract, error) {
+		return bind.DeployContract(opts, *spec.abi, spec.bytecode, backend, constructorArgs...)
+	}
+	_, receipt, err := DeployContract(ctx, ctxt, deploy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy contract; %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("deployment of contract was reverted")
+	}
+
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContractApplication{
+		spec:            spec,
+		contractAddress: receipt.ContractAddress,
+		accountFactory:  accountFactory,
+	}, nil
+}
+
+// ContractApplication represents a user-supplied contract called by its users.
+// While the application is thread-safe, each created user should be used in a
+// single thread only.
+type ContractApplication struct {
+	spec            *contractSpec
+	contractAddress common.Address
+	accountFactory  *AccountFactory
+	senders         nonceCounter
+}
+
+// CreateUsers creates a list of new users for the app.
+func (f *ContractApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		users[i] = &ContractUser{
+			spec:     f.spec,
+			sender:   workerAccount,
+			contract: f.contractAddress,
+			random:   rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))),
+		}
+		addresses[i] = workerAccount.address
+		f.senders.add(workerAccount)
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
+	return users, err
+}
+
+// GetReceivedTransactions obtains the number of received transactions from the
+// counter function of the contract, or from the nonces of the users if the
+// contract has none.
+func (f *ContractApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	if f.spec.counter == "" {
+		return f.senders.getIncludedTransactions(ctx, rpcClient)
+	}
+	data, err := f.spec.abi.Pack(f.spec.counter)
+	if err != nil {
+		return 0, err
+	}
+	result, err := rpcClient.CallContract(ctx, ethereum.CallMsg{To: &f.contractAddress, Data: data}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call counter function %s; %w", f.spec.counter, err)
+	}
+	values, err := f.spec.abi.Unpack(f.spec.counter, result)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode result of counter function %s; %w", f.spec.counter, err)
+	}
+	return toUint64(values[0])
+}
+
+// ContractUser represents a user calling a function of a user-supplied contract.
+// A user is supposed to be used in a single thread.
+type ContractUser struct {
+	spec     *contractSpec
+	sender   *Account
+	contract common.Address
+	random   *rand.Rand
+	sentTxs  atomic.Uint64
+}
+
+func (g *ContractUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	args := make([]any, 0, len(g.spec.args))
+	for _, generate := range g.spec.args {
+		args = append(args, generate(g.sender.address, g.random))
+	}
+	data, err := g.spec.abi.Pack(g.spec.function, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
+	}
+
+	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, g.spec.gasLimit)
+	if err == nil {
+		g.sentTxs.Add(1)
+	}
+	return tx, err
+}
+
+func (g *ContractUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/mock/gomock"
+)
+
+// writeCounterContract writes the bytecode and ABI of the Counter contract
+// into files of a temporary directory and returns a configuration using them.
+func writeCounterContract(t *testing.T) ContractConfig {
+	t.Helper()
+	dir := t.TempDir()
+	config := ContractConfig{
+		Bytecode: filepath.Join(dir, "Counter.bin"),
+		Abi:      filepath.Join(dir, "Counter.abi"),
+		Function: "incrementCounter",
+		GasLimit: 28036,
+		Counter:  "getCount",
+	}
+	if err := os.WriteFile(config.Bytecode, []byte(strings.TrimPrefix(contract.CounterBin, "0x")+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write bytecode: %v", err)
+	}
+	if err := os.WriteFile(config.Abi, []byte(contract.CounterMetaData.ABI), 0600); err != nil {
+		t.Fatalf("failed to write ABI: %v", err)
+	}
+	return config
+}
+
+func TestLoadContract_ContractFilesAreLoaded(t *testing.T) {
+	spec, err := loadContract(writeCounterContract(t))
+	if err != nil {
+		t.Fatalf("failed to load contract: %v", err)
+	}
+	if got, want := common.Bytes2Hex(spec.bytecode), strings.TrimPrefix(contract.CounterBin, "0x"); got != want {
+		t.Errorf("unexpected bytecode, wanted %s, got %s", want, got)
+	}
+	if _, found := spec.abi.Methods["incrementCounter"]; !found {
+		t.Errorf("ABI was not loaded")
+	}
+}
+
+func TestLoadContract_InvalidConfigurationsAreRejected(t *testing.T) {
+	tests := map[string]func(*ContractConfig){
+		"failed to read bytecode":    func(c *ContractConfig) { c.Bytecode = "missing.bin" },
+		"failed to read ABI":         func(c *ContractConfig) { c.Abi = "missing.abi" },
+		"not found in ABI":           func(c *ContractConfig) { c.Function = "unknown" },
+		"invalid arguments":          func(c *ContractConfig) { c.Args = []string{"1"} },
+		"invalid constructor":        func(c *ContractConfig) { c.ConstructorArgs = []string{"1"} },
+		"gas limit must be positive": func(c *ContractConfig) { c.GasLimit = 0 },
+		"counter function":           func(c *ContractConfig) { c.Counter = "incrementCounter" },
+	}
+	for issue, modify := range tests {
+		config := writeCounterContract(t)
+		modify(&config)
+		if _, err := loadContract(config); err == nil || !strings.Contains(err.Error(), issue) {
+			t.Errorf("issue %q was not detected, got %v", issue, err)
+		}
+	}
+}
+
+func TestContractUser_ConfiguredFunctionIsCalled(t *testing.T) {
+	spec, err := loadContract(writeCounterContract(t))
+	if err != nil {
+		t.Fatalf("failed to load contract: %v", err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	user := &ContractUser{
+		spec:     spec,
+		sender:   &Account{privateKey: key, chainID: big.NewInt(0xFA)},
+		contract: common.Address{1},
+		random:   rand.New(rand.NewSource(0)),
+	}
+	tx, err := user.GenerateTx(big.NewInt(1_000))
+	if err != nil {
+		t.Fatalf("failed to generate transaction: %v", err)
+	}
+	want, err := spec.abi.Pack("incrementCounter")
+	if err != nil {
+		t.Fatalf("failed to pack call: %v", err)
+	}
+	if *tx.To() != user.contract || string(tx.Data()) != string(want) || tx.Gas() != 28036 {
+		t.Errorf("unexpected transaction, to %v, data %x, gas %d", tx.To(), tx.Data(), tx.Gas())
+	}
+	if got := user.GetSentTransactions(); got != 1 {
+		t.Errorf("unexpected number of sent transactions, wanted 1, got %d", got)
+	}
+}
+
+func TestContractApplication_ReceivedTransactionsAreObtainedFromCounter(t *testing.T) {
+	spec, err := loadContract(writeCounterContract(t))
+	if err != nil {
+		t.Fatalf("failed to load contract: %v", err)
+	}
+	result, err := spec.abi.Methods["getCount"].Outputs.Pack(big.NewInt(12))
+	if err != nil {
+		t.Fatalf("failed to pack result: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), nil).Return(result, nil)
+
+	application := &ContractApplication{spec: spec, contractAddress: common.Address{1}}
+	received, err := application.GetReceivedTransactions(context.Background(), rpcClient)
+	if err != nil || received != 12 {
+		t.Errorf("unexpected received transactions, wanted 12, got %d, %v", received, err)
+	}
+}
+
+func TestContractApplication_ReceivedTransactionsAreCountedByNoncesWithoutCounter(t *testing.T) {
+	config := writeCounterContract(t)
+	config.Counter = ""
+	spec, err := loadContract(config)
+	if err != nil {
+		t.Fatalf("failed to load contract: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), common.Address{2}, nil).Return(uint64(5), nil)
+
+	application := &ContractApplication{spec: spec}
+	application.senders.add(&Account{address: common.Address{2}, nonce: 1})
+	received, err := application.GetReceivedTransactions(context.Background(), rpcClient)
+	if err != nil || received != 4 {
+		t.Errorf("unexpected received transactions, wanted 4, got %d, %v", received, err)
+	}
+}
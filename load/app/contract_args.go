@@ -0,0 +1,196 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// argGenerator produces the value of an argument of a contract call sent by
+// the given sender.
+type argGenerator func(sender common.Address, random *rand.Rand) any
+
+// senderArg is the generator producing the address of the sender.
+const senderArg = "sender"
+
+// randomArgPattern matches generators of random integers within an inclusive
+// range, e.g. random(1,100).
+var randomArgPattern = regexp.MustCompile(`^random\(\s*(-?\w+)\s*,\s*(-?\w+)\s*\)$`)
+
+// parseArgGenerators parses the generators of the given arguments of a
+// function.
+func parseArgGenerators(arguments abi.Arguments, generators []string) ([]argGenerator, error) {
+	if len(arguments) != len(generators) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(arguments), len(generators))
+	}
+	res := make([]argGenerator, 0, len(generators))
+	for i, generator := range generators {
+		parsed, err := parseArgGenerator(arguments[i].Type, generator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %d; %w", i, err)
+		}
+		res = append(res, parsed)
+	}
+	return res, nil
+}
+
+// parseArgGenerator parses the generator of an argument of the given type.
+// Supported generators are
+//   - sender ... the address of the sender of the call, for address arguments
+//   - random(min,max) ... a random integer in the inclusive range, for integer arguments
+//   - constants, e.g. 42, 0x2a, true, 0x00...01 or text, for arguments of the respective type
+func parseArgGenerator(argType abi.Type, generator string) (argGenerator, error) {
+	generator = strings.TrimSpace(generator)
+	if generator == senderArg {
+		if argType.T != abi.AddressTy {
+			return nil, fmt.Errorf("%s can only be used for addresses, got %v", senderArg, argType)
+		}
+		return func(sender common.Address, _ *rand.Rand) any {
+			return sender
+		}, nil
+	}
+
+	if match := randomArgPattern.FindStringSubmatch(generator); match != nil {
+		if !isIntegerType(argType) {
+			return nil, fmt.Errorf("random values can only be used for integers, got %v", argType)
+		}
+		low, ok := new(big.Int).SetString(match[1], 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid lower bound %s", match[1])
+		}
+		high, ok := new(big.Int).SetString(match[2], 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid upper bound %s", match[2])
+		}
+		if low.Cmp(high) > 0 {
+			return nil, fmt.Errorf("empty range %v", generator)
+		}
+		for _, bound := range []*big.Int{low, high} {
+			if _, err := toIntegerArg(argType, bound); err != nil {
+				return nil, err
+			}
+		}
+		size := new(big.Int).Add(new(big.Int).Sub(high, low), big.NewInt(1))
+		return func(_ common.Address, random *rand.Rand) any {
+			value := new(big.Int).Add(low, new(big.Int).Rand(random, size))
+			res, _ := toIntegerArg(argType, value)
+			return res
+		}, nil
+	}
+
+	value, err := parseConstantArg(argType, generator)
+	if err != nil {
+		return nil, err
+	}
+	return func(common.Address, *rand.Rand) any {
+		return value
+	}, nil
+}
+
+// parseConstantArg parses a constant value of the given type.
+func parseConstantArg(argType abi.Type, value string) (any, error) {
+	switch argType.T {
+	case abi.IntTy, abi.UintTy:
+		parsed, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", value)
+		}
+		return toIntegerArg(argType, parsed)
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %s", value)
+		}
+		return common.HexToAddress(value), nil
+	case abi.StringTy:
+		return value, nil
+	case abi.BytesTy:
+		return hexutil.Decode(value)
+	case abi.FixedBytesTy:
+		bytes, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes) > argType.Size {
+			return nil, fmt.Errorf("value %s exceeds %d bytes", value, argType.Size)
+		}
+		res := reflect.New(argType.GetType()).Elem()
+		reflect.Copy(res, reflect.ValueOf(bytes))
+		return res.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported argument type %v", argType)
+}
+
+// isIntegerType checks whether the given type is a signed or unsigned integer.
+func isIntegerType(argType abi.Type) bool {
+	return argType.T == abi.IntTy || argType.T == abi.UintTy
+}
+
+// toIntegerArg converts an integer into the Go type used by the ABI encoder
+// for the given integer type, which is *big.Int for types of more than 64 bits.
+func toIntegerArg(argType abi.Type, value *big.Int) (any, error) {
+	if argType.T == abi.UintTy && (value.Sign() < 0 || value.BitLen() > argType.Size) {
+		return nil, fmt.Errorf("value %v out of range of %v", value, argType)
+	}
+	if argType.T == abi.IntTy {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(argType.Size-1))
+		if value.Cmp(limit) >= 0 || value.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value %v out of range of %v", value, argType)
+		}
+	}
+	goType := argType.GetType()
+	switch goType.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.ValueOf(value.Uint64()).Convert(goType).Interface(), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(value.Int64()).Convert(goType).Interface(), nil
+	}
+	return new(big.Int).Set(value), nil
+}
+
+// toUint64 converts an integer decoded by the ABI decoder into an uint64.
+func toUint64(value any) (uint64, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v.Sign() < 0 || !v.IsUint64() {
+			return 0, fmt.Errorf("value %v out of range", v)
+		}
+		return v.Uint64(), nil
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflected.Uint(), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if reflected.Int() < 0 {
+			return 0, fmt.Errorf("value %v out of range", value)
+		}
+		return uint64(reflected.Int()), nil
+	}
+	return 0, fmt.Errorf("unsupported value %v", value)
+}
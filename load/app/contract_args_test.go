@@ -0,0 +1,142 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func parseTestType(t *testing.T, name string) abi.Type {
+	t.Helper()
+	res, err := abi.NewType(name, "", nil)
+	if err != nil {
+		t.Fatalf("failed to create type %s: %v", name, err)
+	}
+	return res
+}
+
+func TestParseArgGenerator_ConstantsAreConvertedToArgumentType(t *testing.T) {
+	tests := []struct {
+		argType   string
+		generator string
+		want      any
+	}{
+		{"uint8", "42", uint8(42)},
+		{"uint64", "0x2a", uint64(42)},
+		{"int16", "-42", int16(-42)},
+		{"uint256", "42", big.NewInt(42)},
+		{"bool", "true", true},
+		{"address", "0x0000000000000000000000000000000000000042", common.HexToAddress("0x42")},
+		{"string", "hello", "hello"},
+		{"bytes", "0x0102", []byte{1, 2}},
+		{"bytes4", "0x0102", [4]byte{1, 2}},
+	}
+	random := rand.New(rand.NewSource(0))
+	for _, test := range tests {
+		generate, err := parseArgGenerator(parseTestType(t, test.argType), test.generator)
+		if err != nil {
+			t.Fatalf("failed to parse %s for %s: %v", test.generator, test.argType, err)
+		}
+		got := generate(common.Address{}, random)
+		if want, ok := test.want.(*big.Int); ok {
+			if value, ok := got.(*big.Int); !ok || value.Cmp(want) != 0 {
+				t.Errorf("unexpected value for %s, wanted %v, got %v", test.argType, want, got)
+			}
+			continue
+		}
+		if gotBytes, ok := got.([]byte); ok {
+			if string(gotBytes) != string(test.want.([]byte)) {
+				t.Errorf("unexpected value for %s, wanted %v, got %v", test.argType, test.want, got)
+			}
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected value for %s, wanted %v (%T), got %v (%T)", test.argType, test.want, test.want, got, got)
+		}
+	}
+}
+
+func TestParseArgGenerator_SenderIsProduced(t *testing.T) {
+	generate, err := parseArgGenerator(parseTestType(t, "address"), "sender")
+	if err != nil {
+		t.Fatalf("failed to parse generator: %v", err)
+	}
+	sender := common.Address{1, 2, 3}
+	if got := generate(sender, nil); got != sender {
+		t.Errorf("unexpected value, wanted %v, got %v", sender, got)
+	}
+}
+
+func TestParseArgGenerator_RandomValuesAreWithinRange(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	generate, err := parseArgGenerator(parseTestType(t, "uint32"), "random(5, 7)")
+	if err != nil {
+		t.Fatalf("failed to parse generator: %v", err)
+	}
+	seen := map[uint32]bool{}
+	for i := 0; i < 100; i++ {
+		value := generate(common.Address{}, random).(uint32)
+		if value < 5 || value > 7 {
+			t.Fatalf("value %d out of range", value)
+		}
+		seen[value] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("not all values of the range were produced, got %v", seen)
+	}
+}
+
+func TestParseArgGenerator_InvalidGeneratorsAreRejected(t *testing.T) {
+	tests := []struct {
+		argType   string
+		generator string
+		issue     string
+	}{
+		{"uint256", "sender", "only be used for addresses"},
+		{"address", "random(1,2)", "only be used for integers"},
+		{"uint8", "random(2,1)", "empty range"},
+		{"uint8", "random(0,256)", "out of range"},
+		{"uint8", "-1", "out of range"},
+		{"int8", "128", "out of range"},
+		{"uint8", "abc", "invalid integer"},
+		{"address", "0x42", "invalid address"},
+		{"bytes2", "0x010203", "exceeds"},
+		{"uint256[]", "1", "unsupported"},
+	}
+	for _, test := range tests {
+		_, err := parseArgGenerator(parseTestType(t, test.argType), test.generator)
+		if err == nil || !strings.Contains(err.Error(), test.issue) {
+			t.Errorf("%s for %s should be rejected with %q, got %v", test.generator, test.argType, test.issue, err)
+		}
+	}
+}
+
+func TestParseArgGenerators_NumberOfArgumentsIsChecked(t *testing.T) {
+	arguments := abi.Arguments{{Type: parseTestType(t, "uint256")}}
+	if _, err := parseArgGenerators(arguments, nil); err == nil {
+		t.Errorf("missing argument should be rejected")
+	}
+	if _, err := parseArgGenerators(arguments, []string{"1", "2"}); err == nil {
+		t.Errorf("additional argument should be rejected")
+	}
+}
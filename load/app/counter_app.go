@@ -25,6 +25,7 @@ import (
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -32,20 +33,20 @@ import (
 // NewCounterApplication deploys a Counter contract to the chain.
 // The Counter contract is a simple contract sustaining an integer value, to be incremented by sent txs.
 // It allows to easily test the tx generating, as reading the contract field provides the amount of applied contract calls.
-func NewCounterApplication(ctxt AppContext, feederId, appId uint32) (Application, error) {
+func NewCounterApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
 	client := ctxt.GetClient()
-	chainId, err := client.ChainID(context.Background())
+	chainId, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID; %w", err)
 	}
 
 	// Deploy the Counter contract to be used by this application.
-	_, receipt, err := DeployContract(ctxt, contract.DeployCounter)
+	_, receipt, err := DeployContract(ctx, ctxt, contract.DeployCounter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy Counter contract; %w", err)
 	}
 
-	accountFactory, err := NewAccountFactory(chainId, feederId, appId)
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
 	if err != nil {
 		return nil, err
 	}
@@ -73,13 +74,13 @@ type CounterApplication struct {
 }
 
 // CreateUsers creates a list of new users for the app.
-func (f *CounterApplication) CreateUsers(appContext AppContext, numUsers int) ([]User, error) {
+func (f *CounterApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
 
 	users := make([]User, numUsers)
 	addresses := make([]common.Address, numUsers)
 	for i := 0; i < numUsers; i++ {
 		// Generate a new account for each worker - avoid account nonces related bottlenecks
-		workerAccount, err := f.accountFactory.CreateAccount(appContext.GetClient())
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
 		if err != nil {
 			return nil, err
 		}
@@ -93,18 +94,18 @@ func (f *CounterApplication) CreateUsers(appContext AppContext, numUsers int) ([
 
 	fundsPerUser := big.NewInt(1_000)
 	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
-	err := appContext.FundAccounts(addresses, fundsPerUser)
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
 
 	return users, err
 }
 
-func (f *CounterApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+func (f *CounterApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
 	// get a representation of the deployed contract
 	counterContract, err := contract.NewCounter(f.contractAddress, rpcClient)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get Counter contract representation; %w", err)
 	}
-	count, err := counterContract.GetCount(nil)
+	count, err := counterContract.GetCount(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		return 0, err
 	}
@@ -127,9 +128,9 @@ func (g *CounterUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction,
 		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
 	}
 
-	// prepare tx
+	// prepare tx, the count is stored in the first slot of the contract
 	const gasLimit = 28036
-	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit)
+	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit, getStorageSlot(0))
 	if err == nil {
 		g.sentTxs.Add(1)
 	}
@@ -0,0 +1,349 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// DeployApplicationType is the type of applications deploying a fresh contract
+// with every transaction.
+const DeployApplicationType = "deploy"
+
+// DefaultDeployedCodeSize is the size of the code of contracts deployed by
+// deploy applications, unless configured otherwise.
+const DefaultDeployedCodeSize = 1024
+
+// DeployConfig defines the contracts deployed by a deploy application.
+type DeployConfig struct {
+	// CodeSize is the size of the code of each deployed contract in bytes,
+	// DefaultDeployedCodeSize if zero.
+	CodeSize int
+	// Factory selects deployments through calls of a CREATE2 factory contract
+	// instead of contract creation transactions.
+	Factory bool
+}
+
+// Type returns the type of applications configured by deployments.
+func (config DeployConfig) Type() string {
+	return DeployApplicationType
+}
+
+// Check tests whether contracts can be deployed as configured.
+func (config DeployConfig) Check() error {
+	if config.CodeSize < 0 || config.CodeSize > params.MaxCodeSize {
+		return fmt.Errorf("code size must be between 0 and %d (0 for the default), got %d", params.MaxCodeSize, config.CodeSize)
+	}
+	return nil
+}
+
+// NewDeployApplication creates an application whose users deploy a fresh
+// contract with each transaction, either by contract creation transactions or
+// through a CREATE2 factory deployed by this function. Every deployed contract
+// has a distinct code of the configured size, such that the load covers
+// contract creation and the growth of the code storage.
+func NewDeployApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	deployConfig, _ := getConfig[DeployConfig](config)
+	if err := deployConfig.Check(); err != nil {
+		return nil, err
+	}
+	codeSize := deployConfig.CodeSize
+	if codeSize == 0 {
+		codeSize = DefaultDeployedCodeSize
+	}
+
+	client := ctxt.GetClient()
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	application := &DeployApplication{codeSize: codeSize}
+	if deployConfig.Factory {
+		factory, err := deployCode(ctx, ctxt, factoryCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy factory; %w", err)
+		}
+		application.factory = &factory
+	}
+
+	application.accountFactory, err = NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// DeployApplication represents contracts deployed by its users.
+// While the application is thread-safe, each created user should be used in a
+// single thread only.
+type DeployApplication struct {
+	codeSize       int
+	factory        *common.Address // nil if contracts are deployed by contract creation transactions
+	senders        nonceCounter    // counts contract creation transactions if no factory is used
+	accountFactory *AccountFactory
+}
+
+// CreateUsers creates a list of new users for the app.
+func (f *DeployApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	accounts := make([]*Account, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		accounts[i] = workerAccount
+		users[i] = &DeployUser{
+			sender:   workerAccount,
+			codeSize: f.codeSize,
+			factory:  f.factory,
+			random:   rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))),
+		}
+		addresses[i] = workerAccount.address
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	if err := appContext.FundAccounts(ctx, addresses, fundsPerUser); err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		f.senders.add(account)
+	}
+	return users, nil
+}
+
+// GetReceivedTransactions obtains the number of successful deployments from
+// the counter of the factory, or from the nonces of the users if no factory
+// is used. Contract creation transactions are sent with sufficient gas, such
+// that included transactions are successful deployments.
+func (f *DeployApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	if f.factory == nil {
+		return f.senders.getIncludedTransactions(ctx, rpcClient)
+	}
+	return getCounter(ctx, rpcClient, *f.factory)
+}
+
+// DeployUser represents a user deploying contracts.
+// A user is supposed to be used in a single thread.
+type DeployUser struct {
+	sender   *Account
+	codeSize int
+	factory  *common.Address
+	random   *rand.Rand
+	sentTxs  atomic.Uint64
+}
+
+func (g *DeployUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	code := getCreationCode(newContractCode(g.codeSize, g.random))
+
+	var tx *types.Transaction
+	var err error
+	if g.factory != nil {
+		tx, err = createTx(g.sender, *g.factory, big.NewInt(0), code, currentGasPrice, getFactoryCallGas(code, g.codeSize))
+	} else {
+		tx, err = createDeploymentTx(g.sender, code, currentGasPrice, getDeploymentGas(code, g.codeSize))
+	}
+	if err != nil {
+		return nil, err
+	}
+	g.sentTxs.Add(1)
+	return tx, nil
+}
+
+func (g *DeployUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
+
+// newContractCode creates random contract code of the given size, such that
+// each deployed contract has a distinct code. The code starts with a STOP
+// instruction, so it is not executed, and is not rejected by EIP-3541.
+func newContractCode(size int, random *rand.Rand) []byte {
+	code := make([]byte, size)
+	random.Read(code[1:])
+	code[0] = byte(vm.STOP)
+	return code
+}
+
+// creationPrefixLength is the length of the instructions preceding the
+// deployed code in creation code produced by getCreationCode.
+const creationPrefixLength = 12
+
+// getCreationCode produces creation code deploying the given contract code.
+func getCreationCode(code []byte) []byte {
+	return append([]byte{
+		byte(vm.PUSH2), byte(len(code) >> 8), byte(len(code)),
+		byte(vm.DUP1),
+		byte(vm.PUSH1), creationPrefixLength,
+		byte(vm.PUSH1), 0,
+		byte(vm.CODECOPY), // memory[0:size] = code[prefix:prefix+size]
+		byte(vm.PUSH1), 0,
+		byte(vm.RETURN), // return memory[0:size]
+	}, code...)
+}
+
+// deployCode deploys a contract with the given code, sent by the treasure
+// account, and returns the address of the contract.
+func deployCode(ctx context.Context, ctxt AppContext, code []byte) (common.Address, error) {
+	creationCode := getCreationCode(code)
+	deploy := func(opts *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *bind.BoundContract, error) {
+		opts.GasLimit = getDeploymentGas(creationCode, len(code))
+		return bind.DeployContract(opts, abi.ABI{}, creationCode, backend)
+	}
+	_, receipt, err := DeployContract(ctx, ctxt, deploy)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, fmt.Errorf("deployment was reverted")
+	}
+	return receipt.ContractAddress, nil
+}
+
+// getCounter obtains the counter of a contract returning the counter when
+// called without data, like the factory.
+func getCounter(ctx context.Context, rpcClient rpc.RpcClient, contract common.Address) (uint64, error) {
+	result, err := rpcClient.CallContract(ctx, ethereum.CallMsg{To: &contract}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get counter of %v; %w", contract, err)
+	}
+	return new(big.Int).SetBytes(result).Uint64(), nil
+}
+
+// factoryCode is the code of a contract deploying the creation code passed as
+// call data with CREATE2, using the number of previous deployments as salt.
+// Calls without data return the number of deployments.
+var factoryCode = []byte{
+	byte(vm.CALLDATASIZE),
+	byte(vm.PUSH1), 0x0f, // deploy
+	byte(vm.JUMPI),
+	// return the counter in storage slot 0
+	byte(vm.PUSH1), 0,
+	byte(vm.SLOAD),
+	byte(vm.PUSH1), 0,
+	byte(vm.MSTORE),
+	byte(vm.PUSH1), 32,
+	byte(vm.PUSH1), 0,
+	byte(vm.RETURN),
+	// deploy: copy the call data into memory and deploy it
+	byte(vm.JUMPDEST),
+	byte(vm.CALLDATASIZE),
+	byte(vm.PUSH1), 0,
+	byte(vm.PUSH1), 0,
+	byte(vm.CALLDATACOPY),
+	byte(vm.PUSH1), 0,
+	byte(vm.SLOAD), // salt
+	byte(vm.CALLDATASIZE),
+	byte(vm.PUSH1), 0,
+	byte(vm.PUSH1), 0, // value
+	byte(vm.CREATE2),
+	byte(vm.PUSH1), 0x26, // increment
+	byte(vm.JUMPI),
+	byte(vm.PUSH1), 0,
+	byte(vm.DUP1),
+	byte(vm.REVERT),
+	// increment: count the successful deployment
+	byte(vm.JUMPDEST),
+	byte(vm.PUSH1), 0,
+	byte(vm.SLOAD),
+	byte(vm.PUSH1), 1,
+	byte(vm.ADD),
+	byte(vm.PUSH1), 0,
+	byte(vm.SSTORE),
+	byte(vm.STOP),
+}
+
+// deploymentGasMargin is added to the gas needed by deployments to cover the
+// execution of the few instructions not accounted for individually.
+const deploymentGasMargin = 10_000
+
+// getDeploymentGas returns a gas limit covering a contract creation
+// transaction running the given creation code, which deploys a contract with
+// code of the given size.
+func getDeploymentGas(creationCode []byte, codeSize int) uint64 {
+	words := toWordSize(len(creationCode))
+	return params.TxGasContractCreation +
+		getCalldataGas(creationCode) +
+		params.InitCodeWordGas*words +
+		getCreationExecutionGas(codeSize) +
+		deploymentGasMargin
+}
+
+// getFactoryCallGas returns a gas limit covering a call of the factory
+// deploying the given creation code, which deploys a contract with code of
+// the given size.
+func getFactoryCallGas(creationCode []byte, codeSize int) uint64 {
+	words := toWordSize(len(creationCode))
+	gas := params.TxGas +
+		getCalldataGas(creationCode) +
+		params.CopyGas*words + getMemoryGas(words) + // copy of the call data
+		params.Create2Gas + (params.Keccak256WordGas+params.InitCodeWordGas)*words +
+		getCreationExecutionGas(codeSize) +
+		params.ColdSloadCostEIP2929 + params.SstoreSetGasEIP2200 // update of the counter
+	// Only 63/64 of the remaining gas are passed on to the creation code.
+	return gas + gas/10 + deploymentGasMargin
+}
+
+// getCreationExecutionGas returns the gas used by creation code produced by
+// getCreationCode for contract code of the given size.
+func getCreationExecutionGas(codeSize int) uint64 {
+	words := toWordSize(codeSize)
+	return params.CopyGas*words + getMemoryGas(words) + params.CreateDataGas*uint64(codeSize)
+}
+
+// getCalldataGas returns the intrinsic gas of the given transaction data.
+func getCalldataGas(data []byte) uint64 {
+	gas := uint64(0)
+	for _, b := range data {
+		if b == 0 {
+			gas += params.TxDataZeroGas
+		} else {
+			gas += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	return gas
+}
+
+// getMemoryGas returns the gas of expanding the memory to the given number of
+// words.
+func getMemoryGas(words uint64) uint64 {
+	return params.MemoryGas*words + words*words/params.QuadCoeffDiv
+}
+
+// toWordSize returns the number of 32-byte words needed to hold the given
+// number of bytes.
+func toWordSize(size int) uint64 {
+	return (uint64(size) + 31) / 32
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeployConfig_InvalidCodeSizesAreRejected(t *testing.T) {
+	for _, size := range []int{0, 1, params.MaxCodeSize} {
+		if err := (DeployConfig{CodeSize: size}).Check(); err != nil {
+			t.Errorf("code size %d should be accepted, got %v", size, err)
+		}
+	}
+	for _, size := range []int{-1, params.MaxCodeSize + 1} {
+		if err := (DeployConfig{CodeSize: size}).Check(); err == nil || !strings.Contains(err.Error(), "code size must be between 0 and 24576 (0 for the default)") {
+			t.Errorf("code size %d should be rejected, got %v", size, err)
+		}
+	}
+}
+
+func TestGetCreationCode_ContractsOfGivenSizeAreDeployed(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	for _, size := range []int{1, 32, 1000, params.MaxCodeSize} {
+		code := newContractCode(size, random)
+		creationCode := getCreationCode(code)
+		// The limit of the transaction without the intrinsic gas is available to the creation code.
+		gas := getDeploymentGas(creationCode, size) - params.TxGasContractCreation - getCalldataGas(creationCode)
+		deployed, _, _, err := runtime.Create(creationCode, &runtime.Config{GasLimit: gas})
+		if err != nil {
+			t.Fatalf("failed to deploy contract of size %d: %v", size, err)
+		}
+		if string(deployed) != string(code) {
+			t.Errorf("unexpected code of contract of size %d", size)
+		}
+	}
+}
+
+func TestFactory_ContractsAreDeployedAndCounted(t *testing.T) {
+	config := &runtime.Config{}
+	_, factory, _, err := runtime.Create(getCreationCode(factoryCode), config)
+	if err != nil {
+		t.Fatalf("failed to deploy factory: %v", err)
+	}
+
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < 3; i++ {
+		code := newContractCode(params.MaxCodeSize, random)
+		creationCode := getCreationCode(code)
+		config.GasLimit = getFactoryCallGas(creationCode, len(code)) - params.TxGas - getCalldataGas(creationCode)
+		if _, _, err := runtime.Call(factory, creationCode, config); err != nil {
+			t.Fatalf("failed to deploy contract %d: %v", i, err)
+		}
+		address := crypto.CreateAddress2(factory, common.BigToHash(big.NewInt(int64(i))), crypto.Keccak256(creationCode))
+		if got := config.State.GetCode(address); string(got) != string(code) {
+			t.Errorf("contract %d was not deployed", i)
+		}
+	}
+
+	config.GasLimit = 0
+	counter, _, err := runtime.Call(factory, nil, config)
+	if err != nil {
+		t.Fatalf("failed to get counter: %v", err)
+	}
+	if got := new(big.Int).SetBytes(counter); got.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("unexpected counter, wanted 3, got %v", got)
+	}
+}
+
+func TestDeployUser_ContractsAreDeployedDirectlyOrThroughFactory(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	factory := common.Address{1}
+	for _, factory := range []*common.Address{nil, &factory} {
+		user := &DeployUser{
+			sender:   &Account{privateKey: key, chainID: big.NewInt(0xFA)},
+			codeSize: 100,
+			factory:  factory,
+			random:   rand.New(rand.NewSource(0)),
+		}
+		tx, err := user.GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		if factory == nil && tx.To() != nil || factory != nil && *tx.To() != *factory {
+			t.Errorf("unexpected recipient, wanted %v, got %v", factory, tx.To())
+		}
+		if len(tx.Data()) != creationPrefixLength+100 {
+			t.Errorf("unexpected length of creation code, got %d", len(tx.Data()))
+		}
+		if got := user.GetSentTransactions(); got != 1 {
+			t.Errorf("unexpected number of sent transactions, wanted 1, got %d", got)
+		}
+	}
+}
+
+func TestDeployApplication_ReceivedTransactionsAreObtainedFromFactory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	factory := common.Address{1}
+	rpcClient.EXPECT().CallContract(gomock.Any(), ethereum.CallMsg{To: &factory}, nil).Return(common.BigToHash(big.NewInt(12)).Bytes(), nil)
+
+	application := &DeployApplication{factory: &factory}
+	received, err := application.GetReceivedTransactions(context.Background(), rpcClient)
+	if err != nil || received != 12 {
+		t.Errorf("unexpected received transactions, wanted 12, got %d, %v", received, err)
+	}
+}
+
+func TestDeployApplication_ReceivedTransactionsAreObtainedFromNonces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	sender := &Account{address: common.Address{1}, nonce: 2}
+	application := &DeployApplication{}
+	application.senders.add(sender)
+
+	rpcClient.EXPECT().NonceAt(gomock.Any(), sender.address, nil).Return(uint64(7), nil)
+	received, err := application.GetReceivedTransactions(context.Background(), rpcClient)
+	if err != nil || received != 5 {
+		t.Errorf("unexpected received transactions, wanted 5, got %d, %v", received, err)
+	}
+}
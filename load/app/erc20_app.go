@@ -17,6 +17,7 @@
 package app
 
 import (
+	"context"
 	crand "crypto/rand"
 	"fmt"
 	"math/big"
@@ -33,12 +34,12 @@ import (
 
 // NewERC20Application deploys a new ERC-20 dapp to the chain.
 // The ERC20 contract is a contract sustaining balances of the token for individual owner addresses.
-func NewERC20Application(ctxt AppContext, feederId, appId uint32) (Application, error) {
+func NewERC20Application(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
 	rpcClient := ctxt.GetClient()
 	primaryAccount := ctxt.GetTreasure()
 
 	// Deploy the ERC20 contract to be used by generators created using the factory
-	txOpts, err := ctxt.GetTransactOptions(primaryAccount)
+	txOpts, err := ctxt.GetTransactOptions(ctx, primaryAccount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %w", err)
 	}
@@ -51,7 +52,7 @@ func NewERC20Application(ctxt AppContext, feederId, appId uint32) (Application,
 		return nil, fmt.Errorf("failed to generate recipients addresses; %w", err)
 	}
 
-	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId, config.TxType)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +64,7 @@ func NewERC20Application(ctxt AppContext, feederId, appId uint32) (Application,
 	}
 
 	// wait until the contract will be available on the chain (and will be possible to call CreateGenerator)
-	_, err = ctxt.GetReceipt(transaction.Hash())
+	_, err = ctxt.GetReceipt(ctx, transaction.Hash())
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait until the ERC20 contract is deployed; %w", err)
 	}
@@ -97,14 +98,14 @@ type ERC20Application struct {
 }
 
 // CreateUsers creates a list of new users for the app.
-func (f *ERC20Application) CreateUsers(appContext AppContext, numUsers int) ([]User, error) {
+func (f *ERC20Application) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
 
 	// Create a list of users.
 	users := make([]User, numUsers)
 	addresses := make([]common.Address, numUsers)
 	for i := 0; i < numUsers; i++ {
 		// Generate a new account for each worker - avoid account nonces related bottlenecks
-		workerAccount, err := f.accountFactory.CreateAccount(appContext.GetClient())
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
 		if err != nil {
 			return nil, err
 		}
@@ -120,7 +121,7 @@ func (f *ERC20Application) CreateUsers(appContext AppContext, numUsers int) ([]U
 	// Provide native currency to each user.
 	fundsPerUser := big.NewInt(1_000)
 	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
-	err := appContext.FundAccounts(addresses, fundsPerUser)
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fund accounts; %w", err)
 	}
@@ -130,7 +131,7 @@ func (f *ERC20Application) CreateUsers(appContext AppContext, numUsers int) ([]U
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ERC20 contract representation; %w", err)
 	}
-	receipt, err := appContext.Run(func(opts *bind.TransactOpts) (*types.Transaction, error) {
+	receipt, err := appContext.Run(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		return erc20Contract.MintForAll(opts, addresses, big.NewInt(1_000000000000000000))
 	})
 	if err != nil {
@@ -142,7 +143,7 @@ func (f *ERC20Application) CreateUsers(appContext AppContext, numUsers int) ([]U
 	return users, nil
 }
 
-func (f *ERC20Application) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+func (f *ERC20Application) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
 	// get a representation of the deployed contract
 	ERC20Contract, err := contract.NewERC20(f.contractAddress, rpcClient)
 	if err != nil {
@@ -150,7 +151,7 @@ func (f *ERC20Application) GetReceivedTransactions(rpcClient rpc.RpcClient) (uin
 	}
 	totalReceived := uint64(0)
 	for _, recipient := range f.recipients {
-		recipientBalance, err := ERC20Contract.BalanceOf(nil, recipient)
+		recipientBalance, err := ERC20Contract.BalanceOf(&bind.CallOpts{Context: ctx}, recipient)
 		if err != nil {
 			return 0, err
 		}
@@ -179,9 +180,14 @@ func (g *ERC20User) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, er
 		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
 	}
 
-	// prepare tx
+	// prepare tx, touching the balances of the sender and the recipient in
+	// the balanceOf mapping in the second slot of the contract
 	const gasLimit = 52000 // Transfer method call takes 51349 of gas
-	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit)
+	balances := getStorageSlot(1)
+	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit,
+		getMappingSlot(common.BytesToHash(g.sender.address.Bytes()), balances),
+		getMappingSlot(common.BytesToHash(recipient.Bytes()), balances),
+	)
 	if err == nil {
 		atomic.AddUint64(&g.sentTxs, 1)
 	}
@@ -17,15 +17,16 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
-type appFactoryFunc func(context AppContext, feederId, appId uint32) (Application, error)
+type appFactoryFunc func(ctx context.Context, context AppContext, feederId, appId uint32, config AppConfig) (Application, error)
 
-func NewApplication(appType string, context AppContext, feederId, appId uint32) (Application, error) {
+func NewApplication(ctx context.Context, appType string, context AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
 	if factory := getFactory(appType); factory != nil {
-		return factory(context, feederId, appId)
+		return factory(ctx, context, feederId, appId, config)
 	}
 	return nil, fmt.Errorf("unknown application type '%s'", appType)
 }
@@ -42,8 +43,24 @@ func getFactory(appType string) appFactoryFunc {
 		return NewCounterApplication
 	case "store":
 		return NewStoreApplication
-	case "uniswap":
+	case UniswapApplicationType:
 		return NewUniswapApplication
+	case "transfer":
+		return NewTransferApplication
+	case MixApplicationType:
+		return NewMixApplication
+	case ContractApplicationType:
+		return NewContractApplication
+	case ReplayApplicationType:
+		return NewReplayApplication
+	case DeployApplicationType:
+		return NewDeployApplication
+	case ReadApplicationType:
+		return NewReadApplication
+	case NftApplicationType:
+		return NewNftApplication
+	case WalletApplicationType:
+		return NewWalletApplication
 	}
 	return nil
 }
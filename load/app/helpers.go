@@ -20,23 +20,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"math/big"
+	"sync"
 )
 
-func createTx(from *Account, toAddress common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64) (*types.Transaction, error) {
-	tx := types.NewTx(&types.LegacyTx{
-		Nonce:    from.getNextNonce(),
-		GasPrice: gasPrice,
-		Gas:      gasLimit,
-		To:       &toAddress,
-		Value:    value,
-		Data:     data,
-	})
-	return types.SignTx(tx, types.NewEIP155Signer(from.chainID), from.privateKey)
-}
-
 // GetGasPrice obtains optimal gasPrice for regular transactions
 func GetGasPrice(rpcClient rpc.RpcClient) (*big.Int, error) {
 	gasPrice, err := rpcClient.SuggestGasPrice(context.Background())
@@ -54,10 +41,41 @@ func getPriorityGasPrice(regularGasPrice *big.Int) *big.Int {
 	return &priorityPrice
 }
 
-func reverseAddresses(in []common.Address) []common.Address {
-	out := make([]common.Address, len(in))
-	for i := 0; i < len(in); i++ {
-		out[i] = in[len(in)-1-i]
+// nonceCounter counts the transactions of a set of accounts included in the
+// chain, as indicated by the nonces of the accounts. Transactions sent before
+// an account is added, e.g. in previous runs, are not counted.
+type nonceCounter struct {
+	accounts      []*Account
+	initialNonces []uint64
+	mutex         sync.Mutex
+}
+
+// add starts counting the transactions of the given account. It has to be
+// called before the account sends its first transaction.
+func (c *nonceCounter) add(account *Account) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.accounts = append(c.accounts, account)
+	c.initialNonces = append(c.initialNonces, account.nonce)
+}
+
+// getIncludedTransactions sums up the transactions of all added accounts
+// included in the chain.
+func (c *nonceCounter) getIncludedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	c.mutex.Lock()
+	accounts := append([]*Account{}, c.accounts...)
+	initialNonces := append([]uint64{}, c.initialNonces...)
+	c.mutex.Unlock()
+
+	sum := uint64(0)
+	for i, account := range accounts {
+		nonce, err := rpcClient.NonceAt(ctx, account.address, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get nonce of %v; %w", account.address, err)
+		}
+		if nonce > initialNonces[i] {
+			sum += nonce - initialNonces[i]
+		}
 	}
-	return out
+	return sum, nil
 }
@@ -0,0 +1,184 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MixApplicationType is the type of applications mixing several application
+// types.
+const MixApplicationType = "mix"
+
+// MixComponent is an application type contributing a share of the
+// transactions of a mixed application, proportional to its weight.
+type MixComponent struct {
+	Type   string
+	Weight float32
+}
+
+// MixedApplication is an application drawing its transactions from several
+// application types, which report their received transactions individually.
+type MixedApplication interface {
+	Application
+
+	// GetReceivedTransactionsByComponent returns the number of transactions
+	// received by each component, indexed by the type of the component.
+	GetReceivedTransactionsByComponent(ctx context.Context, rpcClient rpc.RpcClient) (map[string]uint64, error)
+}
+
+// NewMixApplication creates an application for each of the components listed
+// in the configuration. The transactions sent by its users are drawn from the
+// components according to their weights.
+func NewMixApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	if len(config.Mix) == 0 {
+		return nil, fmt.Errorf("mix application requires at least one component")
+	}
+	components := make([]mixedComponent, 0, len(config.Mix))
+	for i, component := range config.Mix {
+		if strings.ToLower(component.Type) == MixApplicationType {
+			return nil, fmt.Errorf("mix application must not contain mix components")
+		}
+		if component.Weight <= 0 {
+			return nil, fmt.Errorf("weight of component %s must be positive, got %v", component.Type, component.Weight)
+		}
+		// Components share the options of the mix. Each component derives the
+		// accounts of its users from a separate feeder ID, such that the
+		// components do not share accounts.
+		componentConfig := config
+		componentConfig.Mix = nil
+		application, err := NewApplication(ctx, component.Type, ctxt, feederId+uint32(i)+1, appId, componentConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create component %s; %w", component.Type, err)
+		}
+		components = append(components, mixedComponent{
+			name:        component.Type,
+			application: application,
+			weight:      component.Weight,
+		})
+	}
+	return &MixApplication{components: components}, nil
+}
+
+// MixApplication is an application drawing transactions from several
+// application types with individual weights, while sharing users and the
+// rate of transactions among them.
+type MixApplication struct {
+	components []mixedComponent
+}
+
+type mixedComponent struct {
+	name        string
+	application Application
+	weight      float32
+}
+
+// CreateUsers creates the given number of users for each of the components and
+// combines them into mixed users.
+func (f *MixApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]*MixUser, numUsers)
+	for i := range users {
+		users[i] = &MixUser{}
+	}
+	for _, component := range f.components {
+		componentUsers, err := component.application.CreateUsers(ctx, appContext, numUsers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create users of component %s; %w", component.name, err)
+		}
+		for i, user := range componentUsers {
+			users[i].users = append(users[i].users, user)
+			users[i].weights = append(users[i].weights, component.weight)
+		}
+	}
+	res := make([]User, numUsers)
+	for i, user := range users {
+		res[i] = user
+	}
+	return res, nil
+}
+
+// GetReceivedTransactions returns the sum of the transactions received by all
+// components of the mix.
+func (f *MixApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	received, err := f.GetReceivedTransactionsByComponent(ctx, rpcClient)
+	if err != nil {
+		return 0, err
+	}
+	sum := uint64(0)
+	for _, count := range received {
+		sum += count
+	}
+	return sum, nil
+}
+
+func (f *MixApplication) GetReceivedTransactionsByComponent(ctx context.Context, rpcClient rpc.RpcClient) (map[string]uint64, error) {
+	res := make(map[string]uint64, len(f.components))
+	for _, component := range f.components {
+		count, err := component.application.GetReceivedTransactions(ctx, rpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get received transactions of component %s; %w", component.name, err)
+		}
+		res[component.name] = count
+	}
+	return res, nil
+}
+
+// MixUser sends the transactions of one user of each component of a mix,
+// choosing the component of each transaction randomly according to the
+// weights of the components.
+// A user is supposed to be used in a single thread.
+type MixUser struct {
+	users   []User
+	weights []float32
+}
+
+func (g *MixUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	return g.users[g.pickComponent(rand.Float32())].GenerateTx(currentGasPrice)
+}
+
+// pickComponent selects the component covering the given point of the
+// interval [0,1), in which each component covers a share proportional to its
+// weight.
+func (g *MixUser) pickComponent(point float32) int {
+	total := float32(0)
+	for _, weight := range g.weights {
+		total += weight
+	}
+	point *= total
+	for i, weight := range g.weights {
+		if point < weight {
+			return i
+		}
+		point -= weight
+	}
+	return len(g.weights) - 1
+}
+
+func (g *MixUser) GetSentTransactions() uint64 {
+	sum := uint64(0)
+	for _, user := range g.users {
+		sum += user.GetSentTransactions()
+	}
+	return sum
+}
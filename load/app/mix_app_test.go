@@ -0,0 +1,131 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMixUser_ComponentsArePickedByWeight(t *testing.T) {
+	user := &MixUser{weights: []float32{7, 2, 1}}
+	tests := map[float32]int{
+		0:    0,
+		0.69: 0,
+		0.7:  1,
+		0.89: 1,
+		0.9:  2,
+		0.99: 2,
+	}
+	for point, want := range tests {
+		if got := user.pickComponent(point); got != want {
+			t.Errorf("unexpected component for %v, wanted %d, got %d", point, want, got)
+		}
+	}
+}
+
+func TestMixUser_TransactionsAreGeneratedByComponents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	first := NewMockUser(ctrl)
+	second := NewMockUser(ctrl)
+	tx := types.NewTx(&types.LegacyTx{})
+	first.EXPECT().GenerateTx(gomock.Any()).Return(tx, nil).AnyTimes()
+	first.EXPECT().GetSentTransactions().Return(uint64(3))
+	second.EXPECT().GetSentTransactions().Return(uint64(4))
+
+	// The second component has no weight and is thus never picked.
+	user := &MixUser{users: []User{first, second}, weights: []float32{1, 0}}
+	for i := 0; i < 10; i++ {
+		if got, err := user.GenerateTx(big.NewInt(1)); err != nil || got != tx {
+			t.Errorf("unexpected transaction, got %v, %v", got, err)
+		}
+	}
+	if got := user.GetSentTransactions(); got != 7 {
+		t.Errorf("unexpected number of sent transactions, wanted 7, got %d", got)
+	}
+}
+
+func TestMixApplication_ReceivedTransactionsAreReportedPerComponent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	transfer := NewMockApplication(ctrl)
+	erc20 := NewMockApplication(ctrl)
+	transfer.EXPECT().GetReceivedTransactions(gomock.Any(), rpcClient).Return(uint64(70), nil).Times(2)
+	erc20.EXPECT().GetReceivedTransactions(gomock.Any(), rpcClient).Return(uint64(30), nil).Times(2)
+
+	application := &MixApplication{components: []mixedComponent{
+		{name: "transfer", application: transfer, weight: 7},
+		{name: "erc20", application: erc20, weight: 3},
+	}}
+	received, err := application.GetReceivedTransactionsByComponent(context.Background(), rpcClient)
+	if err != nil {
+		t.Fatalf("failed to get received transactions: %v", err)
+	}
+	if received["transfer"] != 70 || received["erc20"] != 30 || len(received) != 2 {
+		t.Errorf("unexpected received transactions, got %v", received)
+	}
+	total, err := application.GetReceivedTransactions(context.Background(), rpcClient)
+	if err != nil || total != 100 {
+		t.Errorf("unexpected total of received transactions, wanted 100, got %d, %v", total, err)
+	}
+}
+
+func TestMixApplication_CreateUsersCombinesComponentUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	appContext := NewMockAppContext(ctrl)
+	transfer := NewMockApplication(ctrl)
+	erc20 := NewMockApplication(ctrl)
+	transfer.EXPECT().CreateUsers(gomock.Any(), appContext, 2).Return([]User{NewMockUser(ctrl), NewMockUser(ctrl)}, nil)
+	erc20.EXPECT().CreateUsers(gomock.Any(), appContext, 2).Return([]User{NewMockUser(ctrl), NewMockUser(ctrl)}, nil)
+
+	application := &MixApplication{components: []mixedComponent{
+		{name: "transfer", application: transfer, weight: 7},
+		{name: "erc20", application: erc20, weight: 3},
+	}}
+	users, err := application.CreateUsers(context.Background(), appContext, 2)
+	if err != nil {
+		t.Fatalf("failed to create users: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("unexpected number of users, wanted 2, got %d", len(users))
+	}
+	for _, user := range users {
+		mixed := user.(*MixUser)
+		if len(mixed.users) != 2 || len(mixed.weights) != 2 || mixed.weights[0] != 7 || mixed.weights[1] != 3 {
+			t.Errorf("user does not combine component users, got %v", mixed)
+		}
+	}
+}
+
+func TestNewMixApplication_InvalidComponentsAreRejected(t *testing.T) {
+	tests := map[string][]MixComponent{
+		"empty":      nil,
+		"nested":     {{Type: "mix", Weight: 1}},
+		"unknown":    {{Type: "unknown", Weight: 1}},
+		"weightless": {{Type: "transfer", Weight: 0}},
+	}
+	for name, mix := range tests {
+		if _, err := NewMixApplication(context.Background(), nil, 0, 0, AppConfig{Mix: mix}); err == nil {
+			t.Errorf("%s mix should be rejected", name)
+		}
+	}
+}
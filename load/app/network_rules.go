@@ -0,0 +1,52 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// nodeDriverAuthAddress is the address of the NodeDriverAuth contract. It is
+// owned by the account of the first genesis validator.
+var nodeDriverAuthAddress = common.HexToAddress("0xd100ae0000000000000000000000000000000000")
+
+// UpdateNetworkRules submits the given JSON encoded diff of the network rules
+// through the NodeDriverAuth contract. The update is sent by the treasure
+// account of the context, which has to be the owner of the contract. The new
+// rules take effect with the epoch following the one including the update.
+func UpdateNetworkRules(ctx context.Context, appContext AppContext, diff []byte) error {
+	nodeDriverAuth, err := contract.NewNodeDriverAuth(nodeDriverAuthAddress, appContext.GetClient())
+	if err != nil {
+		return fmt.Errorf("failed to get NodeDriverAuth contract representation; %v", err)
+	}
+	receipt, err := appContext.Run(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return nodeDriverAuth.UpdateNetworkRules(opts, diff)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update network rules; %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("failed to update network rules: transaction reverted")
+	}
+	return nil
+}
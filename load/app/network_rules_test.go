@@ -0,0 +1,50 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateNetworkRules_SuccessfulUpdateIsAccepted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	appContext := NewMockAppContext(ctrl)
+	appContext.EXPECT().GetClient().Return(rpc.NewMockRpcClient(ctrl))
+	appContext.EXPECT().Run(gomock.Any(), gomock.Any()).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil)
+
+	if err := UpdateNetworkRules(context.Background(), appContext, []byte(`{"Blocks":{"MaxBlockGas":1000}}`)); err != nil {
+		t.Errorf("failed to update network rules: %v", err)
+	}
+}
+
+func TestUpdateNetworkRules_RevertedUpdateIsReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	appContext := NewMockAppContext(ctrl)
+	appContext.EXPECT().GetClient().Return(rpc.NewMockRpcClient(ctrl))
+	appContext.EXPECT().Run(gomock.Any(), gomock.Any()).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil)
+
+	err := UpdateNetworkRules(context.Background(), appContext, []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "reverted") {
+		t.Errorf("reverted update was not reported, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NftApplicationType is the type of applications minting and transferring
+// ERC-721 tokens.
+const NftApplicationType = "nft"
+
+const (
+	// nftMintGasLimit covers a mint of a token by an account without tokens.
+	nftMintGasLimit = 120_000
+	// nftTransferGasLimit covers a safe transfer of a token to an account
+	// without tokens.
+	nftTransferGasLimit = 100_000
+)
+
+// NewNftApplication deploys an NFT contract to the chain. The users of the
+// application mint tokens and transfer them to other users, such that the
+// storage grows in per-token mappings and events are emitted, like in the
+// bursts of NFT drops.
+func NewNftApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	client := ctxt.GetClient()
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	// Deploy the NFT contract to be used by this application.
+	_, receipt, err := DeployContract(ctx, ctxt, contract.DeployNFT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy NFT contract; %w", err)
+	}
+
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse ABI for generating txs data
+	parsedAbi, err := contract.NFTMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &NftApplication{
+		abi:             parsedAbi,
+		contractAddress: receipt.ContractAddress,
+		accountFactory:  accountFactory,
+	}, nil
+}
+
+// NftApplication represents an ERC-721 token minted and traded by its users.
+// While the application is thread-safe, each created user should be used in a
+// single thread only.
+type NftApplication struct {
+	abi             *abi.ABI
+	contractAddress common.Address
+	accountFactory  *AccountFactory
+}
+
+// CreateUsers creates a list of new users for the app. Each user transfers its
+// tokens to the other users created along with it, or to random recipients if
+// it is the only user created.
+func (f *NftApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	minters := make([]*NftUser, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		minters[i] = &NftUser{
+			abi:      f.abi,
+			sender:   workerAccount,
+			contract: f.contractAddress,
+		}
+		users[i] = minters[i]
+		addresses[i] = workerAccount.address
+	}
+	for i, minter := range minters {
+		minter.recipients = append(append([]common.Address{}, addresses[:i]...), addresses[i+1:]...)
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
+	return users, err
+}
+
+// GetReceivedTransactions obtains the number of mints and transfers from the
+// counter of the NFT contract.
+func (f *NftApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	nftContract, err := contract.NewNFT(f.contractAddress, rpcClient)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get NFT contract representation; %w", err)
+	}
+	count, err := nftContract.GetCount(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+// NftUser represents a user alternately minting a token and transferring it to
+// another user. The IDs of the tokens of a user are derived from its address,
+// such that the ID of a token is known before its mint is executed.
+// A user is supposed to be used in a single thread.
+type NftUser struct {
+	abi        *abi.ABI
+	sender     *Account
+	contract   common.Address
+	recipients []common.Address
+	minted     uint64   // number of tokens minted by the user
+	owned      *big.Int // token minted by the user and not transferred yet, nil if none
+	sentTxs    atomic.Uint64
+}
+
+func (g *NftUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	var data []byte
+	var err error
+	var gasLimit uint64
+	var storageKeys []common.Hash
+	token := g.owned
+	sender := common.BytesToHash(g.sender.address.Bytes())
+	// The counter of the contract is stored in slot 0, its total supply in
+	// slot 1, and the mappings of owners, balances and approvals in slots 2
+	// to 4.
+	if token == nil {
+		token = getNftTokenId(g.sender.address, g.minted)
+		data, err = g.abi.Pack("mint", token)
+		gasLimit = nftMintGasLimit
+		storageKeys = []common.Hash{
+			getStorageSlot(0),
+			getStorageSlot(1),
+			getMappingSlot(common.BigToHash(token), getStorageSlot(2)),
+			getMappingSlot(sender, getStorageSlot(3)),
+		}
+	} else {
+		recipient := g.getRecipient()
+		data, err = g.abi.Pack("safeTransferFrom", g.sender.address, recipient, token)
+		gasLimit = nftTransferGasLimit
+		storageKeys = []common.Hash{
+			getStorageSlot(0),
+			getMappingSlot(common.BigToHash(token), getStorageSlot(2)),
+			getMappingSlot(sender, getStorageSlot(3)),
+			getMappingSlot(common.BytesToHash(recipient.Bytes()), getStorageSlot(3)),
+			getMappingSlot(common.BigToHash(token), getStorageSlot(4)),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
+	}
+
+	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit, storageKeys...)
+	if err != nil {
+		return nil, err
+	}
+	if g.owned == nil {
+		g.owned = token
+		g.minted++
+	} else {
+		g.owned = nil
+	}
+	g.sentTxs.Add(1)
+	return tx, nil
+}
+
+// getRecipient picks the recipient of the next transfer among the other
+// users, or a random address if there are no other users.
+func (g *NftUser) getRecipient() common.Address {
+	if len(g.recipients) > 0 {
+		return g.recipients[rand.Intn(len(g.recipients))]
+	}
+	var recipient common.Address
+	rand.Read(recipient[:])
+	return recipient
+}
+
+func (g *NftUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
+
+// getNftTokenId returns the ID of the token of the given number minted by the
+// given account.
+func getNftTokenId(minter common.Address, number uint64) *big.Int {
+	id := new(big.Int).Lsh(new(big.Int).SetBytes(minter.Bytes()), 64)
+	return id.Or(id, new(big.Int).SetUint64(number))
+}
@@ -0,0 +1,292 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// nftRuntime executes calls of an NFT contract deployed to an in-memory state.
+type nftRuntime struct {
+	t      *testing.T
+	abi    *abi.ABI
+	config *runtime.Config
+	nft    common.Address
+}
+
+func newNftRuntime(t *testing.T) *nftRuntime {
+	t.Helper()
+	parsedAbi, err := contract.NFTMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	config := &runtime.Config{}
+	_, nft, _, err := runtime.Create(common.FromHex(contract.NFTMetaData.Bin), config)
+	if err != nil {
+		t.Fatalf("failed to deploy NFT contract: %v", err)
+	}
+	return &nftRuntime{t: t, abi: parsedAbi, config: config, nft: nft}
+}
+
+// call sends a transaction calling the NFT contract with the given gas limit,
+// or a view call if the limit is zero.
+func (r *nftRuntime) call(from common.Address, gasLimit uint64, method string, args ...any) ([]any, error) {
+	r.t.Helper()
+	data, err := r.abi.Pack(method, args...)
+	if err != nil {
+		r.t.Fatalf("failed to pack call of %s: %v", method, err)
+	}
+	return r.send(from, gasLimit, method, data)
+}
+
+func (r *nftRuntime) send(from common.Address, gasLimit uint64, method string, data []byte) ([]any, error) {
+	r.t.Helper()
+	r.config.Origin = from
+	r.config.GasLimit = 0
+	if gasLimit > 0 {
+		r.config.GasLimit = gasLimit - params.TxGas - getCalldataGas(data)
+	}
+	result, _, err := runtime.Call(r.nft, data, r.config)
+	if err != nil {
+		return nil, err
+	}
+	return r.abi.Unpack(method, result)
+}
+
+func TestNftContract_TokensAreMintedAndTransferredWithinGasLimits(t *testing.T) {
+	r := newNftRuntime(t)
+	alice, bob := common.Address{1}, common.Address{2}
+	token := getNftTokenId(alice, 0)
+	if _, err := r.call(alice, nftMintGasLimit, "mint", token); err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+	if _, err := r.call(bob, nftMintGasLimit, "mint", token); err == nil {
+		t.Errorf("token must not be minted twice")
+	}
+	if owner, err := r.call(alice, 0, "ownerOf", token); err != nil || owner[0] != alice {
+		t.Errorf("unexpected owner, wanted %v, got %v, %v", alice, owner, err)
+	}
+	if _, err := r.call(bob, nftTransferGasLimit, "safeTransferFrom", alice, bob, token); err == nil {
+		t.Errorf("token must only be transferred by its owner")
+	}
+	if _, err := r.call(alice, nftTransferGasLimit, "safeTransferFrom", alice, bob, token); err != nil {
+		t.Fatalf("failed to transfer token: %v", err)
+	}
+	if owner, err := r.call(alice, 0, "ownerOf", token); err != nil || owner[0] != bob {
+		t.Errorf("unexpected owner, wanted %v, got %v, %v", bob, owner, err)
+	}
+	if _, err := r.call(alice, 0, "ownerOf", big.NewInt(1)); err == nil {
+		t.Errorf("owner of a token not minted must not be available")
+	}
+
+	views := map[string][]any{
+		"getCount":    nil,
+		"totalSupply": nil,
+		"balanceOf":   {alice},
+	}
+	want := map[string]int64{"getCount": 2, "totalSupply": 1, "balanceOf": 0}
+	for method, args := range views {
+		result, err := r.call(alice, 0, method, args...)
+		if err != nil || result[0].(*big.Int).Cmp(big.NewInt(want[method])) != 0 {
+			t.Errorf("unexpected result of %s, wanted %d, got %v, %v", method, want[method], result, err)
+		}
+	}
+
+	logs := r.config.State.Logs()
+	if len(logs) != 2 {
+		t.Fatalf("unexpected number of events, wanted 2, got %d", len(logs))
+	}
+	event := r.abi.Events["Transfer"].ID
+	for i, want := range [][]common.Hash{
+		{event, {}, common.BytesToHash(alice.Bytes()), common.BigToHash(token)},
+		{event, common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes()), common.BigToHash(token)},
+	} {
+		if got := logs[i].Topics; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+			t.Errorf("unexpected topics of event %d, wanted %v, got %v", i, want, got)
+		}
+	}
+}
+
+func TestNftContract_ApprovedAccountsCanTransferTokens(t *testing.T) {
+	r := newNftRuntime(t)
+	alice, bob, carol := common.Address{1}, common.Address{2}, common.Address{3}
+	token := getNftTokenId(alice, 0)
+	if _, err := r.call(alice, nftMintGasLimit, "mint", token); err != nil {
+		t.Fatalf("failed to mint token: %v", err)
+	}
+
+	if _, err := r.call(bob, 0, "approve", bob, token); err == nil {
+		t.Errorf("token must only be approved by its owner or operators")
+	}
+	if _, err := r.call(alice, 0, "approve", bob, token); err != nil {
+		t.Fatalf("failed to approve token: %v", err)
+	}
+	if approved, err := r.call(alice, 0, "getApproved", token); err != nil || approved[0] != bob {
+		t.Errorf("unexpected approved account, wanted %v, got %v, %v", bob, approved, err)
+	}
+	if _, err := r.call(bob, 0, "transferFrom", alice, bob, token); err != nil {
+		t.Fatalf("approved account failed to transfer token: %v", err)
+	}
+	if approved, err := r.call(alice, 0, "getApproved", token); err != nil || approved[0] != (common.Address{}) {
+		t.Errorf("approval must be cleared by transfers, got %v, %v", approved, err)
+	}
+	if _, err := r.call(bob, 0, "transferFrom", bob, common.Address{}, token); err == nil {
+		t.Errorf("token must not be transferred to the zero address")
+	}
+
+	if _, err := r.call(bob, 0, "setApprovalForAll", carol, true); err != nil {
+		t.Fatalf("failed to approve operator: %v", err)
+	}
+	if approved, err := r.call(bob, 0, "isApprovedForAll", bob, carol); err != nil || approved[0] != true {
+		t.Errorf("operator was not approved, got %v, %v", approved, err)
+	}
+	if _, err := r.call(carol, 0, "approve", alice, token); err != nil {
+		t.Errorf("operator failed to approve token: %v", err)
+	}
+	if _, err := r.call(carol, 0, "transferFrom", bob, carol, token); err != nil {
+		t.Errorf("operator failed to transfer token: %v", err)
+	}
+	if owner, err := r.call(alice, 0, "ownerOf", token); err != nil || owner[0] != carol {
+		t.Errorf("unexpected owner, wanted %v, got %v, %v", carol, owner, err)
+	}
+	if _, err := r.call(bob, 0, "transferFrom", carol, bob, token); err == nil {
+		t.Errorf("token must not be transferred by an operator of a previous owner")
+	}
+}
+
+func TestNftContract_SafeTransfersAreAcceptedByReceivers(t *testing.T) {
+	r := newNftRuntime(t)
+	alice := common.Address{1}
+	selector := crypto.Keccak256([]byte("onERC721Received(address,address,uint256,bytes)"))[:4]
+	receiver, rejecter := common.Address{2}, common.Address{3}
+	// The receiver returns the selector of onERC721Received, the rejecter
+	// returns nothing.
+	r.config.State.SetCode(receiver, append(append([]byte{byte(vm.PUSH4)}, selector...),
+		byte(vm.PUSH1), 224, byte(vm.SHL), byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN)))
+	r.config.State.SetCode(rejecter, []byte{byte(vm.STOP)})
+
+	for i, call := range []struct {
+		to     common.Address
+		data   []byte
+		accept bool
+	}{
+		{receiver, nil, true},
+		{receiver, []byte("data"), true},
+		{rejecter, nil, false},
+		{rejecter, []byte("data"), false},
+	} {
+		token := getNftTokenId(alice, uint64(i))
+		if _, err := r.call(alice, 0, "mint", token); err != nil {
+			t.Fatalf("failed to mint token: %v", err)
+		}
+		var err error
+		if call.data == nil {
+			_, err = r.call(alice, 0, "safeTransferFrom", alice, call.to, token)
+		} else {
+			_, err = r.call(alice, 0, "safeTransferFrom0", alice, call.to, token, call.data)
+		}
+		if call.accept && err != nil {
+			t.Errorf("transfer %d was not accepted: %v", i, err)
+		}
+		if !call.accept && err == nil {
+			t.Errorf("transfer %d was not rejected", i)
+		}
+	}
+}
+
+func TestNftContract_InterfacesAreSupported(t *testing.T) {
+	r := newNftRuntime(t)
+	for id, want := range map[[4]byte]bool{
+		{0x01, 0xff, 0xc9, 0xa7}: true,  // ERC-165
+		{0x80, 0xac, 0x58, 0xcd}: true,  // ERC-721
+		{0x5b, 0x5e, 0x13, 0x9f}: false, // ERC-721 metadata
+		{0xff, 0xff, 0xff, 0xff}: false,
+	} {
+		result, err := r.call(common.Address{1}, 0, "supportsInterface", id)
+		if err != nil || result[0] != want {
+			t.Errorf("unexpected support of interface %x, wanted %t, got %v, %v", id, want, result, err)
+		}
+	}
+}
+
+func TestNftUser_TokensAreMintedAndTransferredWithinGasLimits(t *testing.T) {
+	r := newNftRuntime(t)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	recipient := common.Address{2}
+	user := &NftUser{
+		abi:        r.abi,
+		sender:     &Account{privateKey: key, address: sender, chainID: big.NewInt(0xFA)},
+		contract:   r.nft,
+		recipients: []common.Address{recipient},
+	}
+
+	for i := 0; i < 4; i++ {
+		tx, err := user.GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		token := getNftTokenId(sender, uint64(i/2))
+		method := "mint"
+		want, err := r.abi.Pack(method, token)
+		if i%2 == 1 {
+			method = "safeTransferFrom"
+			want, err = r.abi.Pack(method, sender, recipient, token)
+		}
+		if err != nil {
+			t.Fatalf("failed to pack call: %v", err)
+		}
+		if *tx.To() != user.contract || string(tx.Data()) != string(want) {
+			t.Errorf("unexpected transaction %d, to %v, data %x", i, tx.To(), tx.Data())
+		}
+		if _, err := r.send(sender, tx.Gas(), method, tx.Data()); err != nil {
+			t.Errorf("transaction %d failed: %v", i, err)
+		}
+	}
+	if got := user.GetSentTransactions(); got != 4 {
+		t.Errorf("unexpected number of sent transactions, wanted 4, got %d", got)
+	}
+	if balance, err := r.call(sender, 0, "balanceOf", recipient); err != nil || balance[0].(*big.Int).Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("unexpected balance of recipient, wanted 2, got %v, %v", balance, err)
+	}
+}
+
+func TestGetNftTokenId_TokensOfMintersAreDistinct(t *testing.T) {
+	ids := map[string]bool{}
+	for _, minter := range []common.Address{{1}, {2}, {19: 1}} {
+		for number := uint64(0); number < 3; number++ {
+			id := getNftTokenId(minter, number).String()
+			if ids[id] {
+				t.Errorf("token ID %s is not unique", id)
+			}
+			ids[id] = true
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ReadApplicationType is the type of applications sending read-dominated
+// transactions.
+const ReadApplicationType = "read"
+
+// ReadPattern defines the state read by the transactions of a read application.
+type ReadPattern string
+
+const (
+	// SlotsReadPattern reads random storage slots written by earlier
+	// transactions of the same user.
+	SlotsReadPattern ReadPattern = "slots"
+	// ScanReadPattern reads consecutive storage slots written by earlier
+	// transactions of the same user, like a scan of a mapping.
+	ScanReadPattern ReadPattern = "scan"
+	// AccountsReadPattern reads the balance and code size of random accounts
+	// funded by earlier transactions of the same user, which are cold in
+	// general.
+	AccountsReadPattern ReadPattern = "accounts"
+)
+
+// ReadConfig defines the transactions sent by the users of a read application.
+type ReadConfig struct {
+	// Pattern defines the state read by each transaction.
+	Pattern ReadPattern
+	// Reads is the number of storage slots or accounts read by each
+	// transaction.
+	Reads int
+	// Writes is the number of new storage slots written, or accounts funded,
+	// by each transaction.
+	Writes int
+	// Accounts is the number of accounts funded by each user with the
+	// accounts pattern. Once all accounts are funded, they are funded again.
+	Accounts int
+}
+
+// DefaultReadConfig is the configuration of read applications, unless
+// configured otherwise.
+var DefaultReadConfig = ReadConfig{
+	Pattern:  SlotsReadPattern,
+	Reads:    100,
+	Writes:   10,
+	Accounts: 1_000_000,
+}
+
+// maxReadGas is the maximum gas limit of transactions of read applications,
+// which bounds the number of reads and writes per transaction.
+const maxReadGas = 10_000_000
+
+// Type returns the type of applications configured by reads.
+func (config ReadConfig) Type() string {
+	return ReadApplicationType
+}
+
+// Check tests whether transactions can be sent as configured.
+func (config ReadConfig) Check() error {
+	errs := []error{}
+	switch config.Pattern {
+	case SlotsReadPattern, ScanReadPattern, AccountsReadPattern:
+	default:
+		errs = append(errs, fmt.Errorf("unknown read pattern '%s', supported are %s, %s and %s", config.Pattern, SlotsReadPattern, ScanReadPattern, AccountsReadPattern))
+	}
+	if config.Reads < 0 || config.Writes < 0 {
+		errs = append(errs, fmt.Errorf("number of reads and writes must not be negative, got %d and %d", config.Reads, config.Writes))
+	} else if config.Writes == 0 {
+		errs = append(errs, fmt.Errorf("transactions must write at least one slot or account, as only state written earlier is read"))
+	} else if gas := getReadGas(config); gas > maxReadGas {
+		errs = append(errs, fmt.Errorf("too many reads and writes, transactions would need %d gas, limit is %d", gas, maxReadGas))
+	}
+	if config.Pattern == AccountsReadPattern && config.Accounts < 1 {
+		errs = append(errs, fmt.Errorf("number of accounts must be positive, got %d", config.Accounts))
+	}
+	return errors.Join(errs...)
+}
+
+// NewReadApplication deploys a Reader contract reading and writing the state
+// addressed by its calls. The users of the application send read-dominated
+// transactions, such that the load covers the caching of state, which
+// write-heavy applications hide.
+func NewReadApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	readConfig, found := getConfig[ReadConfig](config)
+	if !found {
+		readConfig = DefaultReadConfig
+	}
+	if err := readConfig.Check(); err != nil {
+		return nil, err
+	}
+
+	client := ctxt.GetClient()
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	// Deploy the Reader contract to be used by this application.
+	_, receipt, err := DeployContract(ctx, ctxt, contract.DeployReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy Reader contract; %w", err)
+	}
+
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse ABI for generating txs data
+	parsedAbi, err := contract.ReaderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadApplication{
+		config:          readConfig,
+		abi:             parsedAbi,
+		contractAddress: receipt.ContractAddress,
+		accountFactory:  accountFactory,
+	}, nil
+}
+
+// ReadApplication represents a Reader contract reading and writing state on
+// behalf of its users. While the application is thread-safe, each created user
+// should be used in a single thread only.
+type ReadApplication struct {
+	config          ReadConfig
+	abi             *abi.ABI
+	contractAddress common.Address
+	accountFactory  *AccountFactory
+}
+
+// CreateUsers creates a list of new users for the app.
+func (f *ReadApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		users[i] = &ReadUser{
+			config:   f.config,
+			abi:      f.abi,
+			sender:   workerAccount,
+			contract: f.contractAddress,
+			random:   rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))),
+		}
+		addresses[i] = workerAccount.address
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
+	return users, err
+}
+
+// GetReceivedTransactions obtains the number of received transactions from the
+// counter of the Reader contract.
+func (f *ReadApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	reader, err := contract.NewReader(f.contractAddress, rpcClient)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get Reader contract representation; %w", err)
+	}
+	count, err := reader.GetCount(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+// ReadUser represents a user sending read-dominated transactions. The storage
+// slots of a user are numbered in the order of their writes, like the accounts
+// funded by the user.
+// A user is supposed to be used in a single thread.
+type ReadUser struct {
+	config   ReadConfig
+	abi      *abi.ABI
+	sender   *Account
+	contract common.Address
+	random   *rand.Rand
+	written  uint64 // number of slots written, or accounts funded, by the transactions of the user
+	scanned  uint64 // number of the next slot read by the scan pattern
+	sentTxs  atomic.Uint64
+}
+
+func (g *ReadUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	var data []byte
+	var err error
+	value := big.NewInt(0)
+	// The counter of the contract is stored in its first slot.
+	storageKeys := []common.Hash{getStorageSlot(0)}
+	if g.config.Pattern == AccountsReadPattern {
+		reads := make([]common.Address, 0, g.config.Reads)
+		for i := 0; g.written > 0 && i < g.config.Reads; i++ {
+			reads = append(reads, g.getAccount(uint64(g.random.Int63n(int64(g.getFunded())))))
+		}
+		funded := make([]common.Address, 0, g.config.Writes)
+		for i := 0; i < g.config.Writes; i++ {
+			funded = append(funded, g.getAccount((g.written+uint64(i))%uint64(g.config.Accounts)))
+		}
+		value = big.NewInt(int64(len(funded)))
+		data, err = g.abi.Pack("readAccounts", reads, funded)
+	} else {
+		reads := make([]*big.Int, 0, g.config.Reads)
+		for i := 0; g.written > 0 && i < g.config.Reads; i++ {
+			slot := g.nextRead()
+			reads = append(reads, new(big.Int).SetUint64(slot))
+			storageKeys = append(storageKeys, g.getSlot(slot))
+		}
+		for i := uint64(0); i < uint64(g.config.Writes); i++ {
+			storageKeys = append(storageKeys, g.getSlot(g.written+i))
+		}
+		from := new(big.Int).SetUint64(g.written)
+		to := new(big.Int).SetUint64(g.written + uint64(g.config.Writes))
+		data, err = g.abi.Pack("readSlots", reads, from, to)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
+	}
+
+	tx, err := createTx(g.sender, g.contract, value, data, currentGasPrice, getReadGas(g.config), storageKeys...)
+	if err != nil {
+		return nil, err
+	}
+	g.written += uint64(g.config.Writes)
+	g.sentTxs.Add(1)
+	return tx, nil
+}
+
+func (g *ReadUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
+
+// nextRead returns the number of the next slot read by the user, among the
+// slots written by earlier transactions.
+func (g *ReadUser) nextRead() uint64 {
+	if g.config.Pattern == ScanReadPattern {
+		slot := g.scanned % g.written
+		g.scanned = slot + 1
+		return slot
+	}
+	return uint64(g.random.Int63n(int64(g.written)))
+}
+
+// getFunded returns the number of distinct accounts funded by earlier
+// transactions of the user.
+func (g *ReadUser) getFunded() uint64 {
+	return min(g.written, uint64(g.config.Accounts))
+}
+
+// getSlot returns the storage key of the slot of the given number of the user,
+// an entry of the mapping of the Reader contract in slot 1.
+func (g *ReadUser) getSlot(number uint64) common.Hash {
+	slots := getMappingSlot(common.BytesToHash(g.sender.address.Bytes()), getStorageSlot(1))
+	return getMappingSlot(common.BigToHash(new(big.Int).SetUint64(number)), slots)
+}
+
+// getAccount returns the account of the given number funded by the user.
+func (g *ReadUser) getAccount(number uint64) common.Address {
+	return common.BytesToAddress(crypto.Keccak256(g.sender.address.Bytes(), binary.BigEndian.AppendUint64(nil, number)))
+}
+
+const (
+	// readerLoopGas covers the instructions of a loop iteration of the Reader
+	// contract, besides the state access.
+	readerLoopGas = 200
+	// readerWordGas is the maximum intrinsic gas of a word of call data.
+	readerWordGas = 32 * params.TxDataNonZeroGasEIP2028
+	// readerSlotReadGas is the gas of reading a storage slot.
+	readerSlotReadGas = readerWordGas + params.ColdSloadCostEIP2929 + readerLoopGas
+	// readerSlotWriteGas is the gas of writing a new storage slot.
+	readerSlotWriteGas = params.ColdSloadCostEIP2929 + params.SstoreSetGasEIP2200 + readerLoopGas
+	// readerAccountReadGas is the gas of reading an account.
+	readerAccountReadGas = readerWordGas + params.ColdAccountAccessCostEIP2929 + params.WarmStorageReadCostEIP2929 + readerLoopGas
+	// readerAccountFundGas is the gas of funding a new account.
+	readerAccountFundGas = readerWordGas + params.ColdAccountAccessCostEIP2929 + params.CallValueTransferGas + params.CallNewAccountGas + readerLoopGas
+	// readerCallGas covers the selector and the fixed arguments of a call, and
+	// the update of the counter.
+	readerCallGas = 6*readerWordGas + params.ColdSloadCostEIP2929 + params.SstoreSetGasEIP2200
+)
+
+// getReadGas returns a gas limit covering a call of the Reader contract sent by
+// the users of a read application of the given configuration.
+func getReadGas(config ReadConfig) uint64 {
+	gas := params.TxGas + readerCallGas + deploymentGasMargin
+	if config.Pattern == AccountsReadPattern {
+		return gas + uint64(config.Reads)*readerAccountReadGas + uint64(config.Writes)*readerAccountFundGas
+	}
+	return gas + uint64(config.Reads)*readerSlotReadGas + uint64(config.Writes)*readerSlotWriteGas
+}
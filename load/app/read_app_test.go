@@ -0,0 +1,239 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestReadConfig_InvalidConfigurationsAreRejected(t *testing.T) {
+	if err := DefaultReadConfig.Check(); err != nil {
+		t.Errorf("default configuration should be accepted, got %v", err)
+	}
+	tests := map[string]func(*ReadConfig){
+		"unknown read pattern":       func(c *ReadConfig) { c.Pattern = "random" },
+		"must not be negative":       func(c *ReadConfig) { c.Writes = -1 },
+		"at least one slot":          func(c *ReadConfig) { c.Writes = 0 },
+		"too many reads and writes":  func(c *ReadConfig) { c.Reads = 10_000 },
+		"number of accounts must be": func(c *ReadConfig) { c.Pattern, c.Accounts = AccountsReadPattern, 0 },
+	}
+	for issue, modify := range tests {
+		config := DefaultReadConfig
+		modify(&config)
+		if err := config.Check(); err == nil || !strings.Contains(err.Error(), issue) {
+			t.Errorf("issue %q was not detected, got %v", issue, err)
+		}
+	}
+}
+
+func newReadUser(t *testing.T, config ReadConfig) *ReadUser {
+	t.Helper()
+	parsedAbi, err := contract.ReaderMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return &ReadUser{
+		config:   config,
+		abi:      parsedAbi,
+		sender:   &Account{privateKey: key, address: crypto.PubkeyToAddress(key.PublicKey), chainID: big.NewInt(0xFA)},
+		contract: common.Address{1},
+		random:   rand.New(rand.NewSource(0)),
+	}
+}
+
+// runReader deploys the Reader contract and executes the given number of
+// transactions of the user with the gas limits of the transactions.
+func runReader(t *testing.T, user *ReadUser, numTxs int) (*runtime.Config, common.Address) {
+	t.Helper()
+	config := &runtime.Config{BlockNumber: big.NewInt(5)}
+	_, reader, _, err := runtime.Create(common.FromHex(contract.ReaderMetaData.Bin), config)
+	if err != nil {
+		t.Fatalf("failed to deploy Reader contract: %v", err)
+	}
+	user.contract = reader
+	config.Origin = user.sender.address
+	config.State.AddBalance(config.Origin, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	for i := 0; i < numTxs; i++ {
+		tx, err := user.GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		config.Value = tx.Value()
+		config.GasLimit = tx.Gas() - params.TxGas - getCalldataGas(tx.Data())
+		if _, _, err := runtime.Call(reader, tx.Data(), config); err != nil {
+			t.Fatalf("transaction %d failed: %v", i, err)
+		}
+	}
+	return config, reader
+}
+
+func TestReader_SlotsWrittenEarlierAreRead(t *testing.T) {
+	for _, pattern := range []ReadPattern{SlotsReadPattern, ScanReadPattern} {
+		user := newReadUser(t, ReadConfig{Pattern: pattern, Reads: 4, Writes: 3})
+		parsedAbi := user.abi
+		for i := 0; i < 3; i++ {
+			tx, err := user.GenerateTx(big.NewInt(1_000))
+			if err != nil {
+				t.Fatalf("failed to generate transaction: %v", err)
+			}
+			args, err := parsedAbi.Methods["readSlots"].Inputs.Unpack(tx.Data()[4:])
+			if err != nil {
+				t.Fatalf("failed to decode call: %v", err)
+			}
+			reads, from, to := args[0].([]*big.Int), args[1].(*big.Int).Uint64(), args[2].(*big.Int).Uint64()
+			if from != uint64(3*i) || to != uint64(3*i+3) {
+				t.Errorf("unexpected written slots, wanted %d to %d, got %d to %d", 3*i, 3*i+3, from, to)
+			}
+			if i == 0 && len(reads) != 0 || i > 0 && len(reads) != 4 {
+				t.Fatalf("unexpected number of reads, got %d", len(reads))
+			}
+			for j, slot := range reads {
+				if slot.Uint64() >= from {
+					t.Errorf("%s pattern reads slot %v not written before", pattern, slot)
+				}
+				if pattern == ScanReadPattern {
+					// The scan wraps around at the written slots and continues
+					// after the slots read by the previous transaction.
+					want := [][]uint64{nil, {0, 1, 2, 0}, {1, 2, 3, 4}}[i]
+					if slot.Uint64() != want[j] {
+						t.Errorf("unexpected slot read by scan, wanted %d, got %v", want[j], slot)
+					}
+				}
+			}
+		}
+		if got := user.GetSentTransactions(); got != 3 {
+			t.Errorf("unexpected number of sent transactions, wanted 3, got %d", got)
+		}
+	}
+}
+
+func TestReader_AccountsFundedEarlierAreRead(t *testing.T) {
+	user := newReadUser(t, ReadConfig{Pattern: AccountsReadPattern, Reads: 20, Writes: 2, Accounts: 3})
+	funded := map[common.Address]bool{}
+	for i := 0; i < 3; i++ {
+		tx, err := user.GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		args, err := user.abi.Methods["readAccounts"].Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			t.Fatalf("failed to decode call: %v", err)
+		}
+		reads, writes := args[0].([]common.Address), args[1].([]common.Address)
+		if i == 0 && len(reads) != 0 || i > 0 && len(reads) != 20 || len(writes) != 2 {
+			t.Fatalf("unexpected call, %d reads, %d writes", len(reads), len(writes))
+		}
+		if tx.Value().Cmp(big.NewInt(2)) != 0 {
+			t.Errorf("unexpected value, wanted 2, got %v", tx.Value())
+		}
+		for _, account := range reads {
+			if !funded[account] {
+				t.Errorf("account %v was not funded before", account)
+			}
+		}
+		for _, account := range writes {
+			funded[account] = true
+		}
+	}
+	if len(funded) != 3 {
+		t.Errorf("unexpected number of funded accounts, wanted 3, got %d", len(funded))
+	}
+}
+
+func TestReader_StateIsReadAndWrittenWithinGasLimits(t *testing.T) {
+	for _, pattern := range []ReadPattern{SlotsReadPattern, ScanReadPattern, AccountsReadPattern} {
+		user := newReadUser(t, ReadConfig{Pattern: pattern, Reads: 10, Writes: 4, Accounts: 6})
+		config, reader := runReader(t, user, 3)
+
+		for i := uint64(0); i < 12; i++ {
+			if pattern == AccountsReadPattern {
+				if got := config.State.GetBalance(user.getAccount(i % 6)); i < 6 && got.Uint64() != 2 {
+					t.Errorf("account %d was not funded twice, got %v", i, got)
+				}
+			} else if got := config.State.GetState(reader, user.getSlot(i)); got != common.BigToHash(big.NewInt(5)) {
+				t.Errorf("slot %d was not written, got %v", i, got)
+			}
+		}
+
+		config.Value = nil
+		config.GasLimit = 0
+		data, err := user.abi.Pack("getCount")
+		if err != nil {
+			t.Fatalf("failed to pack call: %v", err)
+		}
+		result, _, err := runtime.Call(reader, data, config)
+		if err != nil {
+			t.Fatalf("failed to get counter: %v", err)
+		}
+		if got := new(big.Int).SetBytes(result); got.Cmp(big.NewInt(3)) != 0 {
+			t.Errorf("unexpected counter, wanted 3, got %v", got)
+		}
+	}
+}
+
+func TestReader_GasOfLargestTransactionsIsCovered(t *testing.T) {
+	for _, config := range []ReadConfig{
+		{Pattern: SlotsReadPattern, Reads: 1000, Writes: 300},
+		{Pattern: AccountsReadPattern, Reads: 1000, Writes: 100, Accounts: 1_000_000},
+	} {
+		if err := config.Check(); err != nil {
+			t.Fatalf("configuration should be accepted, got %v", err)
+		}
+		runReader(t, newReadUser(t, config), 2)
+	}
+}
+
+func TestReadUser_AccessListsDeclareTouchedSlots(t *testing.T) {
+	user := newReadUser(t, ReadConfig{Pattern: SlotsReadPattern, Reads: 2, Writes: 1})
+	user.sender.txType = AccessListTxType
+	for i := 0; i < 2; i++ {
+		tx, err := user.GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		want := []common.Hash{getStorageSlot(0)}
+		if i > 0 {
+			want = append(want, user.getSlot(0), user.getSlot(0))
+		}
+		want = append(want, user.getSlot(uint64(i)))
+		accessList := tx.AccessList()
+		if tx.Type() != types.AccessListTxType || len(accessList) != 1 || len(accessList[0].StorageKeys) != len(want) {
+			t.Fatalf("unexpected access list %v", accessList)
+		}
+		for j, key := range accessList[0].StorageKeys {
+			if key != want[j] {
+				t.Errorf("unexpected storage key %d, wanted %v, got %v", j, want[j], key)
+			}
+		}
+	}
+}
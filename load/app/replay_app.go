@@ -0,0 +1,294 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ReplayApplicationType is the type of applications replaying recorded
+// transactions.
+const ReplayApplicationType = "replay"
+
+// recordedTx is a transaction recorded on a production network. The fields
+// use the names and encodings of eth_getTransactionByHash, extended by the
+// contractAddress of the receipt for contract creations, such that the
+// records can be exported from an archive node with little effort. Further
+// fields of the exported transactions, e.g. the sender or the nonce, are
+// ignored.
+type recordedTx struct {
+	// To is the target of the transaction, nil for contract creations.
+	To *common.Address `json:"to"`
+	// Input is the calldata of the transaction, or the creation code for
+	// contract creations.
+	Input hexutil.Bytes `json:"input"`
+	// Value is the amount of wei sent with the transaction, zero if nil.
+	Value *hexutil.Big `json:"value"`
+	// Gas is the gas limit of the transaction.
+	Gas hexutil.Uint64 `json:"gas"`
+	// ContractAddress is the address of the contract created by the
+	// transaction on the recorded network. Recorded transactions sent to this
+	// address are redirected to the contract deployed by the replay.
+	ContractAddress *common.Address `json:"contractAddress"`
+}
+
+// getValue returns the value of the recorded transaction.
+func (r *recordedTx) getValue() *big.Int {
+	if r.Value == nil {
+		return big.NewInt(0)
+	}
+	return r.Value.ToInt()
+}
+
+// recording is a parsed file of recorded transactions, split into the
+// contract creations deployed when the application is created and the
+// calls replayed by its users, both in the order of the file.
+type recording struct {
+	creations []recordedTx
+	calls     []recordedTx
+}
+
+// ReplayConfig defines the transactions replayed by a replay application.
+type ReplayConfig struct {
+	// Path is the path of a file of recorded transactions.
+	Path string
+}
+
+// Type returns the type of applications configured by recorded transactions.
+func (config ReplayConfig) Type() string {
+	return ReplayApplicationType
+}
+
+// Check tests whether the file of recorded transactions can be replayed.
+func (config ReplayConfig) Check() error {
+	_, err := loadRecording(config.Path)
+	return err
+}
+
+// loadRecording reads a file holding a sequence of JSON-encoded recorded
+// transactions, typically one per line.
+func loadRecording(path string) (*recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded transactions; %w", err)
+	}
+	defer file.Close()
+
+	res := &recording{}
+	errs := []error{}
+	decoder := json.NewDecoder(file)
+	for i := 1; ; i++ {
+		var tx recordedTx
+		if err := decoder.Decode(&tx); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid transaction %d in %s; %w", i, path, err)
+		}
+		if tx.Gas == 0 {
+			errs = append(errs, fmt.Errorf("transaction %d has no gas limit", i))
+		}
+		if tx.getValue().Sign() < 0 {
+			errs = append(errs, fmt.Errorf("transaction %d has a negative value", i))
+		}
+		if tx.To == nil {
+			if len(tx.Input) == 0 {
+				errs = append(errs, fmt.Errorf("contract creation %d has no creation code", i))
+			}
+			res.creations = append(res.creations, tx)
+		} else {
+			if tx.ContractAddress != nil {
+				errs = append(errs, fmt.Errorf("transaction %d defines a contract address but is no contract creation", i))
+			}
+			res.calls = append(res.calls, tx)
+		}
+	}
+	if len(errs) == 0 && len(res.calls) == 0 {
+		errs = append(errs, fmt.Errorf("no transactions to replay"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid transactions in %s; %w", path, err)
+	}
+	return res, nil
+}
+
+// relocate replaces all occurrences of recorded contract addresses in the
+// given data by the addresses of the respective deployed contracts, such that
+// ABI-encoded references to those contracts remain valid.
+func relocate(data []byte, addresses map[common.Address]common.Address) []byte {
+	for recorded, deployed := range addresses {
+		data = bytes.ReplaceAll(data, recorded.Bytes(), deployed.Bytes())
+	}
+	return data
+}
+
+// NewReplayApplication deploys the contracts created by a file of recorded
+// transactions, in the order of the file. The users of the application
+// re-sign the remaining recorded transactions with their own accounts and
+// send them to the deployed contracts, such that the load resembles the
+// execution and storage patterns of the recorded network.
+func NewReplayApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	replayConfig, found := getConfig[ReplayConfig](config)
+	if !found || replayConfig.Path == "" {
+		return nil, fmt.Errorf("replay application requires a file of recorded transactions")
+	}
+	recording, err := loadRecording(replayConfig.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	client := ctxt.GetClient()
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	addresses := map[common.Address]common.Address{}
+	for i, creation := range recording.creations {
+		code := relocate(creation.Input, addresses)
+		deploy := func(opts *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *bind.BoundContract, error) {
+			// The creation code includes the encoded constructor arguments.
+			opts.Value = creation.getValue()
+			opts.GasLimit = uint64(creation.Gas)
+			return bind.DeployContract(opts, abi.ABI{}, code, backend)
+		}
+		_, receipt, err := DeployContract(ctx, ctxt, deploy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy recorded contract %d; %w", i+1, err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, fmt.Errorf("deployment of recorded contract %d was reverted", i+1)
+		}
+		if creation.ContractAddress != nil {
+			addresses[*creation.ContractAddress] = receipt.ContractAddress
+		}
+	}
+
+	txs := make([]replayedTx, 0, len(recording.calls))
+	for _, call := range recording.calls {
+		to := *call.To
+		if deployed, found := addresses[to]; found {
+			to = deployed
+		}
+		txs = append(txs, replayedTx{
+			to:    to,
+			data:  relocate(call.Input, addresses),
+			value: call.getValue(),
+			gas:   uint64(call.Gas),
+		})
+	}
+
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayApplication{
+		txs:            txs,
+		next:           &atomic.Uint64{},
+		accountFactory: accountFactory,
+	}, nil
+}
+
+// replayedTx is a recorded transaction targeting the deployed contracts.
+type replayedTx struct {
+	to    common.Address
+	data  []byte
+	value *big.Int
+	gas   uint64
+}
+
+// ReplayApplication represents a sequence of recorded transactions replayed by
+// its users. While the application is thread-safe, each created user should be
+// used in a single thread only.
+type ReplayApplication struct {
+	txs            []replayedTx
+	next           *atomic.Uint64 // position of the next replayed transaction, shared by all users
+	accountFactory *AccountFactory
+	senders        nonceCounter
+}
+
+// CreateUsers creates a list of new users for the app.
+func (f *ReplayApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		users[i] = &ReplayUser{
+			sender: workerAccount,
+			txs:    f.txs,
+			next:   f.next,
+		}
+		addresses[i] = workerAccount.address
+		f.senders.add(workerAccount)
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
+	return users, err
+}
+
+// GetReceivedTransactions obtains the number of replayed transactions included
+// in the chain, as indicated by the nonces of the users. Transactions reverted
+// by the replay, e.g. due to state missing from the recorded network, are
+// counted as well.
+func (f *ReplayApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	return f.senders.getIncludedTransactions(ctx, rpcClient)
+}
+
+// ReplayUser represents a user replaying recorded transactions. The users of an
+// application take turns in replaying the recorded sequence, which is restarted
+// once all transactions have been replayed.
+// A user is supposed to be used in a single thread.
+type ReplayUser struct {
+	sender  *Account
+	txs     []replayedTx
+	next    *atomic.Uint64
+	sentTxs atomic.Uint64
+}
+
+func (g *ReplayUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	recorded := g.txs[(g.next.Add(1)-1)%uint64(len(g.txs))]
+	tx, err := createTx(g.sender, recorded.to, recorded.value, recorded.data, currentGasPrice, recorded.gas)
+	if err == nil {
+		g.sentTxs.Add(1)
+	}
+	return tx, err
+}
+
+func (g *ReplayUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
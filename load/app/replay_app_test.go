@@ -0,0 +1,181 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/mock/gomock"
+)
+
+// writeRecording writes the given recorded transactions into a file of a
+// temporary directory and returns its path.
+func writeRecording(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "transactions.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		t.Fatalf("failed to write recorded transactions: %v", err)
+	}
+	return path
+}
+
+func TestLoadRecording_TransactionsAreSplitIntoCreationsAndCalls(t *testing.T) {
+	path := writeRecording(t,
+		`{"to":null,"input":"0x6001","value":"0x0","gas":"0x100000","contractAddress":"0x0000000000000000000000000000000000000001"}`,
+		`{"from":"0x0000000000000000000000000000000000000009","to":"0x0000000000000000000000000000000000000001","input":"0x5b34b966","gas":"0x6d84"}`,
+		`{"to":"0x0000000000000000000000000000000000000002","input":"0x","value":"0xa","gas":"0x5208"}`,
+	)
+	recording, err := loadRecording(path)
+	if err != nil {
+		t.Fatalf("failed to load recorded transactions: %v", err)
+	}
+	if len(recording.creations) != 1 || len(recording.calls) != 2 {
+		t.Fatalf("unexpected number of transactions, %d creations, %d calls", len(recording.creations), len(recording.calls))
+	}
+	if got := *recording.creations[0].ContractAddress; got != (common.Address{19: 1}) {
+		t.Errorf("unexpected contract address, got %v", got)
+	}
+	if got := recording.calls[0]; *got.To != (common.Address{19: 1}) || uint64(got.Gas) != 28036 || got.getValue().Sign() != 0 {
+		t.Errorf("unexpected call, to %v, gas %d, value %v", got.To, got.Gas, got.getValue())
+	}
+	if got := recording.calls[1].getValue(); got.Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("unexpected value, wanted 10, got %v", got)
+	}
+}
+
+func TestLoadRecording_InvalidRecordingsAreRejected(t *testing.T) {
+	call := `{"to":"0x0000000000000000000000000000000000000001","gas":"0x5208"}`
+	tests := map[string][]string{
+		"no transactions to replay":      {`{"input":"0x6001","gas":"0x5208"}`},
+		"invalid transaction 2":          {call, `{"to":"0x01"}`},
+		"transaction 1 has no gas limit": {`{"to":"0x0000000000000000000000000000000000000001"}`},
+		"has no creation code":           {`{"gas":"0x5208"}`, call},
+		"is no contract creation":        {`{"to":"0x0000000000000000000000000000000000000001","gas":"0x5208","contractAddress":"0x0000000000000000000000000000000000000002"}`},
+	}
+	for issue, lines := range tests {
+		if _, err := loadRecording(writeRecording(t, lines...)); err == nil || !strings.Contains(err.Error(), issue) {
+			t.Errorf("issue %q was not detected, got %v", issue, err)
+		}
+	}
+	if err := (ReplayConfig{Path: "missing.jsonl"}).Check(); err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("missing file was not detected, got %v", err)
+	}
+}
+
+func TestRelocate_RecordedAddressesAreReplaced(t *testing.T) {
+	recorded := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	deployed := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, common.LeftPadBytes(recorded.Bytes(), 32)...)
+
+	got := relocate(data, map[common.Address]common.Address{recorded: deployed})
+	want := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, common.LeftPadBytes(deployed.Bytes(), 32)...)
+	if string(got) != string(want) {
+		t.Errorf("unexpected relocated data, wanted %x, got %x", want, got)
+	}
+}
+
+func TestReplayApplication_CallsAreRedirectedToDeployedContracts(t *testing.T) {
+	deployed := crypto.CreateAddress(common.Address{}, 7)
+	path := writeRecording(t,
+		`{"to":null,"input":"0x6001","gas":"0x100000","contractAddress":"0x1111111111111111111111111111111111111111"}`,
+		`{"to":"0x1111111111111111111111111111111111111111","input":"0x5b34b966","gas":"0x6d84"}`,
+	)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(0xFA))
+	if err != nil {
+		t.Fatalf("failed to create transaction options: %v", err)
+	}
+	opts.GasPrice = big.NewInt(1_000)
+	opts.Nonce = big.NewInt(7)
+
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	appContext := NewMockAppContext(ctrl)
+	appContext.EXPECT().GetClient().Return(rpcClient).AnyTimes()
+	appContext.EXPECT().GetTreasure().Return(&Account{})
+	appContext.EXPECT().GetTransactOptions(gomock.Any(), gomock.Any()).Return(opts, nil)
+	rpcClient.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(0xFA), nil)
+	rpcClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tx *types.Transaction) error {
+		if tx.To() != nil || string(tx.Data()) != string([]byte{0x60, 0x01}) || tx.Gas() != 0x100000 {
+			t.Errorf("unexpected deployment, to %v, data %x, gas %d", tx.To(), tx.Data(), tx.Gas())
+		}
+		return nil
+	})
+	appContext.EXPECT().GetReceipt(gomock.Any(), gomock.Any()).Return(&types.Receipt{
+		Status:          types.ReceiptStatusSuccessful,
+		ContractAddress: deployed,
+	}, nil)
+
+	application, err := NewReplayApplication(context.Background(), appContext, 0, 0, AppConfig{Configs: []Config{ReplayConfig{Path: path}}})
+	if err != nil {
+		t.Fatalf("failed to create application: %v", err)
+	}
+	txs := application.(*ReplayApplication).txs
+	if len(txs) != 1 || txs[0].to != deployed || txs[0].gas != 28036 {
+		t.Errorf("call was not redirected to the deployed contract, got %v", txs)
+	}
+}
+
+func TestReplayUser_RecordedTransactionsAreReplayedInTurn(t *testing.T) {
+	txs := []replayedTx{
+		{to: common.Address{1}, data: []byte{1}, value: big.NewInt(0), gas: 21_000},
+		{to: common.Address{2}, data: []byte{2}, value: big.NewInt(5), gas: 22_000},
+		{to: common.Address{3}, data: []byte{3}, value: big.NewInt(0), gas: 23_000},
+	}
+	next := &atomic.Uint64{}
+	users := []*ReplayUser{}
+	for i := 0; i < 2; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		users = append(users, &ReplayUser{
+			sender: &Account{privateKey: key, chainID: big.NewInt(0xFA)},
+			txs:    txs,
+			next:   next,
+		})
+	}
+
+	for i := 0; i < 5; i++ {
+		tx, err := users[i%2].GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		want := txs[i%3]
+		if *tx.To() != want.to || string(tx.Data()) != string(want.data) || tx.Value().Cmp(want.value) != 0 || tx.Gas() != want.gas {
+			t.Errorf("unexpected transaction %d, to %v, data %x, value %v, gas %d", i, tx.To(), tx.Data(), tx.Value(), tx.Gas())
+		}
+	}
+	if got := users[0].GetSentTransactions(); got != 3 {
+		t.Errorf("unexpected number of sent transactions, wanted 3, got %d", got)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -33,21 +34,21 @@ import (
 // NewStoreApplication deploys a Store contract to the chain.
 // The Store contract is a simple contract managing a user-private key/value store.
 // It is intended to produce state-heavy transactions.
-func NewStoreApplication(ctxt AppContext, feederId, appId uint32) (Application, error) {
+func NewStoreApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
 
 	client := ctxt.GetClient()
-	chainId, err := client.ChainID(context.Background())
+	chainId, err := client.ChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID; %w", err)
 	}
 
 	// Deploy the Store contract to be used by this application.
-	_, receipt, err := DeployContract(ctxt, contract.DeployStore)
+	_, receipt, err := DeployContract(ctx, ctxt, contract.DeployStore)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy Store contract; %w", err)
 	}
 
-	accountFactory, err := NewAccountFactory(chainId, feederId, appId)
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
 	if err != nil {
 		return nil, err
 	}
@@ -74,13 +75,13 @@ type StoreApplication struct {
 }
 
 // CreateUsers creates a list of new users for the app.
-func (f *StoreApplication) CreateUsers(appContext AppContext, numUsers int) ([]User, error) {
+func (f *StoreApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
 
 	users := make([]User, numUsers)
 	addresses := make([]common.Address, numUsers)
 	for i := 0; i < numUsers; i++ {
 		// Generate a new account for each worker - avoid account nonces related bottlenecks
-		workerAccount, err := f.accountFactory.CreateAccount(appContext.GetClient())
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
 		if err != nil {
 			return nil, err
 		}
@@ -94,17 +95,17 @@ func (f *StoreApplication) CreateUsers(appContext AppContext, numUsers int) ([]U
 
 	fundsPerUser := big.NewInt(1_000)
 	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
-	err := appContext.FundAccounts(addresses, fundsPerUser)
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
 	return users, err
 }
 
-func (f *StoreApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+func (f *StoreApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
 	// get a representation of the deployed contract
 	storeContract, err := contract.NewStore(f.contractAddress, rpcClient)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get Store contract representation; %w", err)
 	}
-	count, err := storeContract.GetCount(nil)
+	count, err := storeContract.GetCount(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		return 0, err
 	}
@@ -134,9 +135,16 @@ func (g *StoreUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, er
 		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
 	}
 
-	// prepare tx
+	// prepare tx, touching the count in the first slot of the contract and
+	// the filled entries of the sender in the data mapping in the second one
 	const gasLimit = 52000 + 25000*updateSize // wild guess ...
-	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit)
+	slots := make([]common.Hash, 0, updateSize+1)
+	slots = append(slots, getStorageSlot(0))
+	entries := getMappingSlot(common.BytesToHash(g.sender.address.Bytes()), getStorageSlot(1))
+	for key := from; key < to; key++ {
+		slots = append(slots, getMappingSlot(common.BigToHash(big.NewInt(key)), entries))
+	}
+	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, currentGasPrice, gasLimit, slots...)
 	if err == nil {
 		g.sentTxs.Add(1)
 	}
@@ -0,0 +1,134 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// transferGasLimit is the gas consumed by a plain value transfer between
+// externally owned accounts.
+const transferGasLimit = 21_000
+
+// NewTransferApplication creates an application sending native tokens between
+// accounts. No contract is involved, making it the cheapest kind of transaction
+// and the baseline for the throughput of the network.
+func NewTransferApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	chainId, err := ctxt.GetClient().ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferApplication{
+		accountFactory: accountFactory,
+	}, nil
+}
+
+// TransferApplication represents users sending native tokens to each other.
+// Without a contract counting the transactions, received transactions are
+// derived from the nonces of the sending accounts.
+// While the application is thread-safe, each created user should be used in a
+// single thread only.
+type TransferApplication struct {
+	accountFactory *AccountFactory
+	senders        nonceCounter
+}
+
+// CreateUsers creates a list of new users for the app. Each user sends tokens
+// to the other users created along with it, or to random recipients if it is
+// the only user created.
+func (f *TransferApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	senders := make([]*TransferUser, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		senders[i] = &TransferUser{
+			sender: workerAccount,
+		}
+		users[i] = senders[i]
+		addresses[i] = workerAccount.address
+	}
+	for i, sender := range senders {
+		sender.recipients = append(append([]common.Address{}, addresses[:i]...), addresses[i+1:]...)
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	if err := appContext.FundAccounts(ctx, addresses, fundsPerUser); err != nil {
+		return nil, err
+	}
+
+	for _, sender := range senders {
+		f.senders.add(sender.sender)
+	}
+	return users, nil
+}
+
+// GetReceivedTransactions sums up the transactions of all users of the app
+// included in the chain, as indicated by the nonces of their accounts.
+func (f *TransferApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	return f.senders.getIncludedTransactions(ctx, rpcClient)
+}
+
+// TransferUser represents a user sending native tokens to other accounts.
+// A user is supposed to be used in a single thread.
+type TransferUser struct {
+	sender     *Account
+	recipients []common.Address
+	sentTxs    atomic.Uint64
+}
+
+func (g *TransferUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	tx, err := createTx(g.sender, g.getRecipient(), big.NewInt(1), nil, currentGasPrice, transferGasLimit)
+	if err == nil {
+		g.sentTxs.Add(1)
+	}
+	return tx, err
+}
+
+// getRecipient picks the recipient of the next transfer among the other
+// users, or a random address if there are no other users.
+func (g *TransferUser) getRecipient() common.Address {
+	if len(g.recipients) > 0 {
+		return g.recipients[rand.Intn(len(g.recipients))]
+	}
+	var recipient common.Address
+	rand.Read(recipient[:])
+	return recipient
+}
+
+func (g *TransferUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferApplication_UsersSendToEachOther(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	appContext := NewMockAppContext(ctrl)
+	appContext.EXPECT().GetClient().Return(rpcClient).AnyTimes()
+	appContext.EXPECT().FundAccounts(gomock.Any(), gomock.Any(), gomock.Any())
+	rpcClient.EXPECT().ChainID(gomock.Any()).Return(big.NewInt(0xFA), nil)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(0), nil).Times(3)
+
+	application, err := NewTransferApplication(context.Background(), appContext, 0, 0, AppConfig{})
+	if err != nil {
+		t.Fatalf("failed to create application: %v", err)
+	}
+	users, err := application.CreateUsers(context.Background(), appContext, 3)
+	if err != nil {
+		t.Fatalf("failed to create users: %v", err)
+	}
+
+	addresses := []common.Address{}
+	for _, user := range users {
+		addresses = append(addresses, user.(*TransferUser).sender.address)
+	}
+	for i, user := range users {
+		tx, err := user.GenerateTx(big.NewInt(1_000))
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		if *tx.To() == addresses[i] || !slices.Contains(addresses, *tx.To()) {
+			t.Errorf("transfer should be sent to another user, got %v", tx.To())
+		}
+		if tx.Gas() != transferGasLimit || len(tx.Data()) != 0 {
+			t.Errorf("unexpected transaction, gas %d, data %x", tx.Gas(), tx.Data())
+		}
+		if got := user.GetSentTransactions(); got != 1 {
+			t.Errorf("unexpected number of sent transactions, wanted 1, got %d", got)
+		}
+	}
+}
+
+func TestTransferApplication_ReceivedTransactionsAreDerivedFromNonces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	first := &Account{address: common.Address{1}, nonce: 5}
+	second := &Account{address: common.Address{2}}
+	application := &TransferApplication{}
+	application.senders.add(first)
+	application.senders.add(second)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), first.address, nil).Return(uint64(8), nil)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), second.address, nil).Return(uint64(4), nil)
+
+	received, err := application.GetReceivedTransactions(context.Background(), rpcClient)
+	if err != nil {
+		t.Fatalf("failed to get received transactions: %v", err)
+	}
+	if received != 7 {
+		t.Errorf("unexpected number of received transactions, wanted 7, got %d", received)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// TxType defines the kind of transactions sent by the users of an application.
+type TxType string
+
+const (
+	// LegacyTxType transactions pay a fixed gas price.
+	LegacyTxType TxType = "legacy"
+	// AccessListTxType transactions pay a fixed gas price and declare the
+	// addresses and storage slots they access (EIP-2930).
+	AccessListTxType TxType = "access_list"
+	// DynamicFeeTxType transactions pay the base fee of the block plus a tip,
+	// bounded by a fee cap (EIP-1559).
+	DynamicFeeTxType TxType = "dynamic_fee"
+)
+
+// ParseTxType converts the name of a transaction type into a TxType. An empty
+// name is interpreted as legacy transactions.
+func ParseTxType(name string) (TxType, error) {
+	switch txType := TxType(strings.ToLower(name)); txType {
+	case "":
+		return LegacyTxType, nil
+	case LegacyTxType, AccessListTxType, DynamicFeeTxType:
+		return txType, nil
+	}
+	return "", fmt.Errorf("unknown transaction type '%s', supported are %s, %s and %s", name, LegacyTxType, AccessListTxType, DynamicFeeTxType)
+}
+
+// tipCapShare is the share of the fee cap offered as tip by dynamic fee
+// transactions, the fee cap being the regular gas price.
+const tipCapShare = 10
+
+// createTx creates a transaction of the type configured for the sending
+// account and signs it. The given gas price is paid by legacy and access list
+// transactions, while it is the fee cap of dynamic fee transactions. The given
+// storage slots of the recipient are declared by access list transactions;
+// they are the slots accessed by the call, as far as they are known upfront.
+func createTx(from *Account, toAddress common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64, storageKeys ...common.Hash) (*types.Transaction, error) {
+	return newTx(from, &toAddress, value, data, gasPrice, gasLimit, storageKeys)
+}
+
+// createDeploymentTx creates a transaction deploying a contract with the given
+// creation code, of the type configured for the sending account, and signs it.
+func createDeploymentTx(from *Account, code []byte, gasPrice *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	return newTx(from, nil, big.NewInt(0), code, gasPrice, gasLimit, nil)
+}
+
+// newTx creates and signs a transaction sent to the given address, or a
+// contract creation if the address is nil.
+func newTx(from *Account, to *common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64, storageKeys []common.Hash) (*types.Transaction, error) {
+	nonce := from.getNextNonce()
+	var txData types.TxData
+	switch from.txType {
+	case DynamicFeeTxType:
+		txData = &types.DynamicFeeTx{
+			ChainID:   from.chainID,
+			Nonce:     nonce,
+			GasTipCap: new(big.Int).Div(gasPrice, big.NewInt(tipCapShare)),
+			GasFeeCap: gasPrice,
+			Gas:       gasLimit,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		}
+	case AccessListTxType:
+		// Declared entries add to the intrinsic gas of the transaction, which
+		// the limit has to cover. Declared storage slots are warm when
+		// accessed, saving more gas during the execution than they add.
+		accessList := types.AccessList{}
+		if to != nil {
+			accessList = types.AccessList{{Address: *to, StorageKeys: storageKeys}}
+			gasLimit += params.TxAccessListAddressGas
+			gasLimit += params.TxAccessListStorageKeyGas * uint64(len(storageKeys))
+		}
+		txData = &types.AccessListTx{
+			ChainID:    from.chainID,
+			Nonce:      nonce,
+			GasPrice:   gasPrice,
+			Gas:        gasLimit,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: accessList,
+		}
+	default:
+		txData = &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gasLimit,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	}
+	return types.SignTx(types.NewTx(txData), types.NewLondonSigner(from.chainID), from.privateKey)
+}
+
+// getStorageSlot returns the storage key of a state variable of a contract
+// occupying the given slot, see the storage layout of Solidity contracts.
+func getStorageSlot(slot uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(slot))
+}
+
+// getMappingSlot returns the storage key of the entry of the given key in a
+// mapping occupying the given slot of a contract, i.e. keccak256(key . slot)
+// for keys of value types padded to 32 bytes.
+func getMappingSlot(key common.Hash, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), slot.Bytes())
+}
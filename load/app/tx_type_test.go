@@ -0,0 +1,182 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestParseTxType_NamesAreParsed(t *testing.T) {
+	tests := map[string]TxType{
+		"":            LegacyTxType,
+		"legacy":      LegacyTxType,
+		"access_list": AccessListTxType,
+		"Dynamic_Fee": DynamicFeeTxType,
+	}
+	for name, want := range tests {
+		got, err := ParseTxType(name)
+		if err != nil || got != want {
+			t.Errorf("unexpected tx type for %q, wanted %v, got %v, %v", name, want, got, err)
+		}
+	}
+	if _, err := ParseTxType("blob"); err == nil {
+		t.Errorf("unknown tx type should be rejected")
+	}
+}
+
+func TestCreateTx_TransactionsOfConfiguredTypeAreCreated(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	chainId := big.NewInt(0xFA)
+	to := common.Address{1}
+	gasPrice := big.NewInt(1_000)
+
+	tests := map[TxType]uint8{
+		"":               types.LegacyTxType,
+		LegacyTxType:     types.LegacyTxType,
+		AccessListTxType: types.AccessListTxType,
+		DynamicFeeTxType: types.DynamicFeeTxType,
+	}
+	for txType, want := range tests {
+		account := &Account{privateKey: key, address: crypto.PubkeyToAddress(key.PublicKey), chainID: chainId, txType: txType}
+		tx, err := createTx(account, to, big.NewInt(0), nil, gasPrice, 21_000)
+		if err != nil {
+			t.Fatalf("failed to create tx: %v", err)
+		}
+		if tx.Type() != want {
+			t.Errorf("unexpected type of %v transaction, wanted %d, got %d", txType, want, tx.Type())
+		}
+		sender, err := types.Sender(types.NewLondonSigner(chainId), tx)
+		if err != nil || sender != account.address {
+			t.Errorf("invalid signature of %v transaction, got sender %v, %v", txType, sender, err)
+		}
+		if tx.GasFeeCap().Cmp(gasPrice) != 0 {
+			t.Errorf("unexpected fee cap of %v transaction, wanted %v, got %v", txType, gasPrice, tx.GasFeeCap())
+		}
+	}
+}
+
+func TestCreateTx_DynamicFeeTransactionsOfferTip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	account := &Account{privateKey: key, chainID: big.NewInt(0xFA), txType: DynamicFeeTxType}
+	tx, err := createTx(account, common.Address{1}, big.NewInt(0), nil, big.NewInt(1_000), 21_000)
+	if err != nil {
+		t.Fatalf("failed to create tx: %v", err)
+	}
+	if got, want := tx.GasTipCap(), big.NewInt(100); got.Cmp(want) != 0 {
+		t.Errorf("unexpected tip cap, wanted %v, got %v", want, got)
+	}
+}
+
+func TestCreateTx_AccessListGasIsCovered(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	to := common.Address{1}
+	account := &Account{privateKey: key, chainID: big.NewInt(0xFA), txType: AccessListTxType}
+	tx, err := createTx(account, to, big.NewInt(0), nil, big.NewInt(1_000), 21_000)
+	if err != nil {
+		t.Fatalf("failed to create tx: %v", err)
+	}
+	if got, want := tx.Gas(), 21_000+params.TxAccessListAddressGas; got != want {
+		t.Errorf("unexpected gas limit, wanted %d, got %d", want, got)
+	}
+	if list := tx.AccessList(); len(list) != 1 || list[0].Address != to {
+		t.Errorf("unexpected access list, got %v", list)
+	}
+}
+
+func TestCreateTx_AccessListDeclaresStorageSlots(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	to := common.Address{1}
+	slots := []common.Hash{getStorageSlot(0), getStorageSlot(1)}
+	account := &Account{privateKey: key, chainID: big.NewInt(0xFA), txType: AccessListTxType}
+	tx, err := createTx(account, to, big.NewInt(0), nil, big.NewInt(1_000), 21_000, slots...)
+	if err != nil {
+		t.Fatalf("failed to create tx: %v", err)
+	}
+	if got, want := tx.Gas(), 21_000+params.TxAccessListAddressGas+2*params.TxAccessListStorageKeyGas; got != want {
+		t.Errorf("unexpected gas limit, wanted %d, got %d", want, got)
+	}
+	list := tx.AccessList()
+	if len(list) != 1 || list[0].Address != to || !slices.Equal(list[0].StorageKeys, slots) {
+		t.Errorf("unexpected access list, got %v", list)
+	}
+}
+
+func TestCreateTx_StorageSlotsAreOnlyDeclaredByAccessListTransactions(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	for _, txType := range []TxType{LegacyTxType, DynamicFeeTxType} {
+		account := &Account{privateKey: key, chainID: big.NewInt(0xFA), txType: txType}
+		tx, err := createTx(account, common.Address{1}, big.NewInt(0), nil, big.NewInt(1_000), 21_000, getStorageSlot(0))
+		if err != nil {
+			t.Fatalf("failed to create tx: %v", err)
+		}
+		if tx.Gas() != 21_000 || len(tx.AccessList()) != 0 {
+			t.Errorf("unexpected %v transaction, gas %d, access list %v", txType, tx.Gas(), tx.AccessList())
+		}
+	}
+}
+
+func TestGetMappingSlot_MatchesSolidityStorageLayout(t *testing.T) {
+	// The slot of balanceOf[0x0101..01] of a mapping in slot 1, as computed by
+	// keccak256(abi.encode(address(0x0101..01), uint256(1))).
+	owner := common.HexToAddress("0x0101010101010101010101010101010101010101")
+	want := crypto.Keccak256Hash(
+		common.LeftPadBytes(owner.Bytes(), 32),
+		common.LeftPadBytes([]byte{1}, 32),
+	)
+	if got := getMappingSlot(common.BytesToHash(owner.Bytes()), getStorageSlot(1)); got != want {
+		t.Errorf("unexpected slot, wanted %v, got %v", want, got)
+	}
+}
+
+func TestCreateDeploymentTx_TransactionsCreateContracts(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	for _, txType := range []TxType{LegacyTxType, AccessListTxType, DynamicFeeTxType} {
+		account := &Account{privateKey: key, chainID: big.NewInt(0xFA), txType: txType}
+		tx, err := createDeploymentTx(account, []byte{0x60, 0x00}, big.NewInt(1_000), 60_000)
+		if err != nil {
+			t.Fatalf("failed to create tx: %v", err)
+		}
+		if tx.To() != nil || string(tx.Data()) != string([]byte{0x60, 0x00}) || tx.Gas() != 60_000 || len(tx.AccessList()) != 0 {
+			t.Errorf("unexpected %v deployment, to %v, data %x, gas %d, access list %v", txType, tx.To(), tx.Data(), tx.Gas(), tx.AccessList())
+		}
+	}
+}
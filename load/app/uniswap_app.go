@@ -18,10 +18,14 @@ package app
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"sync/atomic"
+	"time"
 
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
@@ -31,26 +35,141 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-const TokensInChain = 4
-const PairsInChain = TokensInChain - 1
+// UniswapApplicationType is the type of applications swapping ERC-20 tokens
+// using Uniswap pairs.
+const UniswapApplicationType = "uniswap"
+
+// UniswapTopology defines the pairs of tokens created by a Uniswap application.
+type UniswapTopology string
+
+const (
+	// ChainUniswapTopology pairs each token with the next one, such that the
+	// tokens form a chain.
+	ChainUniswapTopology UniswapTopology = "chain"
+	// StarUniswapTopology pairs the first token with each other token.
+	StarUniswapTopology UniswapTopology = "star"
+	// FullUniswapTopology pairs each token with each other token.
+	FullUniswapTopology UniswapTopology = "full"
+)
+
+// UniswapAmountDistribution defines the distribution of the amounts swapped by
+// the users of a Uniswap application.
+type UniswapAmountDistribution string
+
+const (
+	// ConstantUniswapAmounts swaps the configured amount in each transaction.
+	ConstantUniswapAmounts UniswapAmountDistribution = "constant"
+	// UniformUniswapAmounts swaps uniformly distributed amounts with the
+	// configured amount as mean.
+	UniformUniswapAmounts UniswapAmountDistribution = "uniform"
+	// ExponentialUniswapAmounts swaps exponentially distributed amounts with
+	// the configured amount as mean, i.e. mostly small and rarely large ones.
+	ExponentialUniswapAmounts UniswapAmountDistribution = "exponential"
+)
+
+// UniswapConfig defines the tokens and pairs of a Uniswap application, and the
+// swaps sent by its users.
+type UniswapConfig struct {
+	// Tokens is the number of ERC-20 tokens traded.
+	Tokens int
+	// Topology defines the pairs of tokens.
+	Topology UniswapTopology
+	// PathLength is the number of pairs each swap passes, 0 for the longest
+	// path possible in the topology.
+	PathLength int
+	// Amount is the mean amount of tokens swapped in one transaction.
+	Amount int64
+	// Distribution is the distribution of the swapped amounts.
+	Distribution UniswapAmountDistribution
+	// HotPairShare is the share of swaps passing the hot pair, which is the
+	// pair of the first two tokens, in addition to the swaps passing it by
+	// chance. Concentrating swaps on one pair causes contention on its
+	// storage slots.
+	HotPairShare float64
+}
+
+// DefaultUniswapConfig is the configuration of Uniswap applications, unless
+// configured otherwise. Swaps pass a chain of four tokens end to end.
+var DefaultUniswapConfig = UniswapConfig{
+	Tokens:       4,
+	Topology:     ChainUniswapTopology,
+	PathLength:   0,
+	Amount:       100,
+	Distribution: ConstantUniswapAmounts,
+	HotPairShare: 0,
+}
+
+// maxUniswapTokens is the maximum number of tokens of a Uniswap application,
+// which bounds the number of pairs deployed and configured.
+const maxUniswapTokens = 32
+
+// maxUniswapAmount is the maximum mean amount of tokens swapped in one
+// transaction, such that uniformly distributed amounts do not overflow.
+const maxUniswapAmount = math.MaxInt64 / 2
 
-var AmountSwapped = big.NewInt(100) // swapped in one tx
 var WorkerInitialBalance = big.NewInt(0).Mul(big.NewInt(1_000_000_000), big.NewInt(1_000000000000000000))
 var PairLiquidity = big.NewInt(0).Mul(big.NewInt(1_000_000_000_000_000), big.NewInt(1_000000000000000000))
 
+// Type returns the type of applications configured by swaps.
+func (config UniswapConfig) Type() string {
+	return UniswapApplicationType
+}
+
+// Check tests whether the tokens and pairs can be created and swapped as
+// configured.
+func (config UniswapConfig) Check() error {
+	errs := []error{}
+	if config.Tokens < 2 || config.Tokens > maxUniswapTokens {
+		errs = append(errs, fmt.Errorf("number of tokens must be between 2 and %d, got %d", maxUniswapTokens, config.Tokens))
+	}
+	switch config.Topology {
+	case ChainUniswapTopology, StarUniswapTopology, FullUniswapTopology:
+		if maxLength := getMaxUniswapPathLength(config.Topology, config.Tokens); config.PathLength < 0 || config.PathLength > maxLength {
+			errs = append(errs, fmt.Errorf("path length must be between 0 and %d for %d tokens in a %s, got %d", maxLength, config.Tokens, config.Topology, config.PathLength))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown topology '%s', supported are %s, %s and %s", config.Topology, ChainUniswapTopology, StarUniswapTopology, FullUniswapTopology))
+	}
+	if config.Amount < 1 || config.Amount > maxUniswapAmount {
+		errs = append(errs, fmt.Errorf("swapped amount must be between 1 and %d, got %d", maxUniswapAmount, config.Amount))
+	}
+	switch config.Distribution {
+	case ConstantUniswapAmounts, UniformUniswapAmounts, ExponentialUniswapAmounts:
+	default:
+		errs = append(errs, fmt.Errorf("unknown amount distribution '%s', supported are %s, %s and %s", config.Distribution, ConstantUniswapAmounts, UniformUniswapAmounts, ExponentialUniswapAmounts))
+	}
+	if config.HotPairShare < 0 || config.HotPairShare > 1 {
+		errs = append(errs, fmt.Errorf("hot pair share must be between 0 and 1, got %v", config.HotPairShare))
+	}
+	return errors.Join(errs...)
+}
+
 // NewUniswapApplication deploys a new Uniswap dapp to the chain.
-// Created Uniswap pairs allows to swap first ERC-20 token for second, second for third etc.
-// This app swaps first token for the last one, using all intermediate tokens.
-func NewUniswapApplication(context AppContext, feederId, appId uint32) (Application, error) {
+// Created Uniswap pairs allow to swap tokens paired by the configured topology.
+// This app swaps tokens along paths of the configured length, using all
+// intermediate tokens.
+func NewUniswapApplication(ctx context.Context, context AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	uniswapConfig, found := getConfig[UniswapConfig](config)
+	if !found {
+		uniswapConfig = DefaultUniswapConfig
+	}
+	if err := uniswapConfig.Check(); err != nil {
+		return nil, err
+	}
+	if uniswapConfig.PathLength == 0 {
+		uniswapConfig.PathLength = getMaxUniswapPathLength(uniswapConfig.Topology, uniswapConfig.Tokens)
+	}
+
 	rpcClient := context.GetClient()
 	primaryAccount := context.GetTreasure()
 
-	tokenAddresses := make([]common.Address, TokensInChain)
-	tokenContracts := make([]*contract.ERC20, TokensInChain)
-	pairsAddresses := make([]common.Address, PairsInChain)
-	pairsContracts := make([]*contract.UniswapV2Pair, PairsInChain)
+	pairs := getUniswapPairs(uniswapConfig.Topology, uniswapConfig.Tokens)
+	tokenAddresses := make([]common.Address, uniswapConfig.Tokens)
+	tokenContracts := make([]*contract.ERC20, uniswapConfig.Tokens)
+	pairsAddresses := make([]common.Address, len(pairs))
+	pairsContracts := make([]*contract.UniswapV2Pair, len(pairs))
 
-	txOpts, err := context.GetTransactOptions(primaryAccount)
+	txOpts, err := context.GetTransactOptions(ctx, primaryAccount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %w", err)
 	}
@@ -63,7 +182,7 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 	deployments := []*types.Transaction{tx}
 
 	// Deploy tokens
-	for i := 0; i < TokensInChain; i++ {
+	for i := range tokenContracts {
 		txOpts.Nonce = new(big.Int).Add(txOpts.Nonce, big.NewInt(1))
 		name := fmt.Sprintf("Testing token %d", i)
 		symbol := fmt.Sprintf("TOK%d", i)
@@ -75,7 +194,7 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 	}
 
 	// Deploy pairs
-	for i := 0; i < PairsInChain; i++ {
+	for i := range pairsContracts {
 		txOpts.Nonce = new(big.Int).Add(txOpts.Nonce, big.NewInt(1))
 		pairsAddresses[i], tx, pairsContracts[i], err = contract.DeployUniswapV2Pair(txOpts, rpcClient)
 		if err != nil {
@@ -86,7 +205,7 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 
 	// wait until contracts are available on the chain
 	for i, tx := range deployments {
-		receipt, err := context.GetReceipt(tx.Hash())
+		receipt, err := context.GetReceipt(ctx, tx.Hash())
 		if err != nil {
 			return nil, fmt.Errorf("failed to wait until the Uniswap contract is deployed; %w", err)
 		}
@@ -97,9 +216,9 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 
 	// Mint tokens into pairs
 	configSteps := []*types.Transaction{}
-	for i := 0; i < PairsInChain; i++ {
-		tokenA, tokenB := tokenContracts[i], tokenContracts[i+1]
-		tokenAAddress, tokenBAddress := tokenAddresses[i], tokenAddresses[i+1]
+	for i, pair := range pairs {
+		tokenA, tokenB := tokenContracts[pair[0]], tokenContracts[pair[1]]
+		tokenAAddress, tokenBAddress := tokenAddresses[pair[0]], tokenAddresses[pair[1]]
 		txOpts.Nonce = new(big.Int).Add(txOpts.Nonce, big.NewInt(1))
 		tx, err = tokenA.Mint(txOpts, pairsAddresses[i], PairLiquidity)
 		if err != nil {
@@ -126,7 +245,7 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 	}
 
 	// Whitelist Uniswap router in the token (skip setting allowance by every user)
-	for i := 0; i < TokensInChain; i++ {
+	for i := range tokenContracts {
 		txOpts.Nonce = new(big.Int).Add(txOpts.Nonce, big.NewInt(1))
 		tx, err = tokenContracts[i].WhitelistSpender(txOpts, routerAddress)
 		if err != nil {
@@ -135,7 +254,7 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 		configSteps = append(configSteps, tx)
 	}
 
-	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId, config.TxType)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +267,7 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 
 	// wait until the starting accounts will be available on the chain (and will be possible to call CreateUser)
 	for i, tx := range configSteps {
-		receipt, err := context.GetReceipt(tx.Hash())
+		receipt, err := context.GetReceipt(ctx, tx.Hash())
 		if err != nil {
 			return nil, fmt.Errorf("failed to wait until the Uniswap contracts are configured; %w", err)
 		}
@@ -158,9 +277,11 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 	}
 
 	return &UniswapApplication{
+		config:          uniswapConfig,
 		routerAbi:       routerAbi,
 		routerAddress:   routerAddress,
 		tokensAddresses: tokenAddresses,
+		pairs:           pairs,
 		pairsAddresses:  pairsAddresses,
 		accountFactory:  accountFactory,
 	}, nil
@@ -169,33 +290,36 @@ func NewUniswapApplication(context AppContext, feederId, appId uint32) (Applicat
 // UniswapApplication represents one application deployed to the network - an ERC-20 contract.
 // Each created app should be used in a single thread only.
 type UniswapApplication struct {
+	config          UniswapConfig // with the path length resolved
 	routerAbi       *abi.ABI
 	routerAddress   common.Address
 	tokensAddresses []common.Address
+	pairs           [][2]int // indices of the tokens of each pair
 	pairsAddresses  []common.Address
 	accountFactory  *AccountFactory
 }
 
 // CreateUsers creates a list of new users for the app.
-func (f *UniswapApplication) CreateUsers(appContext AppContext, numUsers int) ([]User, error) {
+func (f *UniswapApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
 
 	// Create a list of users.
 	users := make([]User, numUsers)
 	addresses := make([]common.Address, numUsers)
 	for i := 0; i < numUsers; i++ {
 		// Generate a new account for each worker - avoid account nonces related bottlenecks
-		workerAccount, err := f.accountFactory.CreateAccount(appContext.GetClient())
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
 		if err != nil {
 			return nil, err
 		}
 		users[i] = &UniswapUser{
-			routerAbi:               f.routerAbi,
-			sender:                  workerAccount,
-			routerAddress:           f.routerAddress,
-			tokensAddresses:         f.tokensAddresses,
-			pairsAddresses:          f.pairsAddresses,
-			tokensAddressesReversed: reverseAddresses(f.tokensAddresses),
-			pairsAddressesReversed:  reverseAddresses(f.pairsAddresses),
+			config:          f.config,
+			routerAbi:       f.routerAbi,
+			sender:          workerAccount,
+			routerAddress:   f.routerAddress,
+			tokensAddresses: f.tokensAddresses,
+			pairs:           f.pairs,
+			pairsAddresses:  f.pairsAddresses,
+			random:          rand.New(rand.NewSource(time.Now().UnixNano() + int64(i))),
 		}
 		addresses[i] = workerAccount.address
 	}
@@ -203,52 +327,40 @@ func (f *UniswapApplication) CreateUsers(appContext AppContext, numUsers int) ([
 	// Provide native currency to each user.
 	fundsPerUser := big.NewInt(1_000)
 	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
-	err := appContext.FundAccounts(addresses, fundsPerUser)
+	err := appContext.FundAccounts(ctx, addresses, fundsPerUser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fund accounts; %w", err)
 	}
 
-	// mint ERC-20 tokens for the worker account - tokens to be transferred in the transactions
+	// mint ERC-20 tokens for the worker account - tokens to be transferred in the transactions;
+	// every token may start or end a swap
 	rpcClient := appContext.GetClient()
-	token0Contract, err := contract.NewERC20(f.tokensAddresses[0], rpcClient)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get token representation; %w", err)
-	}
-	tokenNContract, err := contract.NewERC20(f.tokensAddresses[TokensInChain-1], rpcClient)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get token representation; %w", err)
-	}
-
-	receipt, err := appContext.Run(func(opts *bind.TransactOpts) (*types.Transaction, error) {
-		return token0Contract.MintForAll(opts, addresses, WorkerInitialBalance)
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to mint ERC-20; %w", err)
-	}
-	if receipt.Status != types.ReceiptStatusSuccessful {
-		return nil, fmt.Errorf("failed to mint ERC-20; transaction reverted")
-	}
-
-	receipt, err = appContext.Run(func(opts *bind.TransactOpts) (*types.Transaction, error) {
-		return tokenNContract.MintForAll(opts, addresses, WorkerInitialBalance)
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to mint ERC-20; %w", err)
-	}
-	if receipt.Status != types.ReceiptStatusSuccessful {
-		return nil, fmt.Errorf("failed to mint ERC-20; transaction reverted")
+	for _, tokenAddress := range f.tokensAddresses {
+		tokenContract, err := contract.NewERC20(tokenAddress, rpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token representation; %w", err)
+		}
+		receipt, err := appContext.Run(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return tokenContract.MintForAll(opts, addresses, WorkerInitialBalance)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to mint ERC-20; %w", err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, fmt.Errorf("failed to mint ERC-20; transaction reverted")
+		}
 	}
 
 	return users, nil
 }
 
-func (f *UniswapApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+func (f *UniswapApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
 	// get a representation of the deployed contract
 	routerContract, err := contract.NewUniswapRouter(f.routerAddress, rpcClient)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get UniswapRouter representation; %w", err)
 	}
-	count, err := routerContract.GetCount(nil)
+	count, err := routerContract.GetCount(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		return 0, err
 	}
@@ -258,36 +370,36 @@ func (f *UniswapApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (u
 // UniswapUser represents a user sending txs to swap ERC-20 tokens using Uniswap.
 // A generator is supposed to be used in a single thread.
 type UniswapUser struct {
-	routerAbi               *abi.ABI
-	sender                  *Account
-	routerAddress           common.Address
-	tokensAddresses         []common.Address
-	pairsAddresses          []common.Address
-	tokensAddressesReversed []common.Address
-	pairsAddressesReversed  []common.Address
-	sentTxs                 uint64
+	config          UniswapConfig
+	routerAbi       *abi.ABI
+	sender          *Account
+	routerAddress   common.Address
+	tokensAddresses []common.Address
+	pairs           [][2]int
+	pairsAddresses  []common.Address
+	random          *rand.Rand
+	sentTxs         uint64
 }
 
 func (g *UniswapUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
-	var data []byte
-	var err error
-
 	// prepare tx data
-	if rand.Intn(2) == 0 {
-		// swap token1 for tokenN (forward)
-		data, err = g.routerAbi.Pack("swapExactTokensForTokens", AmountSwapped, g.tokensAddresses, g.pairsAddresses)
-	} else {
-		// swap tokenN for token1 (backward)
-		data, err = g.routerAbi.Pack("swapExactTokensForTokens", AmountSwapped, g.tokensAddressesReversed, g.pairsAddressesReversed)
+	tokens, pairs := getUniswapPath(g.pairs, g.config.PathLength, g.config.HotPairShare, g.random)
+	tokensPath := make([]common.Address, len(tokens))
+	for i, token := range tokens {
+		tokensPath[i] = g.tokensAddresses[token]
+	}
+	pairsPath := make([]common.Address, len(pairs))
+	for i, pair := range pairs {
+		pairsPath[i] = g.pairsAddresses[pair]
 	}
+	amount := getUniswapAmount(g.config, g.random)
+	data, err := g.routerAbi.Pack("swapExactTokensForTokens", amount, tokensPath, pairsPath)
 	if err != nil || data == nil {
 		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
 	}
 
 	// prepare tx
-	// swapExactTokensForTokens consumes 157571 for 2 tokens + cca 94314 for each additional token
-	const gasLimit = 160_000 + (TokensInChain-2)*95000
-	tx, err := createTx(g.sender, g.routerAddress, big.NewInt(0), data, currentGasPrice, gasLimit)
+	tx, err := createTx(g.sender, g.routerAddress, big.NewInt(0), data, currentGasPrice, getUniswapGasLimit(len(pairs)))
 	if err == nil {
 		atomic.AddUint64(&g.sentTxs, 1)
 	}
@@ -297,3 +409,116 @@ func (g *UniswapUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction,
 func (g *UniswapUser) GetSentTransactions() uint64 {
 	return atomic.LoadUint64(&g.sentTxs)
 }
+
+// getUniswapGasLimit returns the gas limit of a swap passing the given number
+// of pairs.
+func getUniswapGasLimit(pathLength int) uint64 {
+	// swapExactTokensForTokens consumes 157571 for 2 tokens + cca 94314 for each additional token
+	return 160_000 + uint64(pathLength-1)*95_000
+}
+
+// getUniswapPairs returns the pairs of the given topology as indices of their
+// tokens. The hot pair of the first two tokens comes first.
+func getUniswapPairs(topology UniswapTopology, tokens int) [][2]int {
+	pairs := [][2]int{}
+	switch topology {
+	case ChainUniswapTopology:
+		for i := 0; i+1 < tokens; i++ {
+			pairs = append(pairs, [2]int{i, i + 1})
+		}
+	case StarUniswapTopology:
+		for i := 1; i < tokens; i++ {
+			pairs = append(pairs, [2]int{0, i})
+		}
+	case FullUniswapTopology:
+		for i := 0; i < tokens; i++ {
+			for j := i + 1; j < tokens; j++ {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+	}
+	return pairs
+}
+
+// getMaxUniswapPathLength returns the number of pairs of the longest path
+// without repeated tokens in the given topology.
+func getMaxUniswapPathLength(topology UniswapTopology, tokens int) int {
+	if topology == StarUniswapTopology {
+		return min(2, tokens-1)
+	}
+	return tokens - 1
+}
+
+// getUniswapPath returns a random path of the given number of pairs, which
+// does not repeat tokens, as the indices of the passed tokens and pairs. With
+// the given probability, the path passes the hot pair, otherwise its first
+// pair is chosen uniformly. The path is extended at either end by random
+// pairs until it has the given length, which must not exceed the longest
+// path of the pairs.
+func getUniswapPath(pairs [][2]int, length int, hotPairShare float64, random *rand.Rand) ([]int, []int) {
+	first := 0
+	if random.Float64() >= hotPairShare {
+		first = random.Intn(len(pairs))
+	}
+	tokens := []int{pairs[first][0], pairs[first][1]}
+	if random.Intn(2) == 0 {
+		tokens[0], tokens[1] = tokens[1], tokens[0]
+	}
+	path := []int{first}
+	visited := map[int]bool{tokens[0]: true, tokens[1]: true}
+
+	// extension is a pair appended to the path, or prepended if front is set
+	type extension struct {
+		pair  int
+		token int
+		front bool
+	}
+	for len(path) < length {
+		extensions := []extension{}
+		head, tail := tokens[0], tokens[len(tokens)-1]
+		for i, pair := range pairs {
+			for side, token := range pair {
+				other := pair[1-side]
+				if visited[other] {
+					continue
+				}
+				if token == head {
+					extensions = append(extensions, extension{i, other, true})
+				}
+				if token == tail {
+					extensions = append(extensions, extension{i, other, false})
+				}
+			}
+		}
+		next := extensions[random.Intn(len(extensions))]
+		visited[next.token] = true
+		if next.front {
+			tokens = append([]int{next.token}, tokens...)
+			path = append([]int{next.pair}, path...)
+		} else {
+			tokens = append(tokens, next.token)
+			path = append(path, next.pair)
+		}
+	}
+	return tokens, path
+}
+
+// getUniswapAmount returns a random amount of tokens to be swapped, drawn from
+// the configured distribution. Amounts are at least one more than the path
+// length, since each pair passed reduces small amounts by at least one token
+// and swaps must yield tokens at every pair.
+func getUniswapAmount(config UniswapConfig, random *rand.Rand) *big.Int {
+	amount := config.Amount
+	switch config.Distribution {
+	case UniformUniswapAmounts:
+		amount = 1 + random.Int63n(2*config.Amount-1)
+	case ExponentialUniswapAmounts:
+		// large draws of large means exceed the range of int64 and are clamped
+		if drawn := random.ExpFloat64() * float64(config.Amount); drawn < math.MaxInt64 {
+			amount = int64(drawn)
+		} else {
+			amount = math.MaxInt64
+		}
+	}
+	return big.NewInt(max(amount, int64(config.PathLength)+1))
+}
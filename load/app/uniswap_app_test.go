@@ -0,0 +1,185 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUniswapConfig_DefaultIsValid(t *testing.T) {
+	if err := DefaultUniswapConfig.Check(); err != nil {
+		t.Errorf("default configuration should be valid, got %v", err)
+	}
+}
+
+func TestUniswapConfig_InvalidConfigsAreRejected(t *testing.T) {
+	invalid := map[string]func(*UniswapConfig){
+		"number of tokens must be between":    func(c *UniswapConfig) { c.Tokens = 1 },
+		"unknown topology":                    func(c *UniswapConfig) { c.Topology = "ring" },
+		"path length must be between 0 and 2": func(c *UniswapConfig) { c.Topology = StarUniswapTopology; c.PathLength = 3 },
+		"path length must be between 0 and 3": func(c *UniswapConfig) { c.PathLength = -1 },
+		"swapped amount must be between":      func(c *UniswapConfig) { c.Amount = 0 },
+		"unknown amount distribution":         func(c *UniswapConfig) { c.Distribution = "normal" },
+		"hot pair share must be between":      func(c *UniswapConfig) { c.HotPairShare = 1.5 },
+	}
+	for issue, modify := range invalid {
+		config := DefaultUniswapConfig
+		modify(&config)
+		if err := config.Check(); err == nil || !strings.Contains(err.Error(), issue) {
+			t.Errorf("issue %q was not detected, got %v", issue, err)
+		}
+	}
+}
+
+func TestGetUniswapPairs_TopologiesArePaired(t *testing.T) {
+	tests := map[UniswapTopology][][2]int{
+		ChainUniswapTopology: {{0, 1}, {1, 2}, {2, 3}},
+		StarUniswapTopology:  {{0, 1}, {0, 2}, {0, 3}},
+		FullUniswapTopology:  {{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+	}
+	for topology, want := range tests {
+		if got := getUniswapPairs(topology, 4); !slices.Equal(got, want) {
+			t.Errorf("unexpected pairs of %s, wanted %v, got %v", topology, want, got)
+		}
+	}
+}
+
+func TestGetUniswapPath_PathsAreConnectedWithoutRepeatedTokens(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	for _, topology := range []UniswapTopology{ChainUniswapTopology, StarUniswapTopology, FullUniswapTopology} {
+		for _, tokens := range []int{2, 5, 8} {
+			pairs := getUniswapPairs(topology, tokens)
+			for length := 1; length <= getMaxUniswapPathLength(topology, tokens); length++ {
+				for range 20 {
+					path, pairsPath := getUniswapPath(pairs, length, 0.5, random)
+					if len(pairsPath) != length || len(path) != length+1 {
+						t.Fatalf("unexpected length of path %v over pairs %v, wanted %d", path, pairsPath, length)
+					}
+					seen := map[int]bool{}
+					for i, token := range path {
+						if seen[token] {
+							t.Fatalf("token %d repeated in path %v", token, path)
+						}
+						seen[token] = true
+						if i == 0 {
+							continue
+						}
+						pair := pairs[pairsPath[i-1]]
+						if pair != [2]int{path[i-1], token} && pair != [2]int{token, path[i-1]} {
+							t.Fatalf("pair %v does not connect tokens %d and %d", pair, path[i-1], token)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGetUniswapPath_LongestChainIsSwappedEndToEnd(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	pairs := getUniswapPairs(ChainUniswapTopology, 4)
+	directions := map[int]int{}
+	for range 100 {
+		path, _ := getUniswapPath(pairs, 3, 0, random)
+		if !slices.Equal(path, []int{0, 1, 2, 3}) && !slices.Equal(path, []int{3, 2, 1, 0}) {
+			t.Fatalf("path %v does not pass the chain end to end", path)
+		}
+		directions[path[0]]++
+	}
+	if directions[0] == 0 || directions[3] == 0 {
+		t.Errorf("chain should be swapped in both directions, got %v", directions)
+	}
+}
+
+func TestGetUniswapPath_SwapsAreConcentratedOnHotPair(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	pairs := getUniswapPairs(FullUniswapTopology, 16)
+	for _, share := range []float64{0, 0.5, 1} {
+		hot := 0
+		for range 1000 {
+			if _, pairsPath := getUniswapPath(pairs, 1, share, random); pairsPath[0] == 0 {
+				hot++
+			}
+		}
+		// swaps not forced through the hot pair pass it by chance
+		want := 1000 * (share + (1-share)/float64(len(pairs)))
+		if float64(hot) < want-50 || float64(hot) > want+50 {
+			t.Errorf("unexpected number of swaps passing the hot pair with share %v, wanted about %v, got %d", share, want, hot)
+		}
+	}
+}
+
+func TestGetUniswapAmount_AmountsFollowDistribution(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	config := DefaultUniswapConfig
+	config.PathLength = 3
+	for _, distribution := range []UniswapAmountDistribution{ConstantUniswapAmounts, UniformUniswapAmounts, ExponentialUniswapAmounts} {
+		config.Distribution = distribution
+		sum, high := int64(0), int64(0)
+		for range 10_000 {
+			amount := getUniswapAmount(config, random).Int64()
+			if amount < 4 {
+				t.Fatalf("amount %d must exceed the path length", amount)
+			}
+			if distribution == UniformUniswapAmounts && amount >= 2*config.Amount {
+				t.Fatalf("uniform amount %d exceeds twice the mean", amount)
+			}
+			sum += amount
+			high = max(high, amount)
+		}
+		if mean := sum / 10_000; mean < 90 || mean > 110 {
+			t.Errorf("unexpected mean of %s amounts, wanted about %d, got %d", distribution, config.Amount, mean)
+		}
+		if distribution == ConstantUniswapAmounts && high != config.Amount {
+			t.Errorf("constant amounts should equal %d, got up to %d", config.Amount, high)
+		}
+		if distribution == ExponentialUniswapAmounts && high < 5*config.Amount {
+			t.Errorf("exponential amounts should be large occasionally, got at most %d", high)
+		}
+	}
+}
+
+func TestGetUniswapAmount_LargeExponentialAmountsAreClamped(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	config := DefaultUniswapConfig
+	config.Amount = maxUniswapAmount
+	config.Distribution = ExponentialUniswapAmounts
+	clamped := 0
+	for range 1000 {
+		amount := getUniswapAmount(config, random)
+		if !amount.IsInt64() || amount.Int64() < 1_000 {
+			t.Fatalf("amount %v is out of range", amount)
+		}
+		if amount.Int64() == math.MaxInt64 {
+			clamped++
+		}
+	}
+	// draws exceed twice the mean with a probability of e^-2
+	if clamped == 0 {
+		t.Errorf("no amount was clamped to the maximum")
+	}
+}
+
+func TestGetUniswapGasLimit_LongestDefaultPathIsCovered(t *testing.T) {
+	if got, want := getUniswapGasLimit(3), uint64(350_000); got != want {
+		t.Errorf("unexpected gas limit, wanted %d, got %d", want, got)
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
 	"github.com/Fantom-foundation/go-opera/evmcore"
@@ -13,7 +14,7 @@ import (
 )
 
 // RegisterValidatorNode registers a validator in the SFC contract.
-func RegisterValidatorNode(factory RpcClientFactory) (int, error) {
+func RegisterValidatorNode(ctx context.Context, factory RpcClientFactory) (int, error) {
 	newValId := 0
 
 	rpcClient, err := factory.DialRandomRpc()
@@ -27,8 +28,9 @@ func RegisterValidatorNode(factory RpcClientFactory) (int, error) {
 		return 0, fmt.Errorf("failed to get SFC contract representation; %v", err)
 	}
 
+	callOpts := &bind.CallOpts{Context: ctx}
 	var lastValId *big.Int
-	lastValId, err = SFCContract.LastValidatorID(nil)
+	lastValId, err = SFCContract.LastValidatorID(callOpts)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get validator count; %v", err)
 	}
@@ -42,6 +44,7 @@ func RegisterValidatorNode(factory RpcClientFactory) (int, error) {
 		return 0, fmt.Errorf("failed to create txOpts; %v", err)
 	}
 
+	txOpts.Context = ctx
 	txOpts.Value = big.NewInt(0).Mul(big.NewInt(5_000_000), big.NewInt(1_000_000_000_000_000_000)) // 5_000_000 FTM
 
 	validatorPubKey := validatorpk.PubKey{
@@ -54,7 +57,7 @@ func RegisterValidatorNode(factory RpcClientFactory) (int, error) {
 		return 0, fmt.Errorf("failed to create validator; %v", err)
 	}
 
-	receipt, err := GetReceipt(tx.Hash(), rpcClient)
+	receipt, err := GetReceipt(ctx, tx.Hash(), rpcClient)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get receipt; %v", err)
 	}
@@ -63,7 +66,7 @@ func RegisterValidatorNode(factory RpcClientFactory) (int, error) {
 		return 0, fmt.Errorf("failed to deploy helper contract: transaction reverted")
 	}
 
-	lastValId, err = SFCContract.LastValidatorID(nil)
+	lastValId, err = SFCContract.LastValidatorID(callOpts)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get validator count; %v", err)
 	}
@@ -73,3 +76,43 @@ func RegisterValidatorNode(factory RpcClientFactory) (int, error) {
 
 	return newValId, nil
 }
+
+// GetValidatorStatus obtains the ID of the last validator registered in the
+// SFC contract and the IDs of the validators active in the current epoch.
+// Validators registered during an epoch only become active once the epoch
+// is sealed.
+func GetValidatorStatus(ctx context.Context, factory RpcClientFactory) (int, []int, error) {
+	rpcClient, err := factory.DialRandomRpc()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to connect to network: %w", err)
+	}
+	defer rpcClient.Close()
+
+	SFCContract, err := contract.NewSFC(sfc.ContractAddress, rpcClient)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get SFC contract representation; %v", err)
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	lastValId, err := SFCContract.LastValidatorID(callOpts)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get validator count; %v", err)
+	}
+
+	// The validator set of the current epoch is recorded in the snapshot
+	// of the last sealed epoch.
+	epoch, err := SFCContract.CurrentSealedEpoch(callOpts)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get current sealed epoch; %v", err)
+	}
+	ids, err := SFCContract.GetEpochValidatorIDs(callOpts, epoch)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get validators of epoch %d; %v", epoch, err)
+	}
+
+	active := make([]int, 0, len(ids))
+	for _, id := range ids {
+		active = append(active, int(id.Int64()))
+	}
+	return int(lastValId.Int64()), active, nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// WalletApplicationType is the type of applications whose users send calls
+// through smart contract wallets.
+const WalletApplicationType = "wallet"
+
+const (
+	// walletCallGasLimit covers a call forwarded by a wallet to the Counter
+	// contract, including the first call updating the nonce of the wallet.
+	walletCallGasLimit = 150_000
+	// walletCreationGas covers the creation of a single wallet by the factory.
+	walletCreationGas = 100_000
+	// walletsPerCreation is the maximum number of wallets created by a single
+	// call of the factory.
+	walletsPerCreation = 50
+)
+
+// NewWalletApplication deploys a Counter contract, a Wallet contract and a
+// factory creating wallets delegating to it to the chain. Each user of the
+// application owns a minimal proxy wallet, which forwards signed calls to the
+// Counter contract. The load thus covers DELEGATECALLs, nested CALLs and
+// signature recovery, like account abstraction wallets do.
+func NewWalletApplication(ctx context.Context, ctxt AppContext, feederId, appId uint32, config AppConfig) (Application, error) {
+	client := ctxt.GetClient()
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID; %w", err)
+	}
+
+	// Deploy the Counter contract called through the wallets.
+	_, receipt, err := DeployContract(ctx, ctxt, contract.DeployCounter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy Counter contract; %w", err)
+	}
+	target := receipt.ContractAddress
+
+	_, receipt, err = DeployContract(ctx, ctxt, contract.DeployWallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy Wallet contract; %w", err)
+	}
+	implementation := receipt.ContractAddress
+
+	_, receipt, err = DeployContract(ctx, ctxt, func(opts *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *contract.WalletFactory, error) {
+		return contract.DeployWalletFactory(opts, backend, implementation)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy WalletFactory contract; %w", err)
+	}
+	factory := receipt.ContractAddress
+
+	accountFactory, err := NewAccountFactory(chainId, feederId, appId, config.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse ABIs for generating txs data
+	counterAbi, err := contract.CounterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	walletAbi, err := contract.WalletMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &WalletApplication{
+		counterAbi:     counterAbi,
+		walletAbi:      walletAbi,
+		target:         target,
+		factory:        factory,
+		accountFactory: accountFactory,
+	}, nil
+}
+
+// WalletApplication represents users calling a Counter contract through their
+// wallets. While the application is thread-safe, each created user should be
+// used in a single thread only.
+type WalletApplication struct {
+	counterAbi     *abi.ABI
+	walletAbi      *abi.ABI
+	target         common.Address // the Counter contract called through the wallets
+	factory        common.Address
+	accountFactory *AccountFactory
+}
+
+// CreateUsers creates a list of new users for the app, each owning a wallet
+// created by the factory.
+func (f *WalletApplication) CreateUsers(ctx context.Context, appContext AppContext, numUsers int) ([]User, error) {
+	users := make([]User, numUsers)
+	owners := make(map[common.Address]*WalletUser, numUsers)
+	addresses := make([]common.Address, numUsers)
+	for i := 0; i < numUsers; i++ {
+		// Generate a new account for each worker - avoid account nonces related bottlenecks
+		workerAccount, err := f.accountFactory.CreateAccount(ctx, appContext.GetClient())
+		if err != nil {
+			return nil, err
+		}
+		user := &WalletUser{
+			counterAbi: f.counterAbi,
+			walletAbi:  f.walletAbi,
+			sender:     workerAccount,
+			target:     f.target,
+		}
+		users[i] = user
+		owners[workerAccount.address] = user
+		addresses[i] = workerAccount.address
+	}
+
+	fundsPerUser := big.NewInt(1_000)
+	fundsPerUser = new(big.Int).Mul(fundsPerUser, big.NewInt(1_000_000_000_000_000_000)) // to wei
+	if err := appContext.FundAccounts(ctx, addresses, fundsPerUser); err != nil {
+		return nil, fmt.Errorf("failed to fund accounts; %w", err)
+	}
+
+	factory, err := contract.NewWalletFactory(f.factory, appContext.GetClient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WalletFactory contract representation; %w", err)
+	}
+	for start := 0; start < numUsers; start += walletsPerCreation {
+		created := addresses[start:min(start+walletsPerCreation, numUsers)]
+		receipt, err := appContext.Run(ctx, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			opts.GasLimit = uint64(len(created)) * walletCreationGas
+			return factory.CreateWallets(opts, created)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create wallets; %w", err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, fmt.Errorf("failed to create wallets; transaction reverted")
+		}
+		// The wallets of the owners are announced by the factory.
+		for _, log := range receipt.Logs {
+			event, err := factory.ParseWalletCreated(*log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse WalletCreated event; %w", err)
+			}
+			if user, found := owners[event.Owner]; found {
+				user.wallet = event.Wallet
+			}
+		}
+	}
+	for _, user := range owners {
+		if user.wallet == (common.Address{}) {
+			return nil, fmt.Errorf("no wallet created for user %v", user.sender.address)
+		}
+	}
+	return users, nil
+}
+
+// GetReceivedTransactions obtains the number of calls forwarded by the wallets
+// from the Counter contract.
+func (f *WalletApplication) GetReceivedTransactions(ctx context.Context, rpcClient rpc.RpcClient) (uint64, error) {
+	counterContract, err := contract.NewCounter(f.target, rpcClient)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get Counter contract representation; %w", err)
+	}
+	count, err := counterContract.GetCount(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+// WalletUser represents a user signing calls of the Counter contract, which are
+// forwarded by the user's wallet.
+// A user is supposed to be used in a single thread.
+type WalletUser struct {
+	counterAbi *abi.ABI
+	walletAbi  *abi.ABI
+	sender     *Account
+	wallet     common.Address
+	target     common.Address
+	nonce      uint64 // the nonce of the next call forwarded by the wallet
+	sentTxs    atomic.Uint64
+}
+
+func (g *WalletUser) GenerateTx(currentGasPrice *big.Int) (*types.Transaction, error) {
+	data, err := g.counterAbi.Pack("incrementCounter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tx data; %w", err)
+	}
+	input, err := getWalletInput(g.walletAbi, g.sender, g.wallet, g.nonce, g.target, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// The wallet reads its owner and updates its nonce.
+	tx, err := createTx(g.sender, g.wallet, big.NewInt(0), input, currentGasPrice, walletCallGasLimit, getStorageSlot(0), getStorageSlot(1))
+	if err != nil {
+		return nil, err
+	}
+	g.nonce++
+	g.sentTxs.Add(1)
+	return tx, nil
+}
+
+func (g *WalletUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
+
+// getWalletInput encodes a call of the given wallet forwarding the given data
+// to the target. The call is signed by the owner of the wallet over the chain
+// ID, the address of the wallet, the nonce, the target and the hash of the
+// data, such that it can not be replayed on other chains or by other wallets.
+func getWalletInput(walletAbi *abi.ABI, owner *Account, wallet common.Address, nonce uint64, target common.Address, data []byte) ([]byte, error) {
+	hash := crypto.Keccak256(
+		common.BigToHash(owner.chainID).Bytes(),
+		common.BytesToHash(wallet.Bytes()).Bytes(),
+		common.BigToHash(new(big.Int).SetUint64(nonce)).Bytes(),
+		common.BytesToHash(target.Bytes()).Bytes(),
+		crypto.Keccak256(data),
+	)
+	signature, err := crypto.Sign(hash, owner.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign call; %w", err)
+	}
+	v := signature[64] + 27
+	r, s := common.BytesToHash(signature[:32]), common.BytesToHash(signature[32:64])
+	input, err := walletAbi.Pack("execute", target, data, v, r, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare wallet call; %w", err)
+	}
+	return input, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestWallet_SignedCallsAreForwardedWithinGasLimit(t *testing.T) {
+	counterAbi, err := contract.CounterMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	walletAbi, err := contract.WalletMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	factoryAbi, err := contract.WalletFactoryMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	config := &runtime.Config{}
+	_, counter, _, err := runtime.Create(common.FromHex(contract.CounterBin), config)
+	if err != nil {
+		t.Fatalf("failed to deploy Counter contract: %v", err)
+	}
+	_, implementation, _, err := runtime.Create(common.FromHex(contract.WalletMetaData.Bin), config)
+	if err != nil {
+		t.Fatalf("failed to deploy Wallet contract: %v", err)
+	}
+	arguments, err := factoryAbi.Pack("", implementation)
+	if err != nil {
+		t.Fatalf("failed to pack constructor arguments: %v", err)
+	}
+	_, factory, _, err := runtime.Create(append(common.FromHex(contract.WalletFactoryMetaData.Bin), arguments...), config)
+	if err != nil {
+		t.Fatalf("failed to deploy WalletFactory contract: %v", err)
+	}
+
+	owners := make([]*Account, 3)
+	addresses := make([]common.Address, len(owners))
+	for i := range owners {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		owners[i] = &Account{privateKey: key, address: crypto.PubkeyToAddress(key.PublicKey), chainID: config.ChainConfig.ChainID}
+		addresses[i] = owners[i].address
+	}
+	input, err := factoryAbi.Pack("createWallets", addresses)
+	if err != nil {
+		t.Fatalf("failed to pack call: %v", err)
+	}
+	config.GasLimit = uint64(len(owners))*walletCreationGas - params.TxGas - getCalldataGas(input)
+	if _, _, err := runtime.Call(factory, input, config); err != nil {
+		t.Fatalf("failed to create wallets: %v", err)
+	}
+	if _, _, err := runtime.Call(factory, input, config); err == nil {
+		t.Errorf("wallets must not be created twice")
+	}
+
+	wallets := map[common.Address]common.Address{}
+	for _, log := range config.State.Logs() {
+		event := new(contract.WalletFactoryWalletCreated)
+		if err := factoryAbi.UnpackIntoInterface(event, "WalletCreated", log.Data); err != nil {
+			t.Fatalf("failed to unpack event: %v", err)
+		}
+		wallets[common.BytesToAddress(log.Topics[1].Bytes())] = event.Wallet
+	}
+	for _, owner := range owners {
+		wallet, found := wallets[owner.address]
+		if !found {
+			t.Fatalf("no wallet created for %v", owner.address)
+		}
+		if got := len(config.State.GetCode(wallet)); got != 45 {
+			t.Errorf("unexpected code size of wallet %v: %d", wallet, got)
+		}
+		result, _, err := runtime.Call(wallet, walletAbi.Methods["owner"].ID, config)
+		if err != nil || common.BytesToAddress(result) != owner.address {
+			t.Errorf("unexpected owner of wallet %v: %x, %v", wallet, result, err)
+		}
+		data, err := walletAbi.Pack("initialize", owners[0].address)
+		if err != nil {
+			t.Fatalf("failed to pack call: %v", err)
+		}
+		if _, _, err := runtime.Call(wallet, data, config); err == nil {
+			t.Errorf("wallet %v must not be initialized twice", wallet)
+		}
+	}
+
+	data, err := counterAbi.Pack("incrementCounter")
+	if err != nil {
+		t.Fatalf("failed to pack call: %v", err)
+	}
+	// call forwards the data through the wallet of the given owner, signed by the given signer.
+	call := func(owner, signer *Account, nonce uint64) error {
+		t.Helper()
+		input, err := getWalletInput(walletAbi, signer, wallets[owner.address], nonce, counter, data)
+		if err != nil {
+			t.Fatalf("failed to get wallet input: %v", err)
+		}
+		config.GasLimit = walletCallGasLimit - params.TxGas - getCalldataGas(input)
+		_, _, err = runtime.Call(wallets[owner.address], input, config)
+		return err
+	}
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		for _, owner := range owners {
+			if err := call(owner, owner, nonce); err != nil {
+				t.Fatalf("failed to forward call %d: %v", nonce, err)
+			}
+		}
+	}
+	if err := call(owners[0], owners[0], 2); err == nil {
+		t.Errorf("call with a used nonce must not be forwarded")
+	}
+	if err := call(owners[0], owners[0], 4); err == nil {
+		t.Errorf("call with a future nonce must not be forwarded")
+	}
+	if err := call(owners[0], owners[1], 3); err == nil {
+		t.Errorf("call signed by another account must not be forwarded")
+	}
+	otherChain := *owners[0]
+	otherChain.chainID = big.NewInt(0xFA)
+	if err := call(owners[0], &otherChain, 3); err == nil {
+		t.Errorf("call signed for another chain must not be forwarded")
+	}
+	// A call signed for the wallet of another owner is not accepted.
+	input, err = getWalletInput(walletAbi, owners[0], wallets[owners[1].address], 3, counter, data)
+	if err != nil {
+		t.Fatalf("failed to get wallet input: %v", err)
+	}
+	if _, _, err := runtime.Call(wallets[owners[0].address], input, config); err == nil {
+		t.Errorf("call signed for another wallet must not be forwarded")
+	}
+
+	result, _, err := runtime.Call(wallets[owners[0].address], walletAbi.Methods["nonce"].ID, config)
+	if err != nil || new(big.Int).SetBytes(result).Uint64() != 3 {
+		t.Errorf("unexpected nonce of wallet, wanted 3, got %x, %v", result, err)
+	}
+	result, _, err = runtime.Call(counter, counterAbi.Methods["getCount"].ID, config)
+	if err != nil {
+		t.Fatalf("failed to get count: %v", err)
+	}
+	if got, want := new(big.Int).SetBytes(result), big.NewInt(9); got.Cmp(want) != 0 {
+		t.Errorf("unexpected count, wanted %v, got %v", want, got)
+	}
+}
+
+func TestWalletUser_CallsAreSignedWithIncreasingNonces(t *testing.T) {
+	counterAbi, err := contract.CounterMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	walletAbi, err := contract.WalletMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	sender := &Account{privateKey: key, address: crypto.PubkeyToAddress(key.PublicKey), chainID: big.NewInt(0xFA)}
+	user := &WalletUser{counterAbi: counterAbi, walletAbi: walletAbi, sender: sender, wallet: common.Address{1}, target: common.Address{2}}
+	data, err := counterAbi.Pack("incrementCounter")
+	if err != nil {
+		t.Fatalf("failed to pack call: %v", err)
+	}
+
+	for i := uint64(0); i < 3; i++ {
+		tx, err := user.GenerateTx(big.NewInt(1))
+		if err != nil {
+			t.Fatalf("failed to generate tx: %v", err)
+		}
+		if *tx.To() != user.wallet {
+			t.Errorf("unexpected recipient, wanted %v, got %v", user.wallet, tx.To())
+		}
+		args, err := walletAbi.Methods["execute"].Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			t.Fatalf("failed to unpack call: %v", err)
+		}
+		if got := args[0].(common.Address); got != user.target {
+			t.Errorf("unexpected target, wanted %v, got %v", user.target, got)
+		}
+		if got := args[1].([]byte); string(got) != string(data) {
+			t.Errorf("unexpected data, wanted %x, got %x", data, got)
+		}
+		hash := crypto.Keccak256(
+			common.BigToHash(sender.chainID).Bytes(),
+			common.BytesToHash(user.wallet.Bytes()).Bytes(),
+			common.BigToHash(new(big.Int).SetUint64(i)).Bytes(),
+			common.BytesToHash(user.target.Bytes()).Bytes(),
+			crypto.Keccak256(data),
+		)
+		r, s := args[3].([32]byte), args[4].([32]byte)
+		signature := append(append(r[:], s[:]...), args[2].(uint8)-27)
+		signer, err := crypto.SigToPub(hash, signature)
+		if err != nil {
+			t.Fatalf("failed to recover signer: %v", err)
+		}
+		if got := crypto.PubkeyToAddress(*signer); got != sender.address {
+			t.Errorf("unexpected signer, wanted %v, got %v", sender.address, got)
+		}
+	}
+	if got := user.GetSentTransactions(); got != 3 {
+		t.Errorf("unexpected number of sent transactions, wanted 3, got %d", got)
+	}
+}
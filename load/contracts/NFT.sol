@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.17;
+
+// IERC721Receiver is implemented by contracts accepting tokens sent by
+// safeTransferFrom.
+interface IERC721Receiver {
+    function onERC721Received(address operator, address from, uint256 tokenId, bytes calldata data) external returns (bytes4);
+}
+
+// NFT is an ERC-721 token whose users mint tokens of their choice and transfer
+// them to others, or let approved accounts transfer them on their behalf.
+contract NFT {
+    uint256 private count = 0;
+    uint256 public totalSupply;
+    mapping(uint256 => address) private owners;
+    mapping(address => uint256) private balances;
+    mapping(uint256 => address) private tokenApprovals;
+    mapping(address => mapping(address => bool)) private operatorApprovals;
+
+    event Transfer(address indexed from, address indexed to, uint256 indexed tokenId);
+    event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId);
+    event ApprovalForAll(address indexed owner, address indexed operator, bool approved);
+
+    function supportsInterface(bytes4 interfaceId) external pure returns (bool) {
+        // ERC-165 and ERC-721
+        return interfaceId == 0x01ffc9a7 || interfaceId == 0x80ac58cd;
+    }
+
+    function balanceOf(address owner) external view returns (uint256) {
+        require(owner != address(0));
+        return balances[owner];
+    }
+
+    function ownerOf(uint256 tokenId) public view returns (address) {
+        address owner = owners[tokenId];
+        require(owner != address(0));
+        return owner;
+    }
+
+    function approve(address to, uint256 tokenId) external {
+        address owner = ownerOf(tokenId);
+        require(msg.sender == owner || operatorApprovals[owner][msg.sender]);
+        tokenApprovals[tokenId] = to;
+        emit Approval(owner, to, tokenId);
+    }
+
+    function getApproved(uint256 tokenId) external view returns (address) {
+        ownerOf(tokenId);
+        return tokenApprovals[tokenId];
+    }
+
+    function setApprovalForAll(address operator, bool approved) external {
+        operatorApprovals[msg.sender][operator] = approved;
+        emit ApprovalForAll(msg.sender, operator, approved);
+    }
+
+    function isApprovedForAll(address owner, address operator) external view returns (bool) {
+        return operatorApprovals[owner][operator];
+    }
+
+    function mint(uint256 tokenId) external {
+        require(owners[tokenId] == address(0));
+        owners[tokenId] = msg.sender;
+        balances[msg.sender]++;
+        totalSupply++;
+        count++;
+        emit Transfer(address(0), msg.sender, tokenId);
+    }
+
+    function transferFrom(address from, address to, uint256 tokenId) public {
+        address owner = ownerOf(tokenId);
+        require(owner == from && to != address(0));
+        require(msg.sender == owner || tokenApprovals[tokenId] == msg.sender || operatorApprovals[owner][msg.sender]);
+        delete tokenApprovals[tokenId];
+        owners[tokenId] = to;
+        balances[from]--;
+        balances[to]++;
+        count++;
+        emit Transfer(from, to, tokenId);
+    }
+
+    function safeTransferFrom(address from, address to, uint256 tokenId) external {
+        safeTransferFrom(from, to, tokenId, "");
+    }
+
+    function safeTransferFrom(address from, address to, uint256 tokenId, bytes memory data) public {
+        transferFrom(from, to, tokenId);
+        if (to.code.length > 0) {
+            require(IERC721Receiver(to).onERC721Received(msg.sender, from, tokenId, data) == IERC721Receiver.onERC721Received.selector);
+        }
+    }
+
+    function getCount() external view returns (uint256) {
+        return count;
+    }
+}
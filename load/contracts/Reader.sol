@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+// Reader reads and writes state on behalf of its callers, such that the load
+// covers the caching of state. The slots of a caller are numbered in the order
+// of their writes.
+contract Reader {
+    uint256 private count = 0;
+    mapping(address => mapping(uint256 => uint256)) private slots;
+
+    // readSlots reads the given slots of the caller, and writes the slots
+    // from `from` up to `to`, exclusively, with the current block number.
+    function readSlots(uint256[] calldata reads, uint256 from, uint256 to) external {
+        mapping(uint256 => uint256) storage own = slots[msg.sender];
+        // The checked sum keeps the reads from being optimized away.
+        uint256 sum = 0;
+        for (uint256 i = 0; i < reads.length; i++) {
+            sum += own[reads[i]];
+        }
+        for (uint256 key = from; key < to; key++) {
+            own[key] = block.number;
+        }
+        count++;
+    }
+
+    // readAccounts reads the balance and code size of the given accounts, and
+    // funds the given accounts with one wei each.
+    function readAccounts(address[] calldata reads, address[] calldata funded) external payable {
+        // The checked sum keeps the reads from being optimized away.
+        uint256 sum = 0;
+        for (uint256 i = 0; i < reads.length; i++) {
+            sum += reads[i].balance + reads[i].code.length;
+        }
+        for (uint256 i = 0; i < funded.length; i++) {
+            payable(funded[i]).transfer(1);
+        }
+        count++;
+    }
+
+    function getCount() external view returns (uint256) {
+        return count;
+    }
+}
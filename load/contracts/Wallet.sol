@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.17;
+
+// Wallet forwards calls signed by its owner. Wallets of the individual owners
+// are minimal proxies created by the WalletFactory, all delegating to a single
+// deployed Wallet.
+contract Wallet {
+    address public owner;
+    uint256 public nonce;
+
+    // initialize sets the owner of a newly created wallet.
+    function initialize(address _owner) external {
+        require(owner == address(0));
+        owner = _owner;
+    }
+
+    // execute forwards the given data to the target, if the chain ID, the
+    // address of the wallet, the current nonce, the target and the hash of the
+    // data are signed by the owner.
+    function execute(address target, bytes calldata data, uint8 v, bytes32 r, bytes32 s) external {
+        bytes32 hash = keccak256(abi.encode(block.chainid, address(this), nonce, target, keccak256(data)));
+        address signer = ecrecover(hash, v, r, s);
+        require(signer != address(0) && signer == owner);
+        nonce++;
+        (bool success, ) = target.call(data);
+        require(success);
+    }
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.17;
+
+// IWallet is implemented by the Wallet contract delegated to by created wallets.
+interface IWallet {
+    function initialize(address owner) external;
+}
+
+// WalletFactory creates wallets as minimal proxies (EIP-1167) delegating to a
+// Wallet contract.
+contract WalletFactory {
+    address public implementation;
+
+    event WalletCreated(address indexed owner, address wallet);
+
+    constructor(address _implementation) {
+        implementation = _implementation;
+    }
+
+    // createWallets creates and initializes a wallet for each of the given
+    // owners. Wallets are created with CREATE2 using the owner as salt.
+    function createWallets(address[] calldata owners) external {
+        address target = implementation;
+        for (uint256 i = 0; i < owners.length; i++) {
+            bytes32 salt = bytes32(uint256(uint160(owners[i])));
+            address wallet;
+            assembly {
+                // creation code of a minimal proxy delegating to the target
+                mstore(0x00, or(shr(0xe8, shl(0x60, target)), 0x3d602d80600a3d3981f3363d3d373d3d3d363d73000000))
+                mstore(0x20, or(shl(0x78, target), 0x5af43d82803e903d91602b57fd5bf3))
+                wallet := create2(0, 0x09, 0x37, salt)
+            }
+            require(wallet != address(0));
+            IWallet(wallet).initialize(owners[i]);
+            emit WalletCreated(owners[i], wallet);
+        }
+    }
+}
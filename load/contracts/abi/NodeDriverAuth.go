@@ -0,0 +1,218 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NodeDriverAuthMetaData contains all meta data concerning the NodeDriverAuth contract.
+var NodeDriverAuthMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"diff\",\"type\":\"bytes\"}],\"name\":\"updateNetworkRules\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// NodeDriverAuthABI is the input ABI used to generate the binding from.
+// Deprecated: Use NodeDriverAuthMetaData.ABI instead.
+var NodeDriverAuthABI = NodeDriverAuthMetaData.ABI
+
+// NodeDriverAuth is an auto generated Go binding around an Ethereum contract.
+type NodeDriverAuth struct {
+	NodeDriverAuthCaller     // Read-only binding to the contract
+	NodeDriverAuthTransactor // Write-only binding to the contract
+	NodeDriverAuthFilterer   // Log filterer for contract events
+}
+
+// NodeDriverAuthCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NodeDriverAuthCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NodeDriverAuthTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NodeDriverAuthTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NodeDriverAuthFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NodeDriverAuthFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NodeDriverAuthSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NodeDriverAuthSession struct {
+	Contract     *NodeDriverAuth   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NodeDriverAuthCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NodeDriverAuthCallerSession struct {
+	Contract *NodeDriverAuthCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// NodeDriverAuthTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NodeDriverAuthTransactorSession struct {
+	Contract     *NodeDriverAuthTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// NodeDriverAuthRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NodeDriverAuthRaw struct {
+	Contract *NodeDriverAuth // Generic contract binding to access the raw methods on
+}
+
+// NodeDriverAuthCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NodeDriverAuthCallerRaw struct {
+	Contract *NodeDriverAuthCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NodeDriverAuthTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NodeDriverAuthTransactorRaw struct {
+	Contract *NodeDriverAuthTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNodeDriverAuth creates a new instance of NodeDriverAuth, bound to a specific deployed contract.
+func NewNodeDriverAuth(address common.Address, backend bind.ContractBackend) (*NodeDriverAuth, error) {
+	contract, err := bindNodeDriverAuth(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeDriverAuth{NodeDriverAuthCaller: NodeDriverAuthCaller{contract: contract}, NodeDriverAuthTransactor: NodeDriverAuthTransactor{contract: contract}, NodeDriverAuthFilterer: NodeDriverAuthFilterer{contract: contract}}, nil
+}
+
+// NewNodeDriverAuthCaller creates a new read-only instance of NodeDriverAuth, bound to a specific deployed contract.
+func NewNodeDriverAuthCaller(address common.Address, caller bind.ContractCaller) (*NodeDriverAuthCaller, error) {
+	contract, err := bindNodeDriverAuth(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeDriverAuthCaller{contract: contract}, nil
+}
+
+// NewNodeDriverAuthTransactor creates a new write-only instance of NodeDriverAuth, bound to a specific deployed contract.
+func NewNodeDriverAuthTransactor(address common.Address, transactor bind.ContractTransactor) (*NodeDriverAuthTransactor, error) {
+	contract, err := bindNodeDriverAuth(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeDriverAuthTransactor{contract: contract}, nil
+}
+
+// NewNodeDriverAuthFilterer creates a new log filterer instance of NodeDriverAuth, bound to a specific deployed contract.
+func NewNodeDriverAuthFilterer(address common.Address, filterer bind.ContractFilterer) (*NodeDriverAuthFilterer, error) {
+	contract, err := bindNodeDriverAuth(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeDriverAuthFilterer{contract: contract}, nil
+}
+
+// bindNodeDriverAuth binds a generic wrapper to an already deployed contract.
+func bindNodeDriverAuth(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NodeDriverAuthMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NodeDriverAuth *NodeDriverAuthRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NodeDriverAuth.Contract.NodeDriverAuthCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NodeDriverAuth *NodeDriverAuthRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NodeDriverAuth.Contract.NodeDriverAuthTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NodeDriverAuth *NodeDriverAuthRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NodeDriverAuth.Contract.NodeDriverAuthTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NodeDriverAuth *NodeDriverAuthCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NodeDriverAuth.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NodeDriverAuth *NodeDriverAuthTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NodeDriverAuth.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NodeDriverAuth *NodeDriverAuthTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NodeDriverAuth.Contract.contract.Transact(opts, method, params...)
+}
+
+// UpdateNetworkRules is a paid mutator transaction binding the contract method 0xb9cc6b1c.
+//
+// Solidity: function updateNetworkRules(bytes diff) returns()
+func (_NodeDriverAuth *NodeDriverAuthTransactor) UpdateNetworkRules(opts *bind.TransactOpts, diff []byte) (*types.Transaction, error) {
+	return _NodeDriverAuth.contract.Transact(opts, "updateNetworkRules", diff)
+}
+
+// UpdateNetworkRules is a paid mutator transaction binding the contract method 0xb9cc6b1c.
+//
+// Solidity: function updateNetworkRules(bytes diff) returns()
+func (_NodeDriverAuth *NodeDriverAuthSession) UpdateNetworkRules(diff []byte) (*types.Transaction, error) {
+	return _NodeDriverAuth.Contract.UpdateNetworkRules(&_NodeDriverAuth.TransactOpts, diff)
+}
+
+// UpdateNetworkRules is a paid mutator transaction binding the contract method 0xb9cc6b1c.
+//
+// Solidity: function updateNetworkRules(bytes diff) returns()
+func (_NodeDriverAuth *NodeDriverAuthTransactorSession) UpdateNetworkRules(diff []byte) (*types.Transaction, error) {
+	return _NodeDriverAuth.Contract.UpdateNetworkRules(&_NodeDriverAuth.TransactOpts, diff)
+}
@@ -194,6 +194,23 @@ func (_SFC *SFCCaller) LastValidatorID(opts *bind.CallOpts) (*big.Int, error) {
 
 }
 
+// CurrentSealedEpoch is a free data retrieval call binding the contract method 0x7cacb1d6.
+//
+// Solidity: function currentSealedEpoch() view returns(uint256)
+func (_SFC *SFCCaller) CurrentSealedEpoch(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SFC.contract.Call(opts, &out, "currentSealedEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
 // GetValidator is a free data retrieval call binding the contract method 0xb5d89627.
 //
 // Solidity: function getValidator(uint256 ) view returns(uint256 status, uint256 deactivatedTime, uint256 deactivatedEpoch, uint256 receivedStake, uint256 createdEpoch, uint256 createdTime, address auth)
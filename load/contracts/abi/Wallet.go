@@ -0,0 +1,323 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// WalletMetaData contains all meta data concerning the Wallet contract.
+var WalletMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x341561000a57600080fd5b6100f6806100186000396000f33461003f576004361061003f5760003560e01c80638da5cb5b1461004d578063affed0e014610055578063c4d66de81461005d578063166eb6021461006c575b600080fd5b60005260206000f35b600054610044565b600154610044565b60005461003f57600435600055005b60243560040180358082602001610100378061010020608052466000523060205260015460405260043560605260a06000206000526044356020526064356040526084356060526000608052602060806080600060015afa50608051801561003f57600054141561003f57600154600101600155600060008261010060006004355af11561003f5700",
+}
+
+// WalletABI is the input ABI used to generate the binding from.
+// Deprecated: Use WalletMetaData.ABI instead.
+var WalletABI = WalletMetaData.ABI
+
+// WalletBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use WalletMetaData.Bin instead.
+var WalletBin = WalletMetaData.Bin
+
+// DeployWallet deploys a new Ethereum contract, binding an instance of Wallet to it.
+func DeployWallet(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Wallet, error) {
+	parsed, err := WalletMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(WalletBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Wallet{WalletCaller: WalletCaller{contract: contract}, WalletTransactor: WalletTransactor{contract: contract}, WalletFilterer: WalletFilterer{contract: contract}}, nil
+}
+
+// Wallet is an auto generated Go binding around an Ethereum contract.
+type Wallet struct {
+	WalletCaller     // Read-only binding to the contract
+	WalletTransactor // Write-only binding to the contract
+	WalletFilterer   // Log filterer for contract events
+}
+
+// WalletCaller is an auto generated read-only Go binding around an Ethereum contract.
+type WalletCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WalletTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type WalletTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WalletFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type WalletFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WalletSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type WalletSession struct {
+	Contract     *Wallet           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// WalletCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type WalletCallerSession struct {
+	Contract *WalletCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// WalletTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type WalletTransactorSession struct {
+	Contract     *WalletTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// WalletRaw is an auto generated low-level Go binding around an Ethereum contract.
+type WalletRaw struct {
+	Contract *Wallet // Generic contract binding to access the raw methods on
+}
+
+// WalletCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type WalletCallerRaw struct {
+	Contract *WalletCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// WalletTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type WalletTransactorRaw struct {
+	Contract *WalletTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewWallet creates a new instance of Wallet, bound to a specific deployed contract.
+func NewWallet(address common.Address, backend bind.ContractBackend) (*Wallet, error) {
+	contract, err := bindWallet(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{WalletCaller: WalletCaller{contract: contract}, WalletTransactor: WalletTransactor{contract: contract}, WalletFilterer: WalletFilterer{contract: contract}}, nil
+}
+
+// NewWalletCaller creates a new read-only instance of Wallet, bound to a specific deployed contract.
+func NewWalletCaller(address common.Address, caller bind.ContractCaller) (*WalletCaller, error) {
+	contract, err := bindWallet(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletCaller{contract: contract}, nil
+}
+
+// NewWalletTransactor creates a new write-only instance of Wallet, bound to a specific deployed contract.
+func NewWalletTransactor(address common.Address, transactor bind.ContractTransactor) (*WalletTransactor, error) {
+	contract, err := bindWallet(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletTransactor{contract: contract}, nil
+}
+
+// NewWalletFilterer creates a new log filterer instance of Wallet, bound to a specific deployed contract.
+func NewWalletFilterer(address common.Address, filterer bind.ContractFilterer) (*WalletFilterer, error) {
+	contract, err := bindWallet(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletFilterer{contract: contract}, nil
+}
+
+// bindWallet binds a generic wrapper to an already deployed contract.
+func bindWallet(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := WalletMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Wallet *WalletRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Wallet.Contract.WalletCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Wallet *WalletRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Wallet.Contract.WalletTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Wallet *WalletRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Wallet.Contract.WalletTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Wallet *WalletCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Wallet.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Wallet *WalletTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Wallet.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Wallet *WalletTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Wallet.Contract.contract.Transact(opts, method, params...)
+}
+
+// Nonce is a free data retrieval call binding the contract method 0xaffed0e0.
+//
+// Solidity: function nonce() view returns(uint256)
+func (_Wallet *WalletCaller) Nonce(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Wallet.contract.Call(opts, &out, "nonce")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Nonce is a free data retrieval call binding the contract method 0xaffed0e0.
+//
+// Solidity: function nonce() view returns(uint256)
+func (_Wallet *WalletSession) Nonce() (*big.Int, error) {
+	return _Wallet.Contract.Nonce(&_Wallet.CallOpts)
+}
+
+// Nonce is a free data retrieval call binding the contract method 0xaffed0e0.
+//
+// Solidity: function nonce() view returns(uint256)
+func (_Wallet *WalletCallerSession) Nonce() (*big.Int, error) {
+	return _Wallet.Contract.Nonce(&_Wallet.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Wallet *WalletCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Wallet.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Wallet *WalletSession) Owner() (common.Address, error) {
+	return _Wallet.Contract.Owner(&_Wallet.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Wallet *WalletCallerSession) Owner() (common.Address, error) {
+	return _Wallet.Contract.Owner(&_Wallet.CallOpts)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x166eb602.
+//
+// Solidity: function execute(address target, bytes data, uint8 v, bytes32 r, bytes32 s) returns()
+func (_Wallet *WalletTransactor) Execute(opts *bind.TransactOpts, target common.Address, data []byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _Wallet.contract.Transact(opts, "execute", target, data, v, r, s)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x166eb602.
+//
+// Solidity: function execute(address target, bytes data, uint8 v, bytes32 r, bytes32 s) returns()
+func (_Wallet *WalletSession) Execute(target common.Address, data []byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _Wallet.Contract.Execute(&_Wallet.TransactOpts, target, data, v, r, s)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x166eb602.
+//
+// Solidity: function execute(address target, bytes data, uint8 v, bytes32 r, bytes32 s) returns()
+func (_Wallet *WalletTransactorSession) Execute(target common.Address, data []byte, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _Wallet.Contract.Execute(&_Wallet.TransactOpts, target, data, v, r, s)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xc4d66de8.
+//
+// Solidity: function initialize(address _owner) returns()
+func (_Wallet *WalletTransactor) Initialize(opts *bind.TransactOpts, _owner common.Address) (*types.Transaction, error) {
+	return _Wallet.contract.Transact(opts, "initialize", _owner)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xc4d66de8.
+//
+// Solidity: function initialize(address _owner) returns()
+func (_Wallet *WalletSession) Initialize(_owner common.Address) (*types.Transaction, error) {
+	return _Wallet.Contract.Initialize(&_Wallet.TransactOpts, _owner)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xc4d66de8.
+//
+// Solidity: function initialize(address _owner) returns()
+func (_Wallet *WalletTransactorSession) Initialize(_owner common.Address) (*types.Transaction, error) {
+	return _Wallet.Contract.Initialize(&_Wallet.TransactOpts, _owner)
+}
@@ -0,0 +1,416 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// WalletFactoryMetaData contains all meta data concerning the WalletFactory contract.
+var WalletFactoryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_implementation\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"wallet\",\"type\":\"address\"}],\"name\":\"WalletCreated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"owners\",\"type\":\"address[]\"}],\"name\":\"createWallets\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"implementation\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x341561000a57600080fd5b6020602038036000396000516000556100f8806100276000396000f33461002957600436106100295760003560e01c80635c60da1b14610030578063c3ede5681461003c575b600080fd5b005b60005460005260206000f35b600435600401803560051b906020018091015b8181111561002e5781356000548060601b60e81c763d602d80600a3d3981f3363d3d373d3d3d363d730000001760005260781b6e5af43d82803e903d91602b57fd5bf31760205280603760096000f580156100295763c4d66de860e01b6080528160845260006000602460806000855af115610029576000527f5b03bfed1c14a02bdeceb5fa582eb1a5765fc0bc64ca0e6af4c20afc9487f08160206000a2906020019061004f56",
+}
+
+// WalletFactoryABI is the input ABI used to generate the binding from.
+// Deprecated: Use WalletFactoryMetaData.ABI instead.
+var WalletFactoryABI = WalletFactoryMetaData.ABI
+
+// WalletFactoryBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use WalletFactoryMetaData.Bin instead.
+var WalletFactoryBin = WalletFactoryMetaData.Bin
+
+// DeployWalletFactory deploys a new Ethereum contract, binding an instance of WalletFactory to it.
+func DeployWalletFactory(auth *bind.TransactOpts, backend bind.ContractBackend, _implementation common.Address) (common.Address, *types.Transaction, *WalletFactory, error) {
+	parsed, err := WalletFactoryMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(WalletFactoryBin), backend, _implementation)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &WalletFactory{WalletFactoryCaller: WalletFactoryCaller{contract: contract}, WalletFactoryTransactor: WalletFactoryTransactor{contract: contract}, WalletFactoryFilterer: WalletFactoryFilterer{contract: contract}}, nil
+}
+
+// WalletFactory is an auto generated Go binding around an Ethereum contract.
+type WalletFactory struct {
+	WalletFactoryCaller     // Read-only binding to the contract
+	WalletFactoryTransactor // Write-only binding to the contract
+	WalletFactoryFilterer   // Log filterer for contract events
+}
+
+// WalletFactoryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type WalletFactoryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WalletFactoryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type WalletFactoryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WalletFactoryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type WalletFactoryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// WalletFactorySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type WalletFactorySession struct {
+	Contract     *WalletFactory    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// WalletFactoryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type WalletFactoryCallerSession struct {
+	Contract *WalletFactoryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// WalletFactoryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type WalletFactoryTransactorSession struct {
+	Contract     *WalletFactoryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// WalletFactoryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type WalletFactoryRaw struct {
+	Contract *WalletFactory // Generic contract binding to access the raw methods on
+}
+
+// WalletFactoryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type WalletFactoryCallerRaw struct {
+	Contract *WalletFactoryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// WalletFactoryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type WalletFactoryTransactorRaw struct {
+	Contract *WalletFactoryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewWalletFactory creates a new instance of WalletFactory, bound to a specific deployed contract.
+func NewWalletFactory(address common.Address, backend bind.ContractBackend) (*WalletFactory, error) {
+	contract, err := bindWalletFactory(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletFactory{WalletFactoryCaller: WalletFactoryCaller{contract: contract}, WalletFactoryTransactor: WalletFactoryTransactor{contract: contract}, WalletFactoryFilterer: WalletFactoryFilterer{contract: contract}}, nil
+}
+
+// NewWalletFactoryCaller creates a new read-only instance of WalletFactory, bound to a specific deployed contract.
+func NewWalletFactoryCaller(address common.Address, caller bind.ContractCaller) (*WalletFactoryCaller, error) {
+	contract, err := bindWalletFactory(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletFactoryCaller{contract: contract}, nil
+}
+
+// NewWalletFactoryTransactor creates a new write-only instance of WalletFactory, bound to a specific deployed contract.
+func NewWalletFactoryTransactor(address common.Address, transactor bind.ContractTransactor) (*WalletFactoryTransactor, error) {
+	contract, err := bindWalletFactory(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletFactoryTransactor{contract: contract}, nil
+}
+
+// NewWalletFactoryFilterer creates a new log filterer instance of WalletFactory, bound to a specific deployed contract.
+func NewWalletFactoryFilterer(address common.Address, filterer bind.ContractFilterer) (*WalletFactoryFilterer, error) {
+	contract, err := bindWalletFactory(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletFactoryFilterer{contract: contract}, nil
+}
+
+// bindWalletFactory binds a generic wrapper to an already deployed contract.
+func bindWalletFactory(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := WalletFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_WalletFactory *WalletFactoryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _WalletFactory.Contract.WalletFactoryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_WalletFactory *WalletFactoryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _WalletFactory.Contract.WalletFactoryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_WalletFactory *WalletFactoryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _WalletFactory.Contract.WalletFactoryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_WalletFactory *WalletFactoryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _WalletFactory.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_WalletFactory *WalletFactoryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _WalletFactory.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_WalletFactory *WalletFactoryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _WalletFactory.Contract.contract.Transact(opts, method, params...)
+}
+
+// Implementation is a free data retrieval call binding the contract method 0x5c60da1b.
+//
+// Solidity: function implementation() view returns(address)
+func (_WalletFactory *WalletFactoryCaller) Implementation(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _WalletFactory.contract.Call(opts, &out, "implementation")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Implementation is a free data retrieval call binding the contract method 0x5c60da1b.
+//
+// Solidity: function implementation() view returns(address)
+func (_WalletFactory *WalletFactorySession) Implementation() (common.Address, error) {
+	return _WalletFactory.Contract.Implementation(&_WalletFactory.CallOpts)
+}
+
+// Implementation is a free data retrieval call binding the contract method 0x5c60da1b.
+//
+// Solidity: function implementation() view returns(address)
+func (_WalletFactory *WalletFactoryCallerSession) Implementation() (common.Address, error) {
+	return _WalletFactory.Contract.Implementation(&_WalletFactory.CallOpts)
+}
+
+// CreateWallets is a paid mutator transaction binding the contract method 0xc3ede568.
+//
+// Solidity: function createWallets(address[] owners) returns()
+func (_WalletFactory *WalletFactoryTransactor) CreateWallets(opts *bind.TransactOpts, owners []common.Address) (*types.Transaction, error) {
+	return _WalletFactory.contract.Transact(opts, "createWallets", owners)
+}
+
+// CreateWallets is a paid mutator transaction binding the contract method 0xc3ede568.
+//
+// Solidity: function createWallets(address[] owners) returns()
+func (_WalletFactory *WalletFactorySession) CreateWallets(owners []common.Address) (*types.Transaction, error) {
+	return _WalletFactory.Contract.CreateWallets(&_WalletFactory.TransactOpts, owners)
+}
+
+// CreateWallets is a paid mutator transaction binding the contract method 0xc3ede568.
+//
+// Solidity: function createWallets(address[] owners) returns()
+func (_WalletFactory *WalletFactoryTransactorSession) CreateWallets(owners []common.Address) (*types.Transaction, error) {
+	return _WalletFactory.Contract.CreateWallets(&_WalletFactory.TransactOpts, owners)
+}
+
+// WalletFactoryWalletCreatedIterator is returned from FilterWalletCreated and is used to iterate over the raw logs and unpacked data for WalletCreated events raised by the WalletFactory contract.
+type WalletFactoryWalletCreatedIterator struct {
+	Event *WalletFactoryWalletCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *WalletFactoryWalletCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(WalletFactoryWalletCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(WalletFactoryWalletCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *WalletFactoryWalletCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *WalletFactoryWalletCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// WalletFactoryWalletCreated represents a WalletCreated event raised by the WalletFactory contract.
+type WalletFactoryWalletCreated struct {
+	Owner  common.Address
+	Wallet common.Address
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterWalletCreated is a free log retrieval operation binding the contract event 0x5b03bfed1c14a02bdeceb5fa582eb1a5765fc0bc64ca0e6af4c20afc9487f081.
+//
+// Solidity: event WalletCreated(address indexed owner, address wallet)
+func (_WalletFactory *WalletFactoryFilterer) FilterWalletCreated(opts *bind.FilterOpts, owner []common.Address) (*WalletFactoryWalletCreatedIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _WalletFactory.contract.FilterLogs(opts, "WalletCreated", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletFactoryWalletCreatedIterator{contract: _WalletFactory.contract, event: "WalletCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchWalletCreated is a free log subscription operation binding the contract event 0x5b03bfed1c14a02bdeceb5fa582eb1a5765fc0bc64ca0e6af4c20afc9487f081.
+//
+// Solidity: event WalletCreated(address indexed owner, address wallet)
+func (_WalletFactory *WalletFactoryFilterer) WatchWalletCreated(opts *bind.WatchOpts, sink chan<- *WalletFactoryWalletCreated, owner []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _WalletFactory.contract.WatchLogs(opts, "WalletCreated", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(WalletFactoryWalletCreated)
+				if err := _WalletFactory.contract.UnpackLog(event, "WalletCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWalletCreated is a log parse operation binding the contract event 0x5b03bfed1c14a02bdeceb5fa582eb1a5765fc0bc64ca0e6af4c20afc9487f081.
+//
+// Solidity: event WalletCreated(address indexed owner, address wallet)
+func (_WalletFactory *WalletFactoryFilterer) ParseWalletCreated(log types.Log) (*WalletFactoryWalletCreated, error) {
+	event := new(WalletFactoryWalletCreated)
+	if err := _WalletFactory.contract.UnpackLog(event, "WalletCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
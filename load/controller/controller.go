@@ -40,14 +40,15 @@ type AppController struct {
 	trigger     chan struct{}
 	users       []app.User
 	rpcClient   rpc.RpcClient
+	rpcMutex    sync.Mutex // guards rpcClient, which is queried and re-dialed concurrently
 }
 
-func NewAppController(application app.Application, shaper shaper.Shaper, numUsers int, context app.AppContext, network driver.Network) (*AppController, error) {
+func NewAppController(ctx context.Context, application app.Application, shaper shaper.Shaper, numUsers int, appContext app.AppContext, network driver.Network) (*AppController, error) {
 	trigger := make(chan struct{}, 100)
 
 	// create users for this application
 	log.Printf("starting initialization of %d users\n", numUsers)
-	users, err := application.CreateUsers(context, numUsers)
+	users, err := application.CreateUsers(ctx, appContext, numUsers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create users for app; %v", err)
 	}
@@ -59,12 +60,16 @@ func NewAppController(application app.Application, shaper shaper.Shaper, numUser
 		network:     network,
 		trigger:     trigger,
 		users:       users,
-		rpcClient:   context.GetClient(),
+		rpcClient:   appContext.GetClient(),
 	}, nil
 }
 
 func (ac *AppController) Run(ctx context.Context) error {
-	defer ac.rpcClient.Close()
+	defer func() {
+		ac.rpcMutex.Lock()
+		defer ac.rpcMutex.Unlock()
+		ac.rpcClient.Close()
+	}()
 
 	// start generators for each user
 	var done sync.WaitGroup
@@ -79,7 +84,7 @@ func (ac *AppController) Run(ctx context.Context) error {
 
 	var pending float64
 	lastUpdate := time.Now()
-	ac.shaper.Start(lastUpdate, ac)
+	ac.shaper.Start(lastUpdate, &loadInfo{ctx: ctx, controller: ac})
 
 	for {
 		// re-plenish the number of pending messages
@@ -127,22 +132,69 @@ func (ac *AppController) GetSentTransactions() (uint64, error) {
 	return sum, nil
 }
 
-func (ac *AppController) GetReceivedTransactions() (uint64, error) {
+func (ac *AppController) GetReceivedTransactions(ctx context.Context) (uint64, error) {
+	var res uint64
+	err := ac.withRpcClient(func(rpcClient rpc.RpcClient) error {
+		var err error
+		res, err = ac.application.GetReceivedTransactions(ctx, rpcClient)
+		return err
+	})
+	return res, err
+}
+
+// GetReceivedTransactionsByComponent returns the number of transactions
+// received by each component of a mixed application, or nil if the application
+// is not mixed.
+func (ac *AppController) GetReceivedTransactionsByComponent(ctx context.Context) (map[string]uint64, error) {
+	mixed, ok := ac.application.(app.MixedApplication)
+	if !ok {
+		return nil, nil
+	}
+	var res map[string]uint64
+	err := ac.withRpcClient(func(rpcClient rpc.RpcClient) error {
+		var err error
+		res, err = mixed.GetReceivedTransactionsByComponent(ctx, rpcClient)
+		return err
+	})
+	return res, err
+}
+
+// loadInfo provides the load state of a running application to its shaper.
+// Queries are aborted once the application is stopped.
+type loadInfo struct {
+	ctx        context.Context
+	controller *AppController
+}
+
+func (i *loadInfo) GetSentTransactions() (uint64, error) {
+	return i.controller.GetSentTransactions()
+}
+
+func (i *loadInfo) GetReceivedTransactions() (uint64, error) {
+	return i.controller.GetReceivedTransactions(i.ctx)
+}
+
+// withRpcClient runs the given query on the network, re-connecting to a random
+// node if the query fails. Queries are serialized, such that no query uses a
+// client closed by the re-connect of another one.
+func (ac *AppController) withRpcClient(query func(rpc.RpcClient) error) error {
+	ac.rpcMutex.Lock()
+	defer ac.rpcMutex.Unlock()
 	for retry := 0; ; retry++ {
 		// fetch transaction data from the network
-		res, err := ac.application.GetReceivedTransactions(ac.rpcClient)
+		err := query(ac.rpcClient)
 		if err == nil {
-			return res, nil
+			return nil
 		}
 		if retry >= 5 {
-			return 0, err
+			return err
 		}
 
 		// attempt a re-connect
 		ac.rpcClient.Close()
 		ac.rpcClient, err = ac.network.DialRandomRpc()
 		if err != nil {
-			return 0, fmt.Errorf("failed to dial random RPC; %v", err)
+			return fmt.Errorf("failed to dial random RPC; %v", err)
 		}
 	}
 }
@@ -76,7 +76,7 @@ func TestLoadGeneration_CanRealizeConstantTrafficShape(t *testing.T) {
 			for i := range users {
 				users[i] = user
 			}
-			application.EXPECT().CreateUsers(gomock.Any(), 100).AnyTimes().Return(users, nil)
+			application.EXPECT().CreateUsers(gomock.Any(), gomock.Any(), 100).AnyTimes().Return(users, nil)
 
 			rpcClient.EXPECT().SuggestGasPrice(gomock.Any()).AnyTimes().Return(big.NewInt(0), nil)
 			user.EXPECT().GenerateTx(gomock.Any()).AnyTimes().Return(&transaction, nil)
@@ -85,11 +85,11 @@ func TestLoadGeneration_CanRealizeConstantTrafficShape(t *testing.T) {
 			clientFactory.EXPECT().DialRandomRpc().AnyTimes().Return(rpcClient, nil)
 
 			shaper := shaper.NewConstantShaper(float64(rate))
-			appContext, err := app.NewContext(clientFactory, treasure)
+			appContext, err := app.NewContext(context.Background(), clientFactory, treasure)
 			if err != nil {
 				t.Fatalf("failed to create app context: %v", err)
 			}
-			controller, err := controller.NewAppController(application, shaper, 100, appContext, net)
+			controller, err := controller.NewAppController(context.Background(), application, shaper, 100, appContext, net)
 			if err != nil {
 				t.Fatalf("failed to create app controller: %v", err)
 			}
@@ -174,3 +174,62 @@ func (c *RateCheck) GetNumberOfUnderflows() int {
 func (c *RateCheck) GetNumberOfOverflows() int {
 	return int(c.overflows.Load())
 }
+
+func TestAppController_ConcurrentQueriesDoNotUseClosedClients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	application := app.NewMockApplication(ctrl)
+	appContext := app.NewMockAppContext(ctrl)
+
+	var mutex sync.Mutex
+	closed := map[rpc.RpcClient]bool{}
+	newClient := func() rpc.RpcClient {
+		client := rpc.NewMockRpcClient(ctrl)
+		client.EXPECT().Close().AnyTimes().Do(func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			closed[client] = true
+		})
+		return client
+	}
+	net.EXPECT().DialRandomRpc().AnyTimes().DoAndReturn(func() (rpc.RpcClient, error) {
+		return newClient(), nil
+	})
+	appContext.EXPECT().GetClient().Return(newClient())
+	application.EXPECT().CreateUsers(gomock.Any(), gomock.Any(), 1).Return([]app.User{app.NewMockUser(ctrl)}, nil)
+
+	// every second query fails, forcing a re-connect
+	var queries atomic.Int32
+	application.EXPECT().GetReceivedTransactions(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, client rpc.RpcClient) (uint64, error) {
+		time.Sleep(time.Millisecond)
+		mutex.Lock()
+		isClosed := closed[client]
+		mutex.Unlock()
+		if isClosed {
+			t.Errorf("query used a closed client")
+		}
+		if queries.Add(1)%2 == 0 {
+			return 0, fmt.Errorf("injected error")
+		}
+		return 1, nil
+	})
+
+	controller, err := controller.NewAppController(context.Background(), application, shaper.NewConstantShaper(0), 1, appContext, net)
+	if err != nil {
+		t.Fatalf("failed to create app controller: %v", err)
+	}
+
+	var done sync.WaitGroup
+	for range 4 {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			for range 10 {
+				if _, err := controller.GetReceivedTransactions(context.Background()); err != nil {
+					t.Errorf("failed to get received transactions: %v", err)
+				}
+			}
+		}()
+	}
+	done.Wait()
+}
@@ -50,7 +50,7 @@ func TestMockedTrafficGenerating(t *testing.T) {
 	mockedNetwork.EXPECT().DialRandomRpc().Return(mockedRpcClient, nil)
 
 	mockedApp := app.NewMockApplication(mockCtrl)
-	mockedApp.EXPECT().CreateUsers(appContext, numUsers).Return([]app.User{mockUser, mockUser}, nil)
+	mockedApp.EXPECT().CreateUsers(gomock.Any(), appContext, numUsers).Return([]app.User{mockUser, mockUser}, nil)
 
 	// app should be called 10-times to generate 10 txs
 	mockedNetwork.EXPECT().DialRandomRpc().Return(mockedRpcClient, nil).MaxTimes(11)
@@ -62,7 +62,7 @@ func TestMockedTrafficGenerating(t *testing.T) {
 	// use constant shaper
 	constantShaper := shaper.NewConstantShaper(100) // 100 txs/sec
 
-	appController, err := NewAppController(mockedApp, constantShaper, numUsers, appContext, mockedNetwork)
+	appController, err := NewAppController(context.Background(), mockedApp, constantShaper, numUsers, appContext, mockedNetwork)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -33,7 +33,7 @@ const FakeNetworkID = 0xfa3
 
 func TestTrafficGenerating(t *testing.T) {
 	// run local network of one node
-	net, err := local.NewLocalNetwork(&driver.NetworkConfig{NumberOfValidators: 1})
+	net, err := local.NewLocalNetwork(context.Background(), &driver.NetworkConfig{NumberOfValidators: 1})
 	if err != nil {
 		t.Fatalf("failed to create new local network: %v", err)
 	}
@@ -44,12 +44,12 @@ func TestTrafficGenerating(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	appContext, err := app.NewContext(net, primaryAccount)
+	appContext, err := app.NewContext(context.Background(), net, primaryAccount)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	application, err := app.NewERC20Application(appContext, 0, 0)
+	application, err := app.NewERC20Application(context.Background(), appContext, 0, 0, app.AppConfig{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,7 +57,7 @@ func TestTrafficGenerating(t *testing.T) {
 	constantShaper := shaper.NewConstantShaper(30.0) // 30 txs/sec
 
 	numGenerators := 5 // 5 parallel workers
-	app, err := controller.NewAppController(application, constantShaper, numGenerators, appContext, net)
+	app, err := controller.NewAppController(context.Background(), application, constantShaper, numGenerators, appContext, net)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -84,7 +84,7 @@ func TestTrafficGenerating(t *testing.T) {
 		t.Fatalf("failed to fetch sent transactions: %v", err)
 	}
 
-	if received, err := app.GetReceivedTransactions(); err != nil || received != sum {
+	if received, err := app.GetReceivedTransactions(context.Background()); err != nil || received != sum {
 		t.Errorf("invalid number of received transactions on the network, wanted %d, got %d, err %v", sum, received, err)
 	}
 
@@ -10,14 +10,26 @@ datadir="/datadir"
 echo "val id=${VALIDATOR_ID}"
 echo "genesis validator count=${VALIDATORS_COUNT}"
 
-# Call set genesis script - add balance to all possible validators
-# VALIDATOR_COUNT defines genesis validator count
-# TODO change 100 funded validator addresses to specific number
-./set_genesis.sh genesis.json 100 ${VALIDATORS_COUNT} ${MAX_BLOCK_GAS} ${MAX_EPOCH_GAS}
-
-# Initialize datadir
-mkdir /datadir
-./sonictool --datadir ${datadir} genesis json --experimental genesis.json
+# Initialize datadir, either from the initial state of the scenario, from a
+# snapshot of the running network, or from the genesis built by Norma for the
+# whole network. The datadir may be mounted from the host to be kept after the
+# run.
+mkdir -p ${datadir}
+case "${INITIAL_STATE}" in
+	genesis)
+		echo "Initializing datadir from initial state genesis"
+		./sonictool --datadir ${datadir} genesis /initial_state.g
+		;;
+	datadir)
+		echo "Initializing datadir from initial state datadir"
+		cp -a /initial_state/. ${datadir}/
+		# Each node has to use its own identity and keys.
+		rm -rf ${datadir}/keystore ${datadir}/nodekey ${datadir}/*/nodekey
+		;;
+	*)
+		./sonictool --datadir ${datadir} genesis json --experimental genesis.json
+		;;
+esac
 
 ##
 ## if $VALIDATOR_ID is set, it is a validator
@@ -38,15 +50,47 @@ VALIDATOR_PASSWORD="password.txt"
 val_flag=""
 if [[ $VALIDATOR_ID -ne 0 ]]
 then
-	echo "Sonic is now running as validator"
 	echo "val.id=${VALIDATOR_ID}"
 	echo "pubkey=${VALIDATOR_PUBKEY}"
 	echo "address=${VALIDATOR_ADDRESS}"
 	val_flag="--validator.id ${VALIDATOR_ID} --validator.pubkey ${VALIDATOR_PUBKEY} --validator.password ${VALIDATOR_PASSWORD} --mode rpc"
-else
-	echo "Sonic is now running as an observer"
 fi
 
+# Each node type runs with its own set of services:
+#  - validators offer the APIs needed to run and check the network,
+#  - RPC nodes serve users with the full API set and a larger tx pool,
+#  - observers only follow the chain and disable all APIs but admin.
+# NODE_TYPE defaults to a validator or an RPC node depending on VALIDATOR_ID,
+# observers have to be requested explicitly.
+if [[ -z "${NODE_TYPE}" ]]; then
+	if [[ $VALIDATOR_ID -ne 0 ]]; then
+		NODE_TYPE="validator"
+	else
+		NODE_TYPE="rpc"
+	fi
+fi
+case "${NODE_TYPE}" in
+	validator)
+		echo "Sonic is now running as validator"
+		apis="admin,eth,ftm"
+		type_flags=""
+		;;
+	rpc)
+		echo "Sonic is now running as RPC node"
+		apis="admin,eth,ftm,net,web3,txpool,debug,dag,abft"
+		type_flags="--txpool.globalslots 65536 --txpool.globalqueue 16384 --txpool.accountslots 256 --txpool.accountqueue 256"
+		;;
+	observer)
+		echo "Sonic is now running as an observer"
+		apis="admin"
+		type_flags=""
+		;;
+	*)
+		echo "Unknown node type: ${NODE_TYPE}"
+		exit 1
+		;;
+esac
+
 # Create config.toml
 # when network starts with only one genesis validator, then he will not wait to start emitting
 # if there are two or more validators at genesis they have to wait 5 seconds after connecting to the network
@@ -71,16 +115,50 @@ if [[ -n "${NETWORK_LATENCY}" ]]; then
   tc qdisc add dev eth1 root netem delay $NETWORK_LATENCY
 fi
 
+# Disable the discovery of peers if the network manages a fixed topology.
+discovery_flag=""
+if [[ "${PEER_DISCOVERY}" == "false" ]]; then
+  echo "Peer discovery is disabled"
+  discovery_flag="--nodiscover"
+fi
+
+# Enable the APIs of optional services requested for the node.
+if [[ -n "${SERVICE_APIS}" ]]; then
+  apis="${apis},${SERVICE_APIS}"
+fi
+
+# Signals stopping the container are forwarded to sonic to shut it down.
+trap 'kill -INT ${pid} 2>/dev/null; wait ${pid}; exit' INT TERM
 
-# Start sonic as part of a fake net with RPC service.
-./sonicd \
-    --datadir=${datadir} \
-    ${val_flag} \
-    --http --http.addr 0.0.0.0 --http.port 18545 --http.api admin,eth,ftm \
-    --ws --ws.addr 0.0.0.0 --ws.port 18546 --ws.api admin,eth,ftm \
-    --pprof --pprof.addr 0.0.0.0 \
-    --nat=extip:${external_ip} \
-    --metrics \
-    --metrics.expensive \
-    --config config.toml \
-    --datadir.minfreedisk 0
+# Start sonic as part of a fake net with RPC service. If an export of the
+# state was requested when sonic stops, the state of the now consistent
+# datadir is exported and sonic is started again, see exportState of the
+# OperaNode.
+export_request="/tmp/export.request"
+export_state="/tmp/export.g"
+while true; do
+  ./sonicd \
+      --datadir=${datadir} \
+      ${val_flag} \
+      ${discovery_flag} \
+      ${type_flags} \
+      ${SERVICE_FLAGS} \
+      --http --http.addr 0.0.0.0 --http.port 18545 --http.api ${apis} \
+      --ws --ws.addr 0.0.0.0 --ws.port 18546 --ws.api ${apis} \
+      --pprof --pprof.addr 0.0.0.0 \
+      --nat=extip:${external_ip} \
+      --metrics \
+      --metrics.expensive \
+      --config config.toml \
+      --datadir.minfreedisk 0 &
+  pid=$!
+  echo ${pid} > /tmp/sonicd.pid
+  wait ${pid}
+  code=$?
+  if [[ ! -f ${export_request} ]]; then
+    exit ${code}
+  fi
+  echo "Exporting the state of the node"
+  ./sonictool --datadir ${datadir} genesis export ${export_state}
+  rm -f ${export_request}
+done